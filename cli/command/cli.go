@@ -43,18 +43,20 @@ type Cli interface {
 	ConfigFile() *configfile.ConfigFile
 	ServerInfo() ServerInfo
 	NotaryClient(imgRefAndAuth trust.ImageRefAndAuth, actions []string) (notaryclient.Repository, error)
+	TemplatingClient() TemplatingClient
 }
 
 // DockerCli is an instance the docker command line client.
 // Instances of the client can be returned from NewDockerCli.
 type DockerCli struct {
-	configFile     *configfile.ConfigFile
-	in             *InStream
-	out            *OutStream
-	err            io.Writer
-	client         client.APIClient
-	defaultVersion string
-	server         ServerInfo
+	configFile       *configfile.ConfigFile
+	in               *InStream
+	out              *OutStream
+	err              io.Writer
+	client           client.APIClient
+	templatingClient TemplatingClient
+	defaultVersion   string
+	server           ServerInfo
 }
 
 // DefaultVersion returns api.defaultVersion or DOCKER_API_VERSION if specified.
@@ -67,6 +69,12 @@ func (cli *DockerCli) Client() client.APIClient {
 	return cli.client
 }
 
+// TemplatingClient returns the client creating configs and secrets that have
+// a template driver
+func (cli *DockerCli) TemplatingClient() TemplatingClient {
+	return cli.templatingClient
+}
+
 // Out returns the writer used for stdout
 func (cli *DockerCli) Out() *OutStream {
 	return cli.out
@@ -125,6 +133,10 @@ func (cli *DockerCli) Initialize(opts *cliflags.ClientOptions) error {
 	if err != nil {
 		return err
 	}
+	cli.templatingClient, err = newTemplatingClientFromFlags(opts.Common, cli.configFile, cli.client)
+	if err != nil {
+		return err
+	}
 	cli.initializeFromClient()
 	return nil
 }
@@ -188,11 +200,7 @@ func NewAPIClientFromFlags(opts *cliflags.CommonOptions, configFile *configfile.
 		return &client.Client{}, err
 	}
 
-	customHeaders := configFile.HTTPHeaders
-	if customHeaders == nil {
-		customHeaders = map[string]string{}
-	}
-	customHeaders["User-Agent"] = UserAgent()
+	customHeaders := newCustomHeaders(configFile)
 
 	verStr := api.DefaultVersion
 	if tmpStr := os.Getenv("DOCKER_API_VERSION"); tmpStr != "" {
@@ -207,6 +215,25 @@ func NewAPIClientFromFlags(opts *cliflags.CommonOptions, configFile *configfile.
 	return client.NewClient(host, verStr, httpClient, customHeaders)
 }
 
+// newTemplatingClientFromFlags creates a new TemplatingClient for the daemon
+// of apiClient, from command line flags
+func newTemplatingClientFromFlags(opts *cliflags.CommonOptions, configFile *configfile.ConfigFile, apiClient client.APIClient) (TemplatingClient, error) {
+	httpClient, err := newHTTPClient(apiClient.DaemonHost(), opts.TLSOptions)
+	if err != nil {
+		return nil, err
+	}
+	return newTemplatingClient(apiClient, httpClient, newCustomHeaders(configFile))
+}
+
+func newCustomHeaders(configFile *configfile.ConfigFile) map[string]string {
+	customHeaders := configFile.HTTPHeaders
+	if customHeaders == nil {
+		customHeaders = map[string]string{}
+	}
+	customHeaders["User-Agent"] = UserAgent()
+	return customHeaders
+}
+
 func getServerHost(hosts []string, tlsOptions *tlsconfig.Options) (string, error) {
 	var host string
 	switch len(hosts) {
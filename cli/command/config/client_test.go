@@ -1,6 +1,7 @@
 package config
 
 import (
+	"github.com/docker/cli/cli/command"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/swarm"
 	"github.com/docker/docker/client"
@@ -13,6 +14,8 @@ type fakeClient struct {
 	configInspectFunc func(string) (swarm.Config, []byte, error)
 	configListFunc    func(types.ConfigListOptions) ([]swarm.Config, error)
 	configRemoveFunc  func(string) error
+	serviceListFunc   func(types.ServiceListOptions) ([]swarm.Service, error)
+	serviceUpdateFunc func(string, swarm.Version, swarm.ServiceSpec) (types.ServiceUpdateResponse, error)
 }
 
 func (c *fakeClient) ConfigCreate(ctx context.Context, spec swarm.ConfigSpec) (types.ConfigCreateResponse, error) {
@@ -42,3 +45,29 @@ func (c *fakeClient) ConfigRemove(ctx context.Context, name string) error {
 	}
 	return nil
 }
+
+func (c *fakeClient) ServiceList(ctx context.Context, options types.ServiceListOptions) ([]swarm.Service, error) {
+	if c.serviceListFunc != nil {
+		return c.serviceListFunc(options)
+	}
+	return []swarm.Service{}, nil
+}
+
+func (c *fakeClient) ServiceUpdate(ctx context.Context, serviceID string, version swarm.Version, service swarm.ServiceSpec, options types.ServiceUpdateOptions) (types.ServiceUpdateResponse, error) {
+	if c.serviceUpdateFunc != nil {
+		return c.serviceUpdateFunc(serviceID, version, service)
+	}
+	return types.ServiceUpdateResponse{}, nil
+}
+
+type fakeTemplatingClient struct {
+	command.TemplatingClient
+	configCreateFunc func(command.ConfigSpecWithTemplating) (types.ConfigCreateResponse, error)
+}
+
+func (c *fakeTemplatingClient) ConfigCreate(ctx context.Context, spec command.ConfigSpecWithTemplating) (types.ConfigCreateResponse, error) {
+	if c.configCreateFunc != nil {
+		return c.configCreateFunc(spec)
+	}
+	return types.ConfigCreateResponse{}, nil
+}
@@ -20,7 +20,10 @@ func NewConfigCommand(dockerCli command.Cli) *cobra.Command {
 		newConfigListCommand(dockerCli),
 		newConfigCreateCommand(dockerCli),
 		newConfigInspectCommand(dockerCli),
+		newConfigPruneCommand(dockerCli),
 		newConfigRemoveCommand(dockerCli),
+		newConfigRenderCommand(dockerCli),
+		newConfigRotateCommand(dockerCli),
 	)
 	return cmd
 }
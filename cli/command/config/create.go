@@ -8,6 +8,8 @@ import (
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/opts"
+	"github.com/docker/cli/templates"
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/swarm"
 	"github.com/docker/docker/pkg/system"
 	"github.com/pkg/errors"
@@ -16,9 +18,10 @@ import (
 )
 
 type createOptions struct {
-	name   string
-	file   string
-	labels opts.ListOpts
+	name           string
+	templateDriver string
+	file           string
+	labels         opts.ListOpts
 }
 
 func newConfigCreateCommand(dockerCli command.Cli) *cobra.Command {
@@ -38,6 +41,8 @@ func newConfigCreateCommand(dockerCli command.Cli) *cobra.Command {
 	}
 	flags := cmd.Flags()
 	flags.VarP(&createOpts.labels, "label", "l", "Config labels")
+	flags.StringVar(&createOpts.templateDriver, "template-driver", "", "Template driver")
+	flags.SetAnnotation("template-driver", "version", []string{"1.37"})
 
 	return cmd
 }
@@ -46,19 +51,14 @@ func runConfigCreate(dockerCli command.Cli, options createOptions) error {
 	client := dockerCli.Client()
 	ctx := context.Background()
 
-	var in io.Reader = dockerCli.In()
-	if options.file != "-" {
-		file, err := system.OpenSequential(options.file)
-		if err != nil {
-			return err
-		}
-		in = file
-		defer file.Close()
-	}
-
-	configData, err := ioutil.ReadAll(in)
+	configData, err := readConfigData(dockerCli.In(), options.file)
 	if err != nil {
-		return errors.Errorf("Error reading content from %q: %v", options.file, err)
+		return err
+	}
+	if options.templateDriver == templates.PayloadTemplateDriver {
+		if _, err := templates.ParsePayload(string(configData), templates.PayloadFunctions{}); err != nil {
+			return errors.Wrap(err, "invalid config template")
+		}
 	}
 
 	spec := swarm.ConfigSpec{
@@ -69,7 +69,15 @@ func runConfigCreate(dockerCli command.Cli, options createOptions) error {
 		Data: configData,
 	}
 
-	r, err := client.ConfigCreate(ctx, spec)
+	var r types.ConfigCreateResponse
+	if options.templateDriver != "" {
+		r, err = dockerCli.TemplatingClient().ConfigCreate(ctx, command.ConfigSpecWithTemplating{
+			ConfigSpec: spec,
+			Templating: &swarm.Driver{Name: options.templateDriver},
+		})
+	} else {
+		r, err = client.ConfigCreate(ctx, spec)
+	}
 	if err != nil {
 		return err
 	}
@@ -77,3 +85,20 @@ func runConfigCreate(dockerCli command.Cli, options createOptions) error {
 	fmt.Fprintln(dockerCli.Out(), r.ID)
 	return nil
 }
+
+func readConfigData(in io.Reader, file string) ([]byte, error) {
+	if file != "-" {
+		f, err := system.OpenSequential(file)
+		if err != nil {
+			return nil, err
+		}
+		in = f
+		defer f.Close()
+	}
+
+	data, err := ioutil.ReadAll(in)
+	if err != nil {
+		return nil, errors.Errorf("Error reading content from %q: %v", file, err)
+	}
+	return data, nil
+}
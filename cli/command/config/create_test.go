@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/internal/test"
 	"github.com/docker/cli/internal/test/testutil"
 	"github.com/docker/docker/api/types"
@@ -105,3 +106,44 @@ func TestConfigCreateWithLabels(t *testing.T) {
 	assert.NoError(t, cmd.Execute())
 	assert.Equal(t, "ID-"+name, strings.TrimSpace(cli.OutBuffer().String()))
 }
+
+func TestConfigCreateWithTemplatingDriver(t *testing.T) {
+	var actual command.ConfigSpecWithTemplating
+	cli := test.NewFakeCli(&fakeClient{
+		configCreateFunc: func(spec swarm.ConfigSpec) (types.ConfigCreateResponse, error) {
+			return types.ConfigCreateResponse{}, errors.Errorf("unexpected creation of the config without its template driver")
+		},
+	})
+	cli.SetTemplatingClient(&fakeTemplatingClient{
+		configCreateFunc: func(spec command.ConfigSpecWithTemplating) (types.ConfigCreateResponse, error) {
+			actual = spec
+			return types.ConfigCreateResponse{ID: "ID-" + spec.Name}, nil
+		},
+	})
+	cli.SetIn(command.NewInStream(ioutil.NopCloser(strings.NewReader(`listen {{env "PORT"}} on {{.Node.Hostname}}`))))
+
+	cmd := newConfigCreateCommand(cli)
+	cmd.SetArgs([]string{"foo", "-"})
+	cmd.Flags().Set("template-driver", "golang")
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "ID-foo", strings.TrimSpace(cli.OutBuffer().String()))
+	assert.Equal(t, "foo", actual.Name)
+	assert.Equal(t, []byte(`listen {{env "PORT"}} on {{.Node.Hostname}}`), actual.Data)
+	assert.Equal(t, &swarm.Driver{Name: "golang"}, actual.Templating)
+}
+
+func TestConfigCreateWithInvalidTemplate(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{})
+	cli.SetTemplatingClient(&fakeTemplatingClient{
+		configCreateFunc: func(spec command.ConfigSpecWithTemplating) (types.ConfigCreateResponse, error) {
+			return types.ConfigCreateResponse{}, errors.Errorf("unexpected creation of the config")
+		},
+	})
+	cli.SetIn(command.NewInStream(ioutil.NopCloser(strings.NewReader(`{{hostname}}`))))
+
+	cmd := newConfigCreateCommand(cli)
+	cmd.SetArgs([]string{"foo", "-"})
+	cmd.Flags().Set("template-driver", "golang")
+	cmd.SetOutput(ioutil.Discard)
+	testutil.ErrorContains(t, cmd.Execute(), `invalid config template: template: expansion:1: function "hostname" not defined`)
+}
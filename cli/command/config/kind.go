@@ -0,0 +1,61 @@
+package config
+
+import (
+	"github.com/docker/cli/cli/command/servicedata"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/docker/docker/client"
+	"golang.org/x/net/context"
+)
+
+// configKind implements servicedata.Kind for configs.
+type configKind struct {
+	client client.APIClient
+}
+
+var _ servicedata.Kind = configKind{}
+
+func (configKind) Name() string {
+	return "config"
+}
+
+func (k configKind) List(ctx context.Context, filters filters.Args) ([]servicedata.Object, error) {
+	configs, err := k.client.ConfigList(ctx, types.ConfigListOptions{Filters: filters})
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]servicedata.Object, 0, len(configs))
+	for _, config := range configs {
+		objects = append(objects, servicedata.Object{ID: config.ID, Name: config.Spec.Name})
+	}
+	return objects, nil
+}
+
+func (k configKind) Remove(ctx context.Context, id string) error {
+	return k.client.ConfigRemove(ctx, id)
+}
+
+func (configKind) ReplaceReferences(spec *swarm.ContainerSpec, oldID, newID, newName string) bool {
+	var replaced bool
+	for _, ref := range spec.Configs {
+		if ref.ConfigID == oldID {
+			ref.ConfigID = newID
+			ref.ConfigName = newName
+			replaced = true
+		}
+	}
+	return replaced
+}
+
+func (configKind) References(spec *swarm.ContainerSpec) []servicedata.Reference {
+	refs := make([]servicedata.Reference, 0, len(spec.Configs))
+	for _, ref := range spec.Configs {
+		var target string
+		if ref.File != nil {
+			target = ref.File.Name
+		}
+		refs = append(refs, servicedata.Reference{ID: ref.ConfigID, Target: target})
+	}
+	return refs
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/command/formatter"
+	"github.com/docker/cli/cli/command/servicedata"
 	"github.com/docker/cli/opts"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/swarm"
@@ -53,7 +54,13 @@ func runConfigList(dockerCli command.Cli, options listOptions) error {
 	client := dockerCli.Client()
 	ctx := context.Background()
 
-	configs, err := client.ConfigList(ctx, types.ConfigListOptions{Filters: options.filter.Value()})
+	listFilters := options.filter.Value()
+	used, err := servicedata.NewUsedFilter(listFilters)
+	if err != nil {
+		return err
+	}
+
+	configs, err := client.ConfigList(ctx, types.ConfigListOptions{Filters: listFilters})
 	if err != nil {
 		return err
 	}
@@ -67,11 +74,25 @@ func runConfigList(dockerCli command.Cli, options listOptions) error {
 		}
 	}
 
+	var usage servicedata.Usage
+	if used.IsSet() || servicedata.FormatUsesServices(format) {
+		if usage, err = servicedata.ServiceUsage(ctx, client, configKind{client: client}); err != nil {
+			return err
+		}
+	}
+	filtered := configs[:0]
+	for _, config := range configs {
+		if used.Match(usage, config.ID) {
+			filtered = append(filtered, config)
+		}
+	}
+	configs = filtered
+
 	sort.Sort(byConfigName(configs))
 
 	configCtx := formatter.Context{
 		Output: dockerCli.Out(),
 		Format: formatter.NewConfigFormat(format, options.quiet),
 	}
-	return formatter.ConfigWrite(configCtx, configs)
+	return formatter.ConfigWrite(configCtx, configs, usage)
 }
@@ -156,3 +156,56 @@ func TestConfigListWithFilter(t *testing.T) {
 	assert.NoError(t, cmd.Execute())
 	golden.Assert(t, cli.OutBuffer().String(), "config-list-with-filter.golden")
 }
+
+func configUsageServices() []swarm.Service {
+	return []swarm.Service{
+		{
+			Spec: swarm.ServiceSpec{
+				Annotations: swarm.Annotations{Name: "web"},
+				TaskTemplate: swarm.TaskSpec{ContainerSpec: &swarm.ContainerSpec{
+					Configs: []*swarm.ConfigReference{
+						{ConfigID: "ID-foo", ConfigName: "foo", File: &swarm.ConfigReferenceFileTarget{Name: "/etc/foo.conf"}},
+					},
+				}},
+			},
+		},
+	}
+}
+
+func TestConfigListWithServices(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		configListFunc: func(options types.ConfigListOptions) ([]swarm.Config, error) {
+			return []swarm.Config{
+				*Config(ConfigID("ID-foo"), ConfigName("foo")),
+				*Config(ConfigID("ID-bar"), ConfigName("bar")),
+			}, nil
+		},
+		serviceListFunc: func(options types.ServiceListOptions) ([]swarm.Service, error) {
+			return configUsageServices(), nil
+		},
+	})
+	cmd := newConfigListCommand(cli)
+	cmd.Flags().Set("format", "{{ .Name }}={{ .Services }}")
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "bar=\nfoo=web:/etc/foo.conf\n", cli.OutBuffer().String())
+}
+
+func TestConfigListWithUsedFilter(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		configListFunc: func(options types.ConfigListOptions) ([]swarm.Config, error) {
+			assert.False(t, options.Filters.Include("used"))
+			return []swarm.Config{
+				*Config(ConfigID("ID-foo"), ConfigName("foo")),
+				*Config(ConfigID("ID-bar"), ConfigName("bar")),
+			}, nil
+		},
+		serviceListFunc: func(options types.ServiceListOptions) ([]swarm.Service, error) {
+			return configUsageServices(), nil
+		},
+	})
+	cmd := newConfigListCommand(cli)
+	cmd.Flags().Set("filter", "used=true")
+	cmd.Flags().Set("quiet", "true")
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "ID-foo\n", cli.OutBuffer().String())
+}
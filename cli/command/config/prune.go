@@ -0,0 +1,26 @@
+package config
+
+import (
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/servicedata"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+func newConfigPruneCommand(dockerCli command.Cli) *cobra.Command {
+	options := servicedata.NewPruneOptions()
+
+	cmd := &cobra.Command{
+		Use:   "prune [OPTIONS]",
+		Short: "Remove all unused configs",
+		Args:  cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return servicedata.Prune(context.Background(), dockerCli, configKind{client: dockerCli.Client()}, options)
+		},
+	}
+
+	servicedata.AddPruneFlags(cmd.Flags(), &options)
+
+	return cmd
+}
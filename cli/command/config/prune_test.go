@@ -0,0 +1,52 @@
+package config
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
+	// Import builders to get the builder function as package function
+	. "github.com/docker/cli/internal/test/builders"
+	"github.com/stretchr/testify/assert"
+)
+
+func pruneTestClient(removed *[]string) *fakeClient {
+	return &fakeClient{
+		configListFunc: func(options types.ConfigListOptions) ([]swarm.Config, error) {
+			return []swarm.Config{
+				*Config(ConfigID("ID-foo"), ConfigName("foo")),
+				*Config(ConfigID("ID-bar"), ConfigName("bar")),
+			}, nil
+		},
+		serviceListFunc: func(options types.ServiceListOptions) ([]swarm.Service, error) {
+			return configUsageServices(), nil
+		},
+		configRemoveFunc: func(id string) error {
+			*removed = append(*removed, id)
+			return nil
+		},
+	}
+}
+
+func TestConfigPrune(t *testing.T) {
+	var removed []string
+	cli := test.NewFakeCli(pruneTestClient(&removed))
+	cli.SetIn(command.NewInStream(ioutil.NopCloser(strings.NewReader("y\n"))))
+	cmd := newConfigPruneCommand(cli)
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, []string{"ID-bar"}, removed)
+	assert.Contains(t, cli.OutBuffer().String(), "Deleted Configs:\nbar\n")
+}
+
+func TestConfigPruneNotConfirmed(t *testing.T) {
+	var removed []string
+	cli := test.NewFakeCli(pruneTestClient(&removed))
+	cli.SetIn(command.NewInStream(ioutil.NopCloser(strings.NewReader("n\n"))))
+	cmd := newConfigPruneCommand(cli)
+	assert.NoError(t, cmd.Execute())
+	assert.Empty(t, removed)
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/opts"
+	"github.com/docker/cli/templates"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type renderOptions struct {
+	file     string
+	service  string
+	labels   opts.ListOpts
+	node     string
+	platform string
+	slot     int
+	env      opts.ListOpts
+	secrets  opts.ListOpts
+	configs  opts.ListOpts
+}
+
+func newConfigRenderCommand(dockerCli command.Cli) *cobra.Command {
+	options := renderOptions{
+		labels:  opts.NewListOpts(opts.ValidateEnv),
+		env:     opts.NewListOpts(opts.ValidateEnv),
+		secrets: opts.NewListOpts(opts.ValidateEnv),
+		configs: opts.NewListOpts(opts.ValidateEnv),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "render [OPTIONS] file|-",
+		Short: "Preview a config template as rendered for a sample task",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.file = args[0]
+			return runConfigRender(dockerCli, options)
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&options.service, "service", "app", "Name of the service of the sample task")
+	flags.Var(&options.labels, "service-label", "Label of the service of the sample task")
+	flags.StringVar(&options.node, "node", "node-1", "Hostname of the node of the sample task")
+	flags.StringVar(&options.platform, "platform", "linux/amd64", "Platform of the node of the sample task (os/arch)")
+	flags.IntVar(&options.slot, "slot", 1, "Slot of the sample task")
+	flags.VarP(&options.env, "env", "e", "Set an environment variable of the sample task")
+	flags.Var(&options.secrets, "secret", "Set the value of a secret of the sample task (target=value)")
+	flags.Var(&options.configs, "config", "Set the value of a config of the sample task (target=value)")
+
+	return cmd
+}
+
+func runConfigRender(dockerCli command.Cli, options renderOptions) error {
+	configData, err := readConfigData(dockerCli.In(), options.file)
+	if err != nil {
+		return err
+	}
+
+	platform := strings.SplitN(options.platform, "/", 2)
+	if len(platform) != 2 || platform[0] == "" || platform[1] == "" {
+		return errors.Errorf("invalid platform %q: expected os/arch", options.platform)
+	}
+
+	env := opts.ConvertKVStringsToMap(options.env.GetAll())
+	secrets := opts.ConvertKVStringsToMap(options.secrets.GetAll())
+	configs := opts.ConvertKVStringsToMap(options.configs.GetAll())
+	tmpl, err := templates.ParsePayload(string(configData), templates.PayloadFunctions{
+		Env: func(variable string) (string, error) {
+			return env[variable], nil
+		},
+		Secret: func(target string) (string, error) {
+			if value, ok := secrets[target]; ok {
+				return value, nil
+			}
+			return "", errors.Errorf("secret target %s not found", target)
+		},
+		Config: func(target string) (string, error) {
+			if value, ok := configs[target]; ok {
+				return value, nil
+			}
+			return "", errors.Errorf("config target %s not found", target)
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "invalid config template")
+	}
+
+	ctx := sampleTaskContext(options.service, options.slot)
+	ctx.Service.Labels = opts.ConvertKVStringsToMap(options.labels.GetAll())
+	ctx.Node.Hostname = options.node
+	ctx.Node.Platform = templates.PayloadPlatform{OS: platform[0], Architecture: platform[1]}
+
+	if err := tmpl.Execute(dockerCli.Out(), ctx); err != nil {
+		return errors.Wrap(err, "failed to render config template")
+	}
+	return nil
+}
+
+// sampleTaskContext returns the template context of a task of a service, with
+// placeholder IDs.
+func sampleTaskContext(service string, slot int) templates.PayloadContext {
+	ctx := templates.PayloadContext{}
+	ctx.Service.ID = "serviceid"
+	ctx.Service.Name = service
+	ctx.Node.ID = "nodeid"
+	ctx.Task.ID = "taskid"
+	ctx.Task.Slot = strconv.Itoa(slot)
+	ctx.Task.Name = fmt.Sprintf("%s.%d.%s", service, slot, ctx.Task.ID)
+	return ctx
+}
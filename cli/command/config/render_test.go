@@ -0,0 +1,70 @@
+package config
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/cli/internal/test/testutil"
+	"github.com/gotestyourself/gotestyourself/golden"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigRender(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{})
+	cmd := newConfigRenderCommand(cli)
+	cmd.SetArgs([]string{filepath.Join("testdata", "config-render.tmpl")})
+	cmd.Flags().Set("service", "web")
+	cmd.Flags().Set("service-label", "domain=example.com")
+	cmd.Flags().Set("node", "worker-2")
+	cmd.Flags().Set("platform", "linux/arm64")
+	cmd.Flags().Set("slot", "3")
+	cmd.Flags().Set("env", "PORT=8080")
+	cmd.Flags().Set("secret", "db_password=s3cr3t")
+	assert.NoError(t, cmd.Execute())
+	golden.Assert(t, cli.OutBuffer().String(), "config-render.golden")
+}
+
+func TestConfigRenderErrors(t *testing.T) {
+	testCases := []struct {
+		template      string
+		flags         map[string]string
+		expectedError string
+	}{
+		{
+			template:      `{{hostname}}`,
+			expectedError: `invalid config template: template: expansion:1: function "hostname" not defined`,
+		},
+		{
+			template:      `{{secret "db_password"}}`,
+			expectedError: "secret target db_password not found",
+		},
+		{
+			template:      `{{.Service.Labels.domain}}`,
+			expectedError: `map has no entry for key "domain"`,
+		},
+		{
+			template:      `{{.Node.Name}}`,
+			expectedError: "can't evaluate field Name",
+		},
+		{
+			template:      `{{.Node.Hostname}}`,
+			flags:         map[string]string{"platform": "linux"},
+			expectedError: `invalid platform "linux": expected os/arch`,
+		},
+	}
+	for _, tc := range testCases {
+		cli := test.NewFakeCli(&fakeClient{})
+		cli.SetIn(command.NewInStream(ioutil.NopCloser(strings.NewReader(tc.template))))
+		cmd := newConfigRenderCommand(cli)
+		cmd.SetArgs([]string{"-"})
+		for key, value := range tc.flags {
+			cmd.Flags().Set(key, value)
+		}
+		cmd.SetOutput(ioutil.Discard)
+		testutil.ErrorContains(t, cmd.Execute(), tc.expectedError)
+	}
+}
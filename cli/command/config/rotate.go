@@ -0,0 +1,57 @@
+package config
+
+import (
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/servicedata"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+type rotateOptions struct {
+	config string
+	file   string
+	rotate servicedata.RotateOptions
+}
+
+func newConfigRotateCommand(dockerCli command.Cli) *cobra.Command {
+	options := rotateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "rotate [OPTIONS] CONFIG file|-",
+		Short: "Replace a config with a new version, and update the services using it",
+		Args:  cli.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.config = args[0]
+			options.file = args[1]
+			return runConfigRotate(dockerCli, options)
+		},
+	}
+	servicedata.AddRotateFlags(cmd.Flags(), configKind{}, &options.rotate)
+
+	return cmd
+}
+
+func runConfigRotate(dockerCli command.Cli, options rotateOptions) error {
+	client := dockerCli.Client()
+	ctx := context.Background()
+
+	old, _, err := client.ConfigInspectWithRaw(ctx, options.config)
+	if err != nil {
+		return err
+	}
+
+	configData, err := readConfigData(dockerCli.In(), options.file)
+	if err != nil {
+		return err
+	}
+
+	create := func(name string) (string, error) {
+		spec := old.Spec
+		spec.Name = name
+		spec.Data = configData
+		r, err := client.ConfigCreate(ctx, spec)
+		return r.ID, err
+	}
+	return servicedata.Rotate(ctx, dockerCli, configKind{client: client}, servicedata.Object{ID: old.ID, Name: old.Spec.Name}, create, options.rotate)
+}
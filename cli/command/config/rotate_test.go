@@ -0,0 +1,124 @@
+package config
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/cli/internal/test/testutil"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func rotateTestConfig(name string) (swarm.Config, []byte, error) {
+	config := swarm.Config{ID: "configID1"}
+	config.Spec.Name = "nginx.conf.v1"
+	return config, nil, nil
+}
+
+func rotateTestServices() []swarm.Service {
+	service := swarm.Service{ID: "web"}
+	service.Spec.Name = "web"
+	service.Spec.TaskTemplate.ContainerSpec = &swarm.ContainerSpec{
+		Configs: []*swarm.ConfigReference{
+			{ConfigID: "configID2", ConfigName: "app.conf"},
+			{
+				ConfigID:   "configID1",
+				ConfigName: "nginx.conf.v1",
+				File:       &swarm.ConfigReferenceFileTarget{Name: "/etc/nginx/nginx.conf", UID: "0", GID: "0", Mode: 0444},
+			},
+		},
+	}
+	return []swarm.Service{service}
+}
+
+func TestConfigRotateErrors(t *testing.T) {
+	testCases := []struct {
+		args              []string
+		configInspectFunc func(string) (swarm.Config, []byte, error)
+		configCreateFunc  func(swarm.ConfigSpec) (types.ConfigCreateResponse, error)
+		expectedError     string
+	}{
+		{
+			args:          []string{"nginx.conf.v1"},
+			expectedError: "requires exactly 2 arguments",
+		},
+		{
+			args: []string{"nginx.conf.v1", "-"},
+			configInspectFunc: func(string) (swarm.Config, []byte, error) {
+				return swarm.Config{}, nil, errors.Errorf("error inspecting config")
+			},
+			expectedError: "error inspecting config",
+		},
+		{
+			args:              []string{"nginx.conf.v1", "-"},
+			configInspectFunc: rotateTestConfig,
+			configCreateFunc: func(swarm.ConfigSpec) (types.ConfigCreateResponse, error) {
+				return types.ConfigCreateResponse{}, errors.Errorf("error creating config")
+			},
+			expectedError: "error creating config",
+		},
+	}
+	for _, tc := range testCases {
+		cli := test.NewFakeCli(&fakeClient{
+			configInspectFunc: tc.configInspectFunc,
+			configCreateFunc:  tc.configCreateFunc,
+			configRemoveFunc: func(string) error {
+				return errors.Errorf("unexpected removal of the config")
+			},
+		})
+		cli.SetIn(command.NewInStream(ioutil.NopCloser(strings.NewReader("worker_processes 4;"))))
+		cmd := newConfigRotateCommand(cli)
+		cmd.SetArgs(tc.args)
+		cmd.SetOutput(ioutil.Discard)
+		testutil.ErrorContains(t, cmd.Execute(), tc.expectedError)
+	}
+}
+
+func TestConfigRotate(t *testing.T) {
+	var (
+		created swarm.ConfigSpec
+		updated swarm.ServiceSpec
+		removed string
+	)
+	cli := test.NewFakeCli(&fakeClient{
+		configInspectFunc: rotateTestConfig,
+		configCreateFunc: func(spec swarm.ConfigSpec) (types.ConfigCreateResponse, error) {
+			created = spec
+			return types.ConfigCreateResponse{ID: "configID3"}, nil
+		},
+		configRemoveFunc: func(id string) error {
+			removed = id
+			return nil
+		},
+		serviceListFunc: func(types.ServiceListOptions) ([]swarm.Service, error) {
+			return rotateTestServices(), nil
+		},
+		serviceUpdateFunc: func(id string, version swarm.Version, spec swarm.ServiceSpec) (types.ServiceUpdateResponse, error) {
+			updated = spec
+			return types.ServiceUpdateResponse{Warnings: []string{"image could not be accessed"}}, nil
+		},
+	})
+	cli.SetIn(command.NewInStream(ioutil.NopCloser(strings.NewReader("worker_processes 4;"))))
+	cmd := newConfigRotateCommand(cli)
+	cmd.SetArgs([]string{"nginx.conf.v1", "-"})
+	assert.NoError(t, cmd.Execute())
+
+	assert.Equal(t, "nginx.conf.v2", created.Name)
+	assert.Equal(t, []byte("worker_processes 4;"), created.Data)
+	assert.Equal(t, []*swarm.ConfigReference{
+		{ConfigID: "configID2", ConfigName: "app.conf"},
+		{
+			ConfigID:   "configID3",
+			ConfigName: "nginx.conf.v2",
+			File:       &swarm.ConfigReferenceFileTarget{Name: "/etc/nginx/nginx.conf", UID: "0", GID: "0", Mode: 0444},
+		},
+	}, updated.TaskTemplate.ContainerSpec.Configs)
+	assert.Equal(t, "configID1", removed)
+	assert.Equal(t, "Created config nginx.conf.v2 (configID3)\nUpdated service web\nRemoved config nginx.conf.v1 (configID1)\n", cli.OutBuffer().String())
+	assert.Equal(t, "image could not be accessed\n", cli.ErrBuffer().String())
+}
@@ -19,6 +19,7 @@ type attachOptions struct {
 	noStdin    bool
 	proxy      bool
 	detachKeys string
+	record     string
 
 	container string
 }
@@ -59,6 +60,7 @@ func NewAttachCommand(dockerCli command.Cli) *cobra.Command {
 	flags.BoolVar(&opts.noStdin, "no-stdin", false, "Do not attach STDIN")
 	flags.BoolVar(&opts.proxy, "sig-proxy", true, "Proxy all received signals to the process")
 	flags.StringVar(&opts.detachKeys, "detach-keys", "", "Override the key sequence for detaching a container")
+	flags.StringVar(&opts.record, "record", "", "Record the session to an asciicast file")
 	return cmd
 }
 
@@ -119,8 +121,16 @@ func runAttach(dockerCli command.Cli, opts *attachOptions) error {
 		return err
 	}
 
+	var recorder *sessionRecorder
+	if opts.record != "" {
+		if recorder, err = createSessionRecorder(opts.record, dockerCli, opts.container); err != nil {
+			return err
+		}
+		defer recorder.Close()
+	}
+
 	if c.Config.Tty && dockerCli.Out().IsTerminal() {
-		resizeTTY(ctx, dockerCli, opts.container)
+		resizeTTY(ctx, dockerCli, opts.container, recorder)
 	}
 
 	streamer := hijackedIOStreamer{
@@ -131,6 +141,7 @@ func runAttach(dockerCli command.Cli, opts *attachOptions) error {
 		resp:         resp,
 		tty:          c.Config.Tty,
 		detachKeys:   options.DetachKeys,
+		recorder:     recorder,
 	}
 
 	if err := streamer.stream(ctx); err != nil {
@@ -143,7 +154,7 @@ func runAttach(dockerCli command.Cli, opts *attachOptions) error {
 	return getExitStatus(ctx, dockerCli.Client(), opts.container)
 }
 
-func resizeTTY(ctx context.Context, dockerCli command.Cli, containerID string) {
+func resizeTTY(ctx context.Context, dockerCli command.Cli, containerID string, recorder *sessionRecorder) {
 	height, width := dockerCli.Out().GetTtySize()
 	// To handle the case where a user repeatedly attaches/detaches without resizing their
 	// terminal, the only way to get the shell prompt to display for attaches 2+ is to artificially
@@ -151,9 +162,9 @@ func resizeTTY(ctx context.Context, dockerCli command.Cli, containerID string) {
 	// require the user to manually resize or hit enter.
 	resizeTtyTo(ctx, dockerCli.Client(), containerID, height+1, width+1, false)
 
-	// After the above resizing occurs, the call to MonitorTtySize below will handle resetting back
+	// After the above resizing occurs, the call to monitorTtySize below will handle resetting back
 	// to the actual size.
-	if err := MonitorTtySize(ctx, dockerCli, containerID, false); err != nil {
+	if err := monitorTtySize(ctx, dockerCli, containerID, false, recorder); err != nil {
 		logrus.Debugf("Error monitoring TTY size: %s", err)
 	}
 }
@@ -0,0 +1,128 @@
+package container
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/opts"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/net/context"
+)
+
+// bulkConfirmationThreshold is the number of containers selected with
+// --all or --filter above which the user is asked for confirmation
+const bulkConfirmationThreshold = 10
+
+// bulkOptions are the options of the commands operating on multiple
+// containers. They select the containers with filters, as an alternative to
+// passing them as arguments.
+type bulkOptions struct {
+	all      bool
+	filter   opts.FilterOpt
+	yes      bool
+	parallel int
+
+	// includeStopped is set by the commands operating on stopped
+	// containers, which select them with --filter without --all.
+	includeStopped bool
+}
+
+func addBulkFlags(flags *pflag.FlagSet, options *bulkOptions) {
+	options.filter = opts.NewFilterOpt()
+	if options.includeStopped {
+		flags.BoolVar(&options.all, "all", false, "Select all containers")
+	} else {
+		flags.BoolVar(&options.all, "all", false, "Select all containers (default selects running containers only)")
+	}
+	flags.VarP(&options.filter, "filter", "", "Select the containers matching the filter, as in 'docker ps'")
+	flags.BoolVarP(&options.yes, "yes", "y", false, "Do not prompt for confirmation")
+	flags.IntVar(&options.parallel, "parallel", defaultParallel, "Maximum number of containers to process concurrently")
+}
+
+// selected returns whether the containers are selected with --all or
+// --filter.
+func (o *bulkOptions) selected() bool {
+	return o.all || o.filter.Value().Len() > 0
+}
+
+// requiresContainers validates that containers are passed as arguments,
+// unless they are selected with --all or --filter.
+func requiresContainers(options *bulkOptions) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if !options.selected() {
+			return cli.RequiresMinArgs(1)(cmd, args)
+		}
+		if len(args) > 0 {
+			return errors.Errorf("%q accepts no container when --all or --filter is specified.\nSee '%s --help'.", cmd.CommandPath(), cmd.CommandPath())
+		}
+		return nil
+	}
+}
+
+// selectContainers returns the names of the containers selected with --all
+// or --filter. No containers are returned if the user did not confirm.
+func selectContainers(ctx context.Context, dockerCli command.Cli, options *bulkOptions, action string) ([]string, error) {
+	containers, err := dockerCli.Client().ContainerList(ctx, types.ContainerListOptions{
+		All:     options.all || options.includeStopped,
+		Filters: options.filter.Value(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(containers))
+	for _, c := range containers {
+		names = append(names, containerName(c))
+	}
+
+	if len(names) > bulkConfirmationThreshold && !options.yes {
+		warning := fmt.Sprintf("WARNING! This will %s %d containers:\n  %s\nAre you sure you want to continue?",
+			action, len(names), strings.Join(names, "\n  "))
+		if !command.PromptForConfirmation(dockerCli.In(), dockerCli.Out(), warning) {
+			return nil, nil
+		}
+	}
+	return names, nil
+}
+
+// containerName returns the name of a container, ignoring the names it has
+// in other containers through links.
+func containerName(c types.Container) string {
+	for _, name := range c.Names {
+		name = strings.TrimPrefix(name, "/")
+		if !strings.Contains(name, "/") {
+			return name
+		}
+	}
+	return stringid.TruncateID(c.ID)
+}
+
+// runBulkOperation runs op on the containers selected with --all or
+// --filter, and prints the result for each container. action is the name of
+// the operation, and done its past participle.
+func runBulkOperation(ctx context.Context, dockerCli command.Cli, options *bulkOptions, action, done string, op func(ctx context.Context, container string) error) error {
+	containers, err := selectContainers(ctx, dockerCli, options, action)
+	if err != nil || len(containers) == 0 {
+		return err
+	}
+
+	var failed int
+	errChan := parallelOperation(ctx, containers, options.parallel, op)
+	for _, name := range containers {
+		if err := <-errChan; err != nil {
+			failed++
+			fmt.Fprintf(dockerCli.Out(), "%s: failed: %v\n", name, err)
+			continue
+		}
+		fmt.Fprintf(dockerCli.Out(), "%s: %s\n", name, done)
+	}
+	if failed > 0 {
+		return errors.Errorf("failed to %s %d of %d containers", action, failed, len(containers))
+	}
+	return nil
+}
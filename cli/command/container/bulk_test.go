@@ -0,0 +1,185 @@
+package container
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/cli/internal/test/testutil"
+	"github.com/docker/docker/api/types"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func bulkContainers(count int) []types.Container {
+	containers := make([]types.Container, 0, count)
+	for i := 1; i <= count; i++ {
+		containers = append(containers, types.Container{
+			ID:    fmt.Sprintf("%064d", i),
+			Names: []string{fmt.Sprintf("/web-%d", i)},
+		})
+	}
+	return containers
+}
+
+func TestContainerName(t *testing.T) {
+	assert.Equal(t, "db", containerName(types.Container{Names: []string{"/web/db", "/db"}}))
+	assert.Equal(t, "0123456789ab", containerName(types.Container{ID: "0123456789abcdef"}))
+}
+
+func TestStopWithFilter(t *testing.T) {
+	var (
+		listOptions types.ContainerListOptions
+		mu          sync.Mutex
+		stopped     []string
+	)
+	fakeClient := &fakeClient{
+		containerListFunc: func(options types.ContainerListOptions) ([]types.Container, error) {
+			listOptions = options
+			return bulkContainers(3), nil
+		},
+		containerStopFunc: func(container string, timeout *time.Duration) error {
+			if container == "web-2" {
+				return errors.New("Error response from daemon: cannot stop container: web-2")
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			stopped = append(stopped, container)
+			return nil
+		},
+	}
+	cli := test.NewFakeCli(fakeClient)
+	cmd := NewStopCommand(cli)
+	cmd.SetOutput(ioutil.Discard)
+	cmd.SetArgs([]string{"--filter", "label=com.example.app=web", "--parallel", "2"})
+	testutil.ErrorContains(t, cmd.Execute(), "failed to stop 1 of 3 containers")
+
+	assert.False(t, listOptions.All)
+	assert.Equal(t, []string{"com.example.app=web"}, listOptions.Filters.Get("label"))
+	sort.Strings(stopped)
+	assert.Equal(t, []string{"web-1", "web-3"}, stopped)
+	assert.Equal(t, `web-1: stopped
+web-2: failed: Error response from daemon: cannot stop container: web-2
+web-3: stopped
+`, cli.OutBuffer().String())
+}
+
+func TestStartAndRmWithFilterIncludeStoppedContainers(t *testing.T) {
+	for _, newCommand := range []func(command.Cli) *cobra.Command{NewStartCommand, NewRmCommand} {
+		var listOptions types.ContainerListOptions
+		fakeClient := &fakeClient{
+			containerListFunc: func(options types.ContainerListOptions) ([]types.Container, error) {
+				listOptions = options
+				return nil, nil
+			},
+		}
+		cmd := newCommand(test.NewFakeCli(fakeClient))
+		cmd.SetOutput(ioutil.Discard)
+		cmd.SetArgs([]string{"--filter", "status=exited"})
+		require.NoError(t, cmd.Execute())
+
+		assert.True(t, listOptions.All, cmd.Name())
+		assert.Equal(t, []string{"exited"}, listOptions.Filters.Get("status"), cmd.Name())
+	}
+}
+
+func TestStartContainersInOrder(t *testing.T) {
+	var started []string
+	fakeClient := &fakeClient{
+		containerStartFunc: func(container string, options types.ContainerStartOptions) error {
+			started = append(started, container)
+			return nil
+		},
+	}
+	cli := test.NewFakeCli(fakeClient)
+	cmd := NewStartCommand(cli)
+	cmd.SetOutput(ioutil.Discard)
+	cmd.SetArgs([]string{"db", "cache", "web"})
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, []string{"db", "cache", "web"}, started)
+	assert.Equal(t, "db\ncache\nweb\n", cli.OutBuffer().String())
+}
+
+func TestBulkOperationConfirmation(t *testing.T) {
+	testcases := []struct {
+		doc         string
+		count       int
+		yes         bool
+		input       string
+		expectedOps int
+	}{
+		{doc: "below threshold", count: bulkConfirmationThreshold, expectedOps: bulkConfirmationThreshold},
+		{doc: "confirmed", count: bulkConfirmationThreshold + 1, input: "y\n", expectedOps: bulkConfirmationThreshold + 1},
+		{doc: "refused", count: bulkConfirmationThreshold + 1, input: "n\n", expectedOps: 0},
+		{doc: "yes", count: bulkConfirmationThreshold + 1, yes: true, expectedOps: bulkConfirmationThreshold + 1},
+	}
+	for _, tc := range testcases {
+		fakeClient := &fakeClient{
+			containerListFunc: func(options types.ContainerListOptions) ([]types.Container, error) {
+				return bulkContainers(tc.count), nil
+			},
+		}
+		cli := test.NewFakeCli(fakeClient)
+		cli.SetIn(command.NewInStream(ioutil.NopCloser(strings.NewReader(tc.input))))
+
+		var (
+			mu  sync.Mutex
+			ops int
+		)
+		options := &bulkOptions{all: true, yes: tc.yes}
+		err := runBulkOperation(context.Background(), cli, options, "kill", "killed", func(ctx context.Context, container string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			ops++
+			return nil
+		})
+		require.NoError(t, err, tc.doc)
+		assert.Equal(t, tc.expectedOps, ops, tc.doc)
+		if tc.input != "" {
+			assert.Contains(t, cli.OutBuffer().String(), fmt.Sprintf("This will kill %d containers", tc.count), tc.doc)
+		}
+	}
+}
+
+func TestBulkOperationArguments(t *testing.T) {
+	testcases := []struct {
+		args          []string
+		expectedError string
+	}{
+		{
+			args:          []string{},
+			expectedError: "requires at least 1 argument",
+		},
+		{
+			args:          []string{"--all", "web"},
+			expectedError: "accepts no container when --all or --filter is specified",
+		},
+		{
+			args:          []string{"--filter", "status=paused", "web"},
+			expectedError: "accepts no container when --all or --filter is specified",
+		},
+	}
+	for _, tc := range testcases {
+		cmd := NewUnpauseCommand(test.NewFakeCli(&fakeClient{}))
+		cmd.SetOutput(ioutil.Discard)
+		cmd.SetArgs(tc.args)
+		testutil.ErrorContains(t, cmd.Execute(), tc.expectedError)
+	}
+}
+
+func TestStartWithFilterAndAttach(t *testing.T) {
+	cmd := NewStartCommand(test.NewFakeCli(&fakeClient{}))
+	cmd.SetOutput(ioutil.Discard)
+	cmd.SetArgs([]string{"--all", "--attach"})
+	testutil.ErrorContains(t, cmd.Execute(), "you cannot attach to or restore containers selected with --all or --filter")
+}
@@ -2,9 +2,11 @@ package container
 
 import (
 	"io"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/client"
 	"golang.org/x/net/context"
@@ -15,12 +17,20 @@ type fakeClient struct {
 	inspectFunc           func(string) (types.ContainerJSON, error)
 	execInspectFunc       func(execID string) (types.ContainerExecInspect, error)
 	execCreateFunc        func(container string, config types.ExecConfig) (types.IDResponse, error)
+	execAttachFunc        func(execID string, config types.ExecStartCheck) (types.HijackedResponse, error)
 	createContainerFunc   func(config *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig, containerName string) (container.ContainerCreateCreatedBody, error)
 	imageCreateFunc       func(parentReference string, options types.ImageCreateOptions) (io.ReadCloser, error)
+	imageInspectFunc      func(image string) (types.ImageInspect, []byte, error)
 	infoFunc              func() (types.Info, error)
 	containerStatPathFunc func(container, path string) (types.ContainerPathStat, error)
 	containerCopyFromFunc func(container, srcPath string) (io.ReadCloser, types.ContainerPathStat, error)
+	containerCopyToFunc   func(container, path string, content io.Reader, options types.CopyToContainerOptions) error
 	logFunc               func(string, types.ContainerLogsOptions) (io.ReadCloser, error)
+	containerListFunc     func(options types.ContainerListOptions) ([]types.Container, error)
+	containerStopFunc     func(container string, timeout *time.Duration) error
+	containerStartFunc    func(container string, options types.ContainerStartOptions) error
+	waitFunc              func(container string, condition container.WaitCondition) (<-chan container.ContainerWaitOKBody, <-chan error)
+	eventsFunc            func(options types.EventsOptions) (<-chan events.Message, <-chan error)
 }
 
 func (f *fakeClient) ContainerInspect(_ context.Context, containerID string) (types.ContainerJSON, error) {
@@ -44,6 +54,13 @@ func (f *fakeClient) ContainerExecInspect(_ context.Context, execID string) (typ
 	return types.ContainerExecInspect{}, nil
 }
 
+func (f *fakeClient) ContainerExecAttach(_ context.Context, execID string, config types.ExecStartCheck) (types.HijackedResponse, error) {
+	if f.execAttachFunc != nil {
+		return f.execAttachFunc(execID, config)
+	}
+	return types.HijackedResponse{}, nil
+}
+
 func (f *fakeClient) ContainerExecStart(ctx context.Context, execID string, config types.ExecStartCheck) error {
 	return nil
 }
@@ -68,6 +85,13 @@ func (f *fakeClient) ImageCreate(ctx context.Context, parentReference string, op
 	return nil, nil
 }
 
+func (f *fakeClient) ImageInspectWithRaw(_ context.Context, image string) (types.ImageInspect, []byte, error) {
+	if f.imageInspectFunc != nil {
+		return f.imageInspectFunc(image)
+	}
+	return types.ImageInspect{}, nil, nil
+}
+
 func (f *fakeClient) Info(_ context.Context) (types.Info, error) {
 	if f.infoFunc != nil {
 		return f.infoFunc()
@@ -89,9 +113,51 @@ func (f *fakeClient) CopyFromContainer(_ context.Context, container, srcPath str
 	return nil, types.ContainerPathStat{}, nil
 }
 
+func (f *fakeClient) CopyToContainer(_ context.Context, container, path string, content io.Reader, options types.CopyToContainerOptions) error {
+	if f.containerCopyToFunc != nil {
+		return f.containerCopyToFunc(container, path, content, options)
+	}
+	return nil
+}
+
 func (f *fakeClient) ContainerLogs(_ context.Context, container string, options types.ContainerLogsOptions) (io.ReadCloser, error) {
 	if f.logFunc != nil {
 		return f.logFunc(container, options)
 	}
 	return nil, nil
 }
+
+func (f *fakeClient) ContainerWait(_ context.Context, container string, condition container.WaitCondition) (<-chan container.ContainerWaitOKBody, <-chan error) {
+	if f.waitFunc != nil {
+		return f.waitFunc(container, condition)
+	}
+	return nil, nil
+}
+
+func (f *fakeClient) Events(_ context.Context, options types.EventsOptions) (<-chan events.Message, <-chan error) {
+	if f.eventsFunc != nil {
+		return f.eventsFunc(options)
+	}
+	return nil, nil
+}
+
+func (f *fakeClient) ContainerList(_ context.Context, options types.ContainerListOptions) ([]types.Container, error) {
+	if f.containerListFunc != nil {
+		return f.containerListFunc(options)
+	}
+	return []types.Container{}, nil
+}
+
+func (f *fakeClient) ContainerStop(_ context.Context, container string, timeout *time.Duration) error {
+	if f.containerStopFunc != nil {
+		return f.containerStopFunc(container, timeout)
+	}
+	return nil
+}
+
+func (f *fakeClient) ContainerStart(_ context.Context, container string, options types.ContainerStartOptions) error {
+	if f.containerStartFunc != nil {
+		return f.containerStartFunc(container, options)
+	}
+	return nil
+}
@@ -27,12 +27,14 @@ func NewContainerCommand(dockerCli command.Cli) *cobra.Command {
 		NewPauseCommand(dockerCli),
 		NewPortCommand(dockerCli),
 		NewRenameCommand(dockerCli),
+		NewReplayCommand(dockerCli),
 		NewRestartCommand(dockerCli),
 		NewRmCommand(dockerCli),
 		NewRunCommand(dockerCli),
 		NewStartCommand(dockerCli),
 		NewStatsCommand(dockerCli),
 		NewStopCommand(dockerCli),
+		NewSyncCommand(dockerCli),
 		NewTopCommand(dockerCli),
 		NewUnpauseCommand(dockerCli),
 		NewUpdateCommand(dockerCli),
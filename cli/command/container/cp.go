@@ -1,16 +1,25 @@
 package container
 
 import (
+	"archive/tar"
+	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/fileutils"
 	"github.com/docker/docker/pkg/system"
+	"github.com/docker/docker/pkg/term"
+	units "github.com/docker/go-units"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"golang.org/x/net/context"
@@ -21,6 +30,9 @@ type copyOptions struct {
 	destination string
 	followLink  bool
 	copyUIDGID  bool
+	excludes    []string
+	includes    []string
+	quiet       bool
 }
 
 type copyDirection int
@@ -37,6 +49,14 @@ type cpConfig struct {
 	sourcePath string
 	destPath   string
 	container  string
+	// destContainer is the destination container when copying across
+	// containers, in which case container is the source container.
+	destContainer string
+	// excludes matches the files to leave out of the copy, it is nil if
+	// all files are copied.
+	excludes *fileutils.PatternMatcher
+	// progress is nil if the progress is not displayed.
+	progress *copyProgress
 }
 
 // NewCopyCommand creates a new `docker cp` command
@@ -45,10 +65,12 @@ func NewCopyCommand(dockerCli command.Cli) *cobra.Command {
 
 	cmd := &cobra.Command{
 		Use: `cp [OPTIONS] CONTAINER:SRC_PATH DEST_PATH|-
-	docker cp [OPTIONS] SRC_PATH|- CONTAINER:DEST_PATH`,
+	docker cp [OPTIONS] SRC_PATH|- CONTAINER:DEST_PATH
+	docker cp [OPTIONS] CONTAINER:SRC_PATH CONTAINER:DEST_PATH`,
 		Short: "Copy files/folders between a container and the local filesystem",
 		Long: strings.Join([]string{
-			"Copy files/folders between a container and the local filesystem\n",
+			"Copy files/folders between a container and the local filesystem,\n",
+			"or between two containers\n",
 			"\nUse '-' as the source to read a tar archive from stdin\n",
 			"and extract it to a directory destination in a container.\n",
 			"Use '-' as the destination to stream a tar archive of a\n",
@@ -71,6 +93,9 @@ func NewCopyCommand(dockerCli command.Cli) *cobra.Command {
 	flags := cmd.Flags()
 	flags.BoolVarP(&opts.followLink, "follow-link", "L", false, "Always follow symbol link in SRC_PATH")
 	flags.BoolVarP(&opts.copyUIDGID, "archive", "a", false, "Archive mode (copy all uid/gid information)")
+	flags.StringSliceVar(&opts.excludes, "exclude", []string{}, "Exclude files matching a pattern, using the .dockerignore syntax")
+	flags.StringSliceVar(&opts.includes, "include", []string{}, "Include files matching a pattern, even if they are excluded")
+	flags.BoolVarP(&opts.quiet, "quiet", "q", false, "Suppress the progress output")
 	return cmd
 }
 
@@ -84,6 +109,18 @@ func runCopy(dockerCli command.Cli, opts copyOptions) error {
 		sourcePath: srcPath,
 		destPath:   destPath,
 	}
+	if patterns := copyPatterns(opts.excludes, opts.includes); len(patterns) > 0 {
+		matcher, err := fileutils.NewPatternMatcher(patterns)
+		if err != nil {
+			return err
+		}
+		copyConfig.excludes = matcher
+	}
+	// The progress is only displayed on a terminal, so that it doesn't end
+	// up in the logs of scripts.
+	if _, isTerminal := term.GetFdInfo(dockerCli.Err()); isTerminal && !opts.quiet {
+		copyConfig.progress = newCopyProgress(dockerCli.Err())
+	}
 
 	var direction copyDirection
 	if srcContainer != "" {
@@ -92,18 +129,27 @@ func runCopy(dockerCli command.Cli, opts copyOptions) error {
 	}
 	if destContainer != "" {
 		direction |= toContainer
-		copyConfig.container = destContainer
+		if direction == acrossContainers {
+			copyConfig.destContainer = destContainer
+		} else {
+			copyConfig.container = destContainer
+		}
 	}
 
 	ctx := context.Background()
 
+	if copyConfig.progress != nil && direction != 0 {
+		copyConfig.progress.start()
+		defer copyConfig.progress.finish()
+	}
+
 	switch direction {
 	case fromContainer:
 		return copyFromContainer(ctx, dockerCli, copyConfig)
 	case toContainer:
 		return copyToContainer(ctx, dockerCli, copyConfig)
 	case acrossContainers:
-		return errors.New("copying between containers is not supported")
+		return copyAcrossContainers(ctx, dockerCli, copyConfig)
 	default:
 		return errors.New("must specify at least one container source")
 	}
@@ -129,30 +175,14 @@ func copyFromContainer(ctx context.Context, dockerCli command.Cli, copyConfig cp
 	}
 
 	client := dockerCli.Client()
-	// if client requests to follow symbol link, then must decide target file to be copied
-	var rebaseName string
-	if copyConfig.followLink {
-		srcStat, err := client.ContainerStatPath(ctx, copyConfig.container, srcPath)
-
-		// If the destination is a symbolic link, we should follow it.
-		if err == nil && srcStat.Mode&os.ModeSymlink != 0 {
-			linkTarget := srcStat.LinkTarget
-			if !system.IsAbs(linkTarget) {
-				// Join with the parent directory.
-				srcParent, _ := archive.SplitPathDirEntry(srcPath)
-				linkTarget = filepath.Join(srcParent, linkTarget)
-			}
+	srcPath, rebaseName := resolveContainerSourcePath(ctx, dockerCli, copyConfig.container, srcPath, copyConfig.followLink)
 
-			linkTarget, rebaseName = archive.GetRebaseName(srcPath, linkTarget)
-			srcPath = linkTarget
-		}
-
-	}
-
-	content, stat, err := client.CopyFromContainer(ctx, copyConfig.container, srcPath)
+	response, stat, err := client.CopyFromContainer(ctx, copyConfig.container, srcPath)
 	if err != nil {
 		return err
 	}
+	defer response.Close()
+	content := transferArchive(response, srcPath, copyConfig)
 	defer content.Close()
 
 	if dstPath == "-" {
@@ -166,13 +196,40 @@ func copyFromContainer(ctx context.Context, dockerCli command.Cli, copyConfig cp
 		IsDir:      stat.Mode.IsDir(),
 		RebaseName: rebaseName,
 	}
+	return archive.CopyTo(rebaseContainerArchive(content, srcInfo), srcInfo, dstPath)
+}
+
+// resolveContainerSourcePath returns the path to copy from the container.
+// If followLink is set and srcPath is a symbolic link, that is the target of
+// the link, and rebaseName is the name the archive entries must be given.
+func resolveContainerSourcePath(ctx context.Context, dockerCli command.Cli, container, srcPath string, followLink bool) (path, rebaseName string) {
+	if !followLink {
+		return srcPath, ""
+	}
+	srcStat, err := dockerCli.Client().ContainerStatPath(ctx, container, srcPath)
+
+	// If the destination is a symbolic link, we should follow it.
+	if err == nil && srcStat.Mode&os.ModeSymlink != 0 {
+		linkTarget := srcStat.LinkTarget
+		if !system.IsAbs(linkTarget) {
+			// Join with the parent directory.
+			srcParent, _ := archive.SplitPathDirEntry(srcPath)
+			linkTarget = filepath.Join(srcParent, linkTarget)
+		}
+
+		return archive.GetRebaseName(srcPath, linkTarget)
+	}
+	return srcPath, ""
+}
 
-	preArchive := content
-	if len(srcInfo.RebaseName) != 0 {
-		_, srcBase := archive.SplitPathDirEntry(srcInfo.Path)
-		preArchive = archive.RebaseArchiveEntries(content, srcBase, srcInfo.RebaseName)
+// rebaseContainerArchive renames the entries of an archive copied from a
+// container after the symbolic link they were resolved from, if any.
+func rebaseContainerArchive(content io.Reader, srcInfo archive.CopyInfo) io.Reader {
+	if len(srcInfo.RebaseName) == 0 {
+		return content
 	}
-	return archive.CopyTo(preArchive, srcInfo, dstPath)
+	_, srcBase := archive.SplitPathDirEntry(srcInfo.Path)
+	return archive.RebaseArchiveEntries(content, srcBase, srcInfo.RebaseName)
 }
 
 // In order to get the copy behavior right, we need to know information
@@ -192,32 +249,7 @@ func copyToContainer(ctx context.Context, dockerCli command.Cli, copyConfig cpCo
 	}
 
 	client := dockerCli.Client()
-	// Prepare destination copy info by stat-ing the container path.
-	dstInfo := archive.CopyInfo{Path: dstPath}
-	dstStat, err := client.ContainerStatPath(ctx, copyConfig.container, dstPath)
-
-	// If the destination is a symbolic link, we should evaluate it.
-	if err == nil && dstStat.Mode&os.ModeSymlink != 0 {
-		linkTarget := dstStat.LinkTarget
-		if !system.IsAbs(linkTarget) {
-			// Join with the parent directory.
-			dstParent, _ := archive.SplitPathDirEntry(dstPath)
-			linkTarget = filepath.Join(dstParent, linkTarget)
-		}
-
-		dstInfo.Path = linkTarget
-		dstStat, err = client.ContainerStatPath(ctx, copyConfig.container, linkTarget)
-	}
-
-	// Ignore any error and assume that the parent directory of the destination
-	// path exists, in which case the copy may still succeed. If there is any
-	// type of conflict (e.g., non-directory overwriting an existing directory
-	// or vice versa) the extraction will fail. If the destination simply did
-	// not exist, but the parent directory does, the extraction will still
-	// succeed.
-	if err == nil {
-		dstInfo.Exists, dstInfo.IsDir = true, dstStat.Mode.IsDir()
-	}
+	dstInfo := containerDestinationInfo(ctx, dockerCli, copyConfig.container, dstPath)
 
 	var (
 		content         io.Reader
@@ -225,7 +257,9 @@ func copyToContainer(ctx context.Context, dockerCli command.Cli, copyConfig cpCo
 	)
 
 	if srcPath == "-" {
-		content = os.Stdin
+		stdin := transferArchive(os.Stdin, srcPath, copyConfig)
+		defer stdin.Close()
+		content = stdin
 		resolvedDstPath = dstInfo.Path
 		if !dstInfo.IsDir {
 			return errors.Errorf("destination \"%s:%s\" must be a directory", copyConfig.container, dstPath)
@@ -237,10 +271,12 @@ func copyToContainer(ctx context.Context, dockerCli command.Cli, copyConfig cpCo
 			return err
 		}
 
-		srcArchive, err := archive.TarResource(srcInfo)
+		tarArchive, err := archive.TarResource(srcInfo)
 		if err != nil {
 			return err
 		}
+		defer tarArchive.Close()
+		srcArchive := transferArchive(tarArchive, srcPath, copyConfig)
 		defer srcArchive.Close()
 
 		// With the stat info about the local source as well as the
@@ -272,6 +308,238 @@ func copyToContainer(ctx context.Context, dockerCli command.Cli, copyConfig cpCo
 	return client.CopyToContainer(ctx, copyConfig.container, resolvedDstPath, content, options)
 }
 
+// containerDestinationInfo prepares the destination copy info by stat-ing
+// the container path.
+func containerDestinationInfo(ctx context.Context, dockerCli command.Cli, container, dstPath string) archive.CopyInfo {
+	client := dockerCli.Client()
+	dstInfo := archive.CopyInfo{Path: dstPath}
+	dstStat, err := client.ContainerStatPath(ctx, container, dstPath)
+
+	// If the destination is a symbolic link, we should evaluate it.
+	if err == nil && dstStat.Mode&os.ModeSymlink != 0 {
+		linkTarget := dstStat.LinkTarget
+		if !system.IsAbs(linkTarget) {
+			// Join with the parent directory.
+			dstParent, _ := archive.SplitPathDirEntry(dstPath)
+			linkTarget = filepath.Join(dstParent, linkTarget)
+		}
+
+		dstInfo.Path = linkTarget
+		dstStat, err = client.ContainerStatPath(ctx, container, linkTarget)
+	}
+
+	// Ignore any error and assume that the parent directory of the destination
+	// path exists, in which case the copy may still succeed. If there is any
+	// type of conflict (e.g., non-directory overwriting an existing directory
+	// or vice versa) the extraction will fail. If the destination simply did
+	// not exist, but the parent directory does, the extraction will still
+	// succeed.
+	if err == nil {
+		dstInfo.Exists, dstInfo.IsDir = true, dstStat.Mode.IsDir()
+	}
+	return dstInfo
+}
+
+// copyAcrossContainers streams the archive of the source path in the source
+// container to the destination container, altering it the same way as when
+// copying from a container to the local filesystem, and from the local
+// filesystem to a container.
+func copyAcrossContainers(ctx context.Context, dockerCli command.Cli, copyConfig cpConfig) error {
+	client := dockerCli.Client()
+	srcPath, rebaseName := resolveContainerSourcePath(ctx, dockerCli, copyConfig.container, copyConfig.sourcePath, copyConfig.followLink)
+
+	response, stat, err := client.CopyFromContainer(ctx, copyConfig.container, srcPath)
+	if err != nil {
+		return err
+	}
+	defer response.Close()
+	content := transferArchive(response, srcPath, copyConfig)
+	defer content.Close()
+
+	srcInfo := archive.CopyInfo{
+		Path:       srcPath,
+		Exists:     true,
+		IsDir:      stat.Mode.IsDir(),
+		RebaseName: rebaseName,
+	}
+	dstInfo := containerDestinationInfo(ctx, dockerCli, copyConfig.destContainer, copyConfig.destPath)
+
+	dstDir, preparedArchive, err := archive.PrepareArchiveCopy(rebaseContainerArchive(content, srcInfo), srcInfo, dstInfo)
+	if err != nil {
+		return err
+	}
+	defer preparedArchive.Close()
+
+	options := types.CopyToContainerOptions{
+		AllowOverwriteDirWithFile: false,
+		CopyUIDGID:                copyConfig.copyUIDGID,
+	}
+	return client.CopyToContainer(ctx, copyConfig.destContainer, dstDir, preparedArchive, options)
+}
+
+// copyPatterns returns the .dockerignore patterns of the files to exclude.
+// The --include patterns are exceptions to the --exclude patterns, and if
+// there are only --include patterns, all other files are excluded.
+func copyPatterns(excludes, includes []string) []string {
+	if len(excludes) == 0 && len(includes) == 0 {
+		return nil
+	}
+	patterns := append([]string{}, excludes...)
+	if len(excludes) == 0 {
+		patterns = append(patterns, "**")
+	}
+	for _, include := range includes {
+		patterns = append(patterns, "!"+include)
+	}
+	return patterns
+}
+
+// transferArchive returns the content of the tar archive of srcPath,
+// without the entries excluded by the copy patterns, and reports the
+// progress of the copy. The archive is passed through unmodified if there
+// is nothing to exclude and no progress to report.
+func transferArchive(src io.Reader, srcPath string, copyConfig cpConfig) io.ReadCloser {
+	if copyConfig.excludes == nil && copyConfig.progress == nil {
+		return ioutil.NopCloser(src)
+	}
+	// The entries of the archive of a directory are prefixed by the name of
+	// the directory, unless its content is copied (e.g., `/path/.`). This
+	// prefix is not matched against the patterns, and neither is the
+	// directory entry itself.
+	hasBase := srcPath != "-" && filepath.Base(srcPath) != "."
+
+	pr, pw := io.Pipe()
+	go func() {
+		tr := tar.NewReader(src)
+		tw := tar.NewWriter(pw)
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				pw.CloseWithError(tw.Close())
+				return
+			}
+			if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+			if copyConfig.excludes != nil {
+				name := strings.TrimPrefix(path.Clean(header.Name), "./")
+				if hasBase {
+					name = strings.TrimPrefix(strings.TrimPrefix(name, strings.SplitN(name, "/", 2)[0]), "/")
+				}
+				if name != "" && name != "." {
+					excluded, err := copyConfig.excludes.Matches(name)
+					if err != nil {
+						pw.CloseWithError(err)
+						return
+					}
+					if excluded {
+						continue
+					}
+				}
+			}
+			if err := tw.WriteHeader(header); err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+			var content io.Reader = tr
+			if copyConfig.progress != nil {
+				copyConfig.progress.addFile()
+				content = &progressReader{reader: tr, progress: copyConfig.progress}
+			}
+			if _, err := io.Copy(tw, content); err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+	}()
+	return pr
+}
+
+// copyProgress displays the size and number of files copied, and the
+// throughput of the copy, on a terminal.
+type copyProgress struct {
+	out       io.Writer
+	mu        sync.Mutex
+	startTime time.Time
+	files     int
+	size      int64
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+func newCopyProgress(out io.Writer) *copyProgress {
+	return &copyProgress{
+		out:  out,
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+}
+
+func (p *copyProgress) addFile() {
+	p.mu.Lock()
+	p.files++
+	p.mu.Unlock()
+}
+
+func (p *copyProgress) addSize(size int) {
+	p.mu.Lock()
+	p.size += int64(size)
+	p.mu.Unlock()
+}
+
+func (p *copyProgress) String() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return formatCopyProgress(p.size, p.files, time.Since(p.startTime))
+}
+
+func formatCopyProgress(size int64, files int, elapsed time.Duration) string {
+	var throughput float64
+	if elapsed > 0 {
+		throughput = float64(size) / elapsed.Seconds()
+	}
+	filesUnit := "files"
+	if files == 1 {
+		filesUnit = "file"
+	}
+	return fmt.Sprintf("Copied %s, %d %s (%s/s)", units.HumanSizeWithPrecision(float64(size), 3), files, filesUnit, units.HumanSizeWithPrecision(throughput, 3))
+}
+
+func (p *copyProgress) start() {
+	p.startTime = time.Now()
+	go func() {
+		defer close(p.done)
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				fmt.Fprintf(p.out, "\r\033[K%s", p)
+			case <-p.stop:
+				fmt.Fprintf(p.out, "\r\033[K%s\n", p)
+				return
+			}
+		}
+	}()
+}
+
+func (p *copyProgress) finish() {
+	close(p.stop)
+	<-p.done
+}
+
+type progressReader struct {
+	reader   io.Reader
+	progress *copyProgress
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.progress.addSize(n)
+	return n, err
+}
+
 // We use `:` as a delimiter between CONTAINER and PATH, but `:` could also be
 // in a valid LOCALPATH, like `file:name.txt`. We can resolve this ambiguity by
 // requiring a LOCALPATH with a `:` to be made explicit with a relative or
@@ -1,11 +1,14 @@
 package container
 
 import (
+	"archive/tar"
 	"io"
 	"io/ioutil"
+	"os"
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/docker/cli/internal/test"
 	"github.com/docker/cli/internal/test/testutil"
@@ -13,6 +16,7 @@ import (
 	"github.com/docker/docker/pkg/archive"
 	"github.com/gotestyourself/gotestyourself/fs"
 	"github.com/gotestyourself/gotestyourself/skip"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -23,14 +27,6 @@ func TestRunCopyWithInvalidArguments(t *testing.T) {
 		options     copyOptions
 		expectedErr string
 	}{
-		{
-			doc: "copy between container",
-			options: copyOptions{
-				source:      "first:/path",
-				destination: "second:/path",
-			},
-			expectedErr: "copying between containers is not supported",
-		},
 		{
 			doc: "copy without a container",
 			options: copyOptions{
@@ -111,6 +107,173 @@ func TestRunCopyFromContainerToFilesystemMissingDestinationDirectory(t *testing.
 	testutil.ErrorContains(t, err, destDir.Join("missing"))
 }
 
+// tarEntries returns the names and content of the entries of a tar archive
+func tarEntries(t *testing.T, content io.Reader) map[string]string {
+	entries := map[string]string{}
+	tr := tar.NewReader(content)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return entries
+		}
+		require.NoError(t, err)
+		data, err := ioutil.ReadAll(tr)
+		require.NoError(t, err)
+		entries[header.Name] = string(data)
+	}
+}
+
+func TestRunCopyAcrossContainers(t *testing.T) {
+	srcDir := fs.NewDir(t, "cp-test",
+		fs.WithFile("file1", "content\n"))
+	defer srcDir.Remove()
+
+	var (
+		copiedTo string
+		entries  map[string]string
+		options  types.CopyToContainerOptions
+	)
+	fakeClient := &fakeClient{
+		containerCopyFromFunc: func(container, srcPath string) (io.ReadCloser, types.ContainerPathStat, error) {
+			assert.Equal(t, "first", container)
+			assert.Equal(t, "/data", srcPath)
+			readCloser, err := archive.TarResourceRebase(srcDir.Path(), "data")
+			return readCloser, types.ContainerPathStat{Name: "data", Mode: os.ModeDir}, err
+		},
+		containerStatPathFunc: func(container, path string) (types.ContainerPathStat, error) {
+			assert.Equal(t, "second", container)
+			return types.ContainerPathStat{}, errors.New("no such file or directory")
+		},
+		containerCopyToFunc: func(container, path string, content io.Reader, opts types.CopyToContainerOptions) error {
+			assert.Equal(t, "second", container)
+			copiedTo, entries, options = path, tarEntries(t, content), opts
+			return nil
+		},
+	}
+	cli := test.NewFakeCli(fakeClient)
+	err := runCopy(cli, copyOptions{source: "first:/data", destination: "second:/backup/copy", copyUIDGID: true})
+	require.NoError(t, err)
+
+	// The destination doesn't exist, the directory is extracted to its
+	// parent, and renamed after it.
+	assert.Equal(t, "/backup", copiedTo)
+	assert.Equal(t, "content\n", entries["copy/file1"])
+	assert.True(t, options.CopyUIDGID)
+}
+
+func TestRunCopyAcrossContainersFollowLink(t *testing.T) {
+	srcDir := fs.NewDir(t, "cp-test",
+		fs.WithFile("target", "content\n"))
+	defer srcDir.Remove()
+
+	var (
+		copiedTo string
+		entries  map[string]string
+	)
+	fakeClient := &fakeClient{
+		containerStatPathFunc: func(container, path string) (types.ContainerPathStat, error) {
+			switch container + ":" + path {
+			case "first:/etc/link":
+				return types.ContainerPathStat{Name: "link", Mode: os.ModeSymlink, LinkTarget: "target"}, nil
+			case "second:/tmp":
+				return types.ContainerPathStat{Name: "tmp", Mode: os.ModeDir}, nil
+			}
+			t.Fatalf("unexpected stat of %s:%s", container, path)
+			return types.ContainerPathStat{}, nil
+		},
+		containerCopyFromFunc: func(container, srcPath string) (io.ReadCloser, types.ContainerPathStat, error) {
+			assert.Equal(t, "/etc/target", srcPath)
+			readCloser, err := archive.TarWithOptions(srcDir.Path(), &archive.TarOptions{IncludeFiles: []string{"target"}})
+			return readCloser, types.ContainerPathStat{Name: "target"}, err
+		},
+		containerCopyToFunc: func(container, path string, content io.Reader, opts types.CopyToContainerOptions) error {
+			copiedTo, entries = path, tarEntries(t, content)
+			return nil
+		},
+	}
+	cli := test.NewFakeCli(fakeClient)
+	err := runCopy(cli, copyOptions{source: "first:/etc/link", destination: "second:/tmp", followLink: true})
+	require.NoError(t, err)
+
+	// The target of the link is copied, named after the link.
+	assert.Equal(t, "/tmp", copiedTo)
+	assert.Equal(t, map[string]string{"link": "content\n"}, entries)
+}
+
+func TestCopyPatterns(t *testing.T) {
+	assert.Nil(t, copyPatterns(nil, nil))
+	assert.Equal(t, []string{"*.log", "!debug.log"}, copyPatterns([]string{"*.log"}, []string{"debug.log"}))
+	assert.Equal(t, []string{"**", "!*.conf"}, copyPatterns(nil, []string{"*.conf"}))
+}
+
+func TestRunCopyFromContainerWithExcludes(t *testing.T) {
+	srcDir := fs.NewDir(t, "cp-test",
+		fs.WithFile("app.log", "log\n"),
+		fs.WithFile("debug.log", "debug\n"),
+		fs.WithFile("config.json", "{}\n"),
+		fs.WithDir("cache", fs.WithFile("entry", "cached\n")))
+	defer srcDir.Remove()
+	destDir := fs.NewDir(t, "cp-test-dest")
+	defer destDir.Remove()
+
+	fakeClient := &fakeClient{
+		containerCopyFromFunc: func(container, srcPath string) (io.ReadCloser, types.ContainerPathStat, error) {
+			readCloser, err := archive.TarResourceRebase(srcDir.Path(), "data")
+			return readCloser, types.ContainerPathStat{Name: "data", Mode: os.ModeDir}, err
+		},
+	}
+	options := copyOptions{
+		source:      "container:/data",
+		destination: destDir.Join("data"),
+		excludes:    []string{"*.log", "cache"},
+		includes:    []string{"debug.log"},
+	}
+	err := runCopy(test.NewFakeCli(fakeClient), options)
+	require.NoError(t, err)
+
+	files, err := ioutil.ReadDir(destDir.Join("data"))
+	require.NoError(t, err)
+	var names []string
+	for _, file := range files {
+		names = append(names, file.Name())
+	}
+	assert.Equal(t, []string{"config.json", "debug.log"}, names)
+}
+
+func TestRunCopyToContainerDirectoryContentWithIncludes(t *testing.T) {
+	srcDir := fs.NewDir(t, "cp-test",
+		fs.WithFile("app.conf", "conf\n"),
+		fs.WithFile("app.log", "log\n"))
+	defer srcDir.Remove()
+
+	var entries map[string]string
+	fakeClient := &fakeClient{
+		containerStatPathFunc: func(container, path string) (types.ContainerPathStat, error) {
+			return types.ContainerPathStat{Name: "etc", Mode: os.ModeDir}, nil
+		},
+		containerCopyToFunc: func(container, path string, content io.Reader, opts types.CopyToContainerOptions) error {
+			entries = tarEntries(t, content)
+			return nil
+		},
+	}
+	options := copyOptions{
+		source:      srcDir.Path() + "/.",
+		destination: "container:/etc",
+		includes:    []string{"*.conf"},
+	}
+	err := runCopy(test.NewFakeCli(fakeClient), options)
+	require.NoError(t, err)
+	assert.Equal(t, "conf\n", entries["./app.conf"])
+	_, copied := entries["./app.log"]
+	assert.False(t, copied)
+}
+
+func TestFormatCopyProgress(t *testing.T) {
+	assert.Equal(t, "Copied 0B, 0 files (0B/s)", formatCopyProgress(0, 0, 0))
+	assert.Equal(t, "Copied 1B, 1 file (0.5B/s)", formatCopyProgress(1, 1, 2*time.Second))
+	assert.Equal(t, "Copied 20MB, 12 files (10MB/s)", formatCopyProgress(20*1000*1000, 12, 2*time.Second))
+}
+
 func TestSplitCpArg(t *testing.T) {
 	var testcases = []struct {
 		doc               string
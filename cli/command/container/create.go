@@ -23,6 +23,7 @@ import (
 type createOptions struct {
 	name     string
 	platform string
+	fromSpec string
 }
 
 // NewCreateCommand creates a new cobra.Command for `docker create`
@@ -33,12 +34,32 @@ func NewCreateCommand(dockerCli command.Cli) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "create [OPTIONS] IMAGE [COMMAND] [ARG...]",
 		Short: "Create a new container",
-		Args:  cli.RequiresMinArgs(1),
+		Args: func(cmd *cobra.Command, args []string) error {
+			// The image is optional when it is set by the run spec
+			if opts.fromSpec != "" {
+				return nil
+			}
+			return cli.RequiresMinArgs(1)(cmd, args)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			copts.Image = args[0]
+			if len(args) > 0 {
+				copts.Image = args[0]
+			}
 			if len(args) > 1 {
 				copts.Args = args[1:]
 			}
+			if opts.fromSpec != "" {
+				spec, err := loadRunSpec(opts.fromSpec)
+				if err != nil {
+					return err
+				}
+				if err := applyRunSpec(cmd.Flags(), spec, &opts.name, copts); err != nil {
+					return err
+				}
+				if copts.Image == "" {
+					return errors.Errorf("no image specified in run spec %s", opts.fromSpec)
+				}
+			}
 			return runCreate(dockerCli, cmd.Flags(), &opts, copts)
 		},
 	}
@@ -47,6 +68,7 @@ func NewCreateCommand(dockerCli command.Cli) *cobra.Command {
 	flags.SetInterspersed(false)
 
 	flags.StringVar(&opts.name, "name", "", "Assign a name to the container")
+	flags.StringVar(&opts.fromSpec, "from-spec", "", "Read the options of the container from a run spec file")
 
 	// Add an explicit help that doesn't have a `-h` to prevent the conflict
 	// with hostname
@@ -25,12 +25,19 @@ type execOptions struct {
 	privileged  bool
 	env         opts.ListOpts
 	workdir     string
+	record      string
+	filter      opts.FilterOpt
+	failFast    bool
+	parallel    int
 	container   string
 	command     []string
 }
 
 func newExecOptions() execOptions {
-	return execOptions{env: opts.NewListOpts(opts.ValidateEnv)}
+	return execOptions{
+		env:    opts.NewListOpts(opts.ValidateEnv),
+		filter: opts.NewFilterOpt(),
+	}
 }
 
 // NewExecCommand creates a new cobra.Command for `docker exec`
@@ -38,12 +45,21 @@ func NewExecCommand(dockerCli command.Cli) *cobra.Command {
 	options := newExecOptions()
 
 	cmd := &cobra.Command{
-		Use:   "exec [OPTIONS] CONTAINER COMMAND [ARG...]",
+		Use:   "exec [OPTIONS] CONTAINER[,CONTAINER...] COMMAND [ARG...]",
 		Short: "Run a command in a running container",
-		Args:  cli.RequiresMinArgs(2),
+		Args: func(cmd *cobra.Command, args []string) error {
+			// Containers selected with --filter are not passed as arguments
+			if options.filter.Value().Len() > 0 {
+				return cli.RequiresMinArgs(1)(cmd, args)
+			}
+			return cli.RequiresMinArgs(2)(cmd, args)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			options.container = args[0]
-			options.command = args[1:]
+			if options.filter.Value().Len() == 0 {
+				options.container = args[0]
+				args = args[1:]
+			}
+			options.command = args
 			return runExec(dockerCli, options)
 		},
 	}
@@ -61,11 +77,22 @@ func NewExecCommand(dockerCli command.Cli) *cobra.Command {
 	flags.SetAnnotation("env", "version", []string{"1.25"})
 	flags.StringVarP(&options.workdir, "workdir", "w", "", "Working directory inside the container")
 	flags.SetAnnotation("workdir", "version", []string{"1.35"})
+	flags.StringVar(&options.record, "record", "", "Record the session to an asciicast file")
+	flags.VarP(&options.filter, "filter", "", "Run the command in the running containers matching the filter, as in 'docker ps'")
+	flags.BoolVar(&options.failFast, "fail-fast", false, "Stop at the first container the command fails in, when running in multiple containers")
+	flags.IntVar(&options.parallel, "parallel", defaultParallel, "Maximum number of containers to run the command in concurrently")
 
 	return cmd
 }
 
 func runExec(dockerCli command.Cli, options execOptions) error {
+	if options.record != "" && options.detach {
+		return errors.New("Conflicting options: --record and -d")
+	}
+	if isMultiExec(options) {
+		return runMultiExec(dockerCli, options)
+	}
+
 	execConfig := parseExec(options, dockerCli.ConfigFile())
 	ctx := context.Background()
 	client := dockerCli.Client()
@@ -83,6 +110,15 @@ func runExec(dockerCli command.Cli, options execOptions) error {
 		}
 	}
 
+	var recorder *sessionRecorder
+	if options.record != "" {
+		var err error
+		if recorder, err = createSessionRecorder(options.record, dockerCli, options.container); err != nil {
+			return err
+		}
+		defer recorder.Close()
+	}
+
 	response, err := client.ContainerExecCreate(ctx, options.container, *execConfig)
 	if err != nil {
 		return err
@@ -100,10 +136,10 @@ func runExec(dockerCli command.Cli, options execOptions) error {
 		}
 		return client.ContainerExecStart(ctx, execID, execStartCheck)
 	}
-	return interactiveExec(ctx, dockerCli, execConfig, execID)
+	return interactiveExec(ctx, dockerCli, execConfig, execID, recorder)
 }
 
-func interactiveExec(ctx context.Context, dockerCli command.Cli, execConfig *types.ExecConfig, execID string) error {
+func interactiveExec(ctx context.Context, dockerCli command.Cli, execConfig *types.ExecConfig, execID string, recorder *sessionRecorder) error {
 	// Interactive exec requested.
 	var (
 		out, stderr io.Writer
@@ -147,6 +183,7 @@ func interactiveExec(ctx context.Context, dockerCli command.Cli, execConfig *typ
 				resp:         resp,
 				tty:          execConfig.Tty,
 				detachKeys:   execConfig.DetachKeys,
+				recorder:     recorder,
 			}
 
 			return streamer.stream(ctx)
@@ -154,7 +191,7 @@ func interactiveExec(ctx context.Context, dockerCli command.Cli, execConfig *typ
 	}()
 
 	if execConfig.Tty && dockerCli.In().IsTerminal() {
-		if err := MonitorTtySize(ctx, dockerCli, execID, true); err != nil {
+		if err := monitorTtySize(ctx, dockerCli, execID, true, recorder); err != nil {
 			fmt.Fprintln(dockerCli.Err(), "Error monitoring TTY size:", err)
 		}
 	}
@@ -137,6 +137,15 @@ func TestRunExec(t *testing.T) {
 			},
 			expectedError: "failed inspect",
 		},
+		{
+			doc: "record detached",
+			options: withDefaultOpts(execOptions{
+				container: "thecontainer",
+				detach:    true,
+				record:    "session.cast",
+			}),
+			expectedError: "Conflicting options: --record and -d",
+		},
 		{
 			doc:           "missing exec ID",
 			options:       newExecOptions(),
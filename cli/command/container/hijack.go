@@ -31,6 +31,9 @@ type hijackedIOStreamer struct {
 
 	tty        bool
 	detachKeys string
+
+	// recorder, if set, records the session.
+	recorder *sessionRecorder
 }
 
 // stream handles setting up the IO and then begins streaming stdin/stdout
@@ -45,6 +48,8 @@ func (h *hijackedIOStreamer) stream(ctx context.Context) error {
 
 	defer restoreInput()
 
+	h.setupRecording()
+
 	outputDone := h.beginOutputStream(restoreInput)
 	inputDone, detached := h.beginInputStream(restoreInput)
 
@@ -108,6 +113,24 @@ func (h *hijackedIOStreamer) setupInput() (restore func(), err error) {
 	return restore, nil
 }
 
+// setupRecording tees the streams into the recorder. The input is recorded
+// after the detach escape sequence has been handled, so that the sequence is
+// not part of the recording.
+func (h *hijackedIOStreamer) setupRecording() {
+	if h.recorder == nil {
+		return
+	}
+	if h.inputStream != nil {
+		h.inputStream = ioutils.NewReadCloserWrapper(io.TeeReader(h.inputStream, h.recorder.input()), h.inputStream.Close)
+	}
+	if h.outputStream != nil {
+		h.outputStream = io.MultiWriter(h.outputStream, h.recorder.output())
+	}
+	if h.errorStream != nil {
+		h.errorStream = io.MultiWriter(h.errorStream, h.recorder.output())
+	}
+}
+
 func (h *hijackedIOStreamer) beginOutputStream(restoreInput func()) <-chan error {
 	if h.outputStream == nil && h.errorStream == nil {
 		// There is no need to copy output.
@@ -1,9 +1,14 @@
 package container
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/command/inspect"
+	"github.com/docker/docker/api/types/container"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"golang.org/x/net/context"
 )
@@ -29,7 +34,7 @@ func newInspectCommand(dockerCli command.Cli) *cobra.Command {
 	}
 
 	flags := cmd.Flags()
-	flags.StringVarP(&opts.format, "format", "f", "", "Format the output using the given Go template")
+	flags.StringVarP(&opts.format, "format", "f", "", "Format the output using the given Go template, or 'runcmd' or 'runspec' to print how to recreate the container")
 	flags.BoolVarP(&opts.size, "size", "s", false, "Display total file sizes")
 
 	return cmd
@@ -39,8 +44,57 @@ func runInspect(dockerCli command.Cli, opts inspectOptions) error {
 	client := dockerCli.Client()
 	ctx := context.Background()
 
+	switch opts.format {
+	case runCommandFormat, runSpecFormat:
+		return runInspectRunSpec(ctx, dockerCli, opts)
+	}
+
 	getRefFunc := func(ref string) (interface{}, []byte, error) {
 		return client.ContainerInspectWithRaw(ctx, ref, opts.size)
 	}
 	return inspect.Inspect(dockerCli.Out(), opts.refs, opts.format, getRefFunc)
 }
+
+// runInspectRunSpec prints the `docker run` command line or the run spec to
+// recreate each container.
+func runInspectRunSpec(ctx context.Context, dockerCli command.Cli, opts inspectOptions) error {
+	client := dockerCli.Client()
+
+	var (
+		errs    []string
+		printed int
+	)
+	for _, ref := range opts.refs {
+		c, err := client.ContainerInspect(ctx, ref)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		// The image may have been removed, in which case the values set by
+		// the image can't be told apart from the container options.
+		var imageConfig *container.Config
+		if image, _, err := client.ImageInspectWithRaw(ctx, c.Image); err == nil {
+			imageConfig = image.Config
+		}
+		spec := newRunSpec(c, imageConfig)
+
+		if opts.format == runCommandFormat {
+			detach := !c.Config.AttachStdout && !c.Config.AttachStderr
+			fmt.Fprintln(dockerCli.Out(), runCommandLine(spec, detach))
+			continue
+		}
+		content, err := marshalRunSpec(spec)
+		if err != nil {
+			return err
+		}
+		if printed > 0 {
+			fmt.Fprintln(dockerCli.Out(), "---")
+		}
+		dockerCli.Out().Write(content)
+		printed++
+	}
+	if len(errs) > 0 {
+		return errors.Errorf("%s", strings.Join(errs, "\n"))
+	}
+	return nil
+}
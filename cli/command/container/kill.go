@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -13,6 +12,7 @@ import (
 
 type killOptions struct {
 	signal string
+	bulk   bulkOptions
 
 	containers []string
 }
@@ -24,7 +24,7 @@ func NewKillCommand(dockerCli command.Cli) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "kill [OPTIONS] CONTAINER [CONTAINER...]",
 		Short: "Kill one or more running containers",
-		Args:  cli.RequiresMinArgs(1),
+		Args:  requiresContainers(&opts.bulk),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.containers = args
 			return runKill(dockerCli, &opts)
@@ -33,15 +33,21 @@ func NewKillCommand(dockerCli command.Cli) *cobra.Command {
 
 	flags := cmd.Flags()
 	flags.StringVarP(&opts.signal, "signal", "s", "KILL", "Signal to send to the container")
+	addBulkFlags(flags, &opts.bulk)
 	return cmd
 }
 
 func runKill(dockerCli command.Cli, opts *killOptions) error {
-	var errs []string
 	ctx := context.Background()
-	errChan := parallelOperation(ctx, opts.containers, func(ctx context.Context, container string) error {
+	kill := func(ctx context.Context, container string) error {
 		return dockerCli.Client().ContainerKill(ctx, container, opts.signal)
-	})
+	}
+	if opts.bulk.selected() {
+		return runBulkOperation(ctx, dockerCli, &opts.bulk, "kill", "killed", kill)
+	}
+
+	var errs []string
+	errChan := parallelOperation(ctx, opts.containers, opts.bulk.parallel, kill)
 	for _, name := range opts.containers {
 		if err := <-errChan; err != nil {
 			errs = append(errs, err.Error())
@@ -5,8 +5,10 @@ import (
 
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
+	dockeropts "github.com/docker/cli/opts"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"golang.org/x/net/context"
 )
@@ -18,21 +20,35 @@ type logsOptions struct {
 	timestamps bool
 	details    bool
 	tail       string
+	filter     dockeropts.FilterOpt
 
-	container string
+	container  string
+	containers []string
 }
 
 // NewLogsCommand creates a new cobra.Command for `docker logs`
 func NewLogsCommand(dockerCli command.Cli) *cobra.Command {
-	var opts logsOptions
+	opts := logsOptions{filter: dockeropts.NewFilterOpt()}
 
 	cmd := &cobra.Command{
-		Use:   "logs [OPTIONS] CONTAINER",
+		Use:   "logs [OPTIONS] CONTAINER [CONTAINER...]",
 		Short: "Fetch the logs of a container",
-		Args:  cli.ExactArgs(1),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if opts.filter.Value().Len() == 0 {
+				return cli.RequiresMinArgs(1)(cmd, args)
+			}
+			if len(args) > 0 {
+				return errors.Errorf("%q accepts no container when --filter is specified.\nSee '%s --help'.", cmd.CommandPath(), cmd.CommandPath())
+			}
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			opts.container = args[0]
-			return runLogs(dockerCli, &opts)
+			if len(args) == 1 {
+				opts.container = args[0]
+				return runLogs(dockerCli, &opts)
+			}
+			opts.containers = args
+			return runMultiLogs(dockerCli, &opts)
 		},
 	}
 
@@ -44,6 +60,7 @@ func NewLogsCommand(dockerCli command.Cli) *cobra.Command {
 	flags.BoolVarP(&opts.timestamps, "timestamps", "t", false, "Show timestamps")
 	flags.BoolVar(&opts.details, "details", false, "Show extra details provided to logs")
 	flags.StringVar(&opts.tail, "tail", "all", "Number of lines to show from the end of the logs")
+	flags.VarP(&opts.filter, "filter", "", "Fetch the logs of the containers matching the filter, as in 'docker ps --all'")
 	return cmd
 }
 
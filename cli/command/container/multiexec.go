@@ -0,0 +1,173 @@
+package container
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// errExecCancelled is returned for the containers the command was not run in,
+// or stopped being followed in, after it failed in another container with
+// --fail-fast.
+var errExecCancelled = errors.New("cancelled")
+
+// isMultiExec returns whether the command runs in multiple containers, which
+// are either selected with --filter or passed as a comma separated list.
+func isMultiExec(options execOptions) bool {
+	return options.filter.Value().Len() > 0 || strings.Contains(options.container, ",")
+}
+
+// runMultiExec runs the command concurrently in multiple containers, and
+// prefixes each line of output with the name of the container it comes from.
+func runMultiExec(dockerCli command.Cli, options execOptions) error {
+	if options.interactive || options.tty || options.record != "" {
+		return errors.New("conflicting options: --interactive, --tty and --record cannot be used with multiple containers")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	containers, err := multiExecContainers(ctx, dockerCli, options)
+	if err != nil {
+		return err
+	}
+	execConfig := parseExec(options, dockerCli.ConfigFile())
+
+	var width int
+	for _, container := range containers {
+		if len(container) > width {
+			width = len(container)
+		}
+	}
+
+	var mu sync.Mutex
+	errChan := parallelOperation(ctx, containers, options.parallel, func(ctx context.Context, container string) error {
+		if ctx.Err() != nil {
+			return errExecCancelled
+		}
+		prefix := fmt.Sprintf("%-*s | ", width, container)
+		stdout := newPrefixWriter(dockerCli.Out(), &mu, prefix)
+		stderr := newPrefixWriter(dockerCli.Err(), &mu, prefix)
+		err := execInContainer(ctx, dockerCli, container, *execConfig, stdout, stderr)
+		stdout.Flush()
+		stderr.Flush()
+		if err != nil && options.failFast {
+			cancel()
+		}
+		return err
+	})
+
+	var (
+		failures   []string
+		statusCode int
+	)
+	for _, container := range containers {
+		switch err := (<-errChan).(type) {
+		case nil:
+		case cli.StatusError:
+			failures = append(failures, fmt.Sprintf("%s: exited with status %d", container, err.StatusCode))
+			if err.StatusCode > statusCode {
+				statusCode = err.StatusCode
+			}
+		default:
+			failures = append(failures, fmt.Sprintf("%s: %v", container, err))
+		}
+	}
+	if len(failures) > 0 {
+		return cli.StatusError{Status: strings.Join(failures, "\n"), StatusCode: statusCode}
+	}
+	return nil
+}
+
+// multiExecContainers returns the containers passed as a comma separated
+// list, or the running containers matching the filters.
+func multiExecContainers(ctx context.Context, dockerCli command.Cli, options execOptions) ([]string, error) {
+	if options.filter.Value().Len() == 0 {
+		var containers []string
+		seen := make(map[string]bool)
+		for _, container := range strings.Split(options.container, ",") {
+			container = strings.TrimSpace(container)
+			if container != "" && !seen[container] {
+				seen[container] = true
+				containers = append(containers, container)
+			}
+		}
+		if len(containers) == 0 {
+			return nil, errors.Errorf("invalid container list %q", options.container)
+		}
+		return containers, nil
+	}
+
+	list, err := dockerCli.Client().ContainerList(ctx, types.ContainerListOptions{Filters: options.filter.Value()})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, errors.New("no running container matches the filters")
+	}
+	containers := make([]string, 0, len(list))
+	for _, c := range list {
+		containers = append(containers, containerName(c))
+	}
+	return containers, nil
+}
+
+// execInContainer runs the command in the container, copying its output to
+// stdout and stderr. A non-zero exit code of the command is returned as a
+// cli.StatusError.
+func execInContainer(ctx context.Context, dockerCli command.Cli, container string, execConfig types.ExecConfig, stdout, stderr io.Writer) error {
+	client := dockerCli.Client()
+
+	response, err := client.ContainerExecCreate(ctx, container, execConfig)
+	if err != nil {
+		return err
+	}
+	if response.ID == "" {
+		return errors.New("exec ID empty")
+	}
+	if execConfig.Detach {
+		return client.ContainerExecStart(ctx, response.ID, types.ExecStartCheck{Detach: true})
+	}
+
+	resp, err := client.ContainerExecAttach(ctx, response.ID, types.ExecStartCheck{})
+	if err != nil {
+		return err
+	}
+	defer resp.Close()
+
+	copyDone := make(chan error, 1)
+	go func() {
+		_, err := stdcopy.StdCopy(stdout, stderr, resp.Reader)
+		copyDone <- err
+	}()
+	select {
+	case err := <-copyDone:
+		if err != nil {
+			return err
+		}
+	case <-ctx.Done():
+		// Closing the connection stops the copy.
+		resp.Close()
+		<-copyDone
+		return errExecCancelled
+	}
+
+	// The command exited, its status is reported even if ctx was cancelled
+	// by a failure in another container in the meantime.
+	inspect, err := client.ContainerExecInspect(context.Background(), response.ID)
+	if err != nil {
+		return err
+	}
+	if inspect.ExitCode != 0 {
+		return cli.StatusError{StatusCode: inspect.ExitCode}
+	}
+	return nil
+}
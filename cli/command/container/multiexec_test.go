@@ -0,0 +1,117 @@
+package container
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/cli/internal/test/testutil"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// multiExecClient returns a client running commands printing stdout and
+// stderr, and exiting with the exit code of the container.
+func multiExecClient(stdout, stderr string, exitCodes map[string]int) *fakeClient {
+	return &fakeClient{
+		execCreateFunc: func(container string, config types.ExecConfig) (types.IDResponse, error) {
+			return types.IDResponse{ID: container}, nil
+		},
+		execAttachFunc: func(execID string, config types.ExecStartCheck) (types.HijackedResponse, error) {
+			output := new(bytes.Buffer)
+			stdcopy.NewStdWriter(output, stdcopy.Stdout).Write([]byte(stdout))
+			stdcopy.NewStdWriter(output, stdcopy.Stderr).Write([]byte(stderr))
+			_, conn := net.Pipe()
+			return types.HijackedResponse{Conn: conn, Reader: bufio.NewReader(output)}, nil
+		},
+		execInspectFunc: func(execID string) (types.ContainerExecInspect, error) {
+			return types.ContainerExecInspect{ExitCode: exitCodes[execID]}, nil
+		},
+	}
+}
+
+func sortedLines(s string) []string {
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	sort.Strings(lines)
+	return lines
+}
+
+func TestExecWithFilter(t *testing.T) {
+	var listOptions types.ContainerListOptions
+	fakeClient := multiExecClient("ready\nload: 0.5", "warning\n", map[string]int{"web-2": 3})
+	fakeClient.containerListFunc = func(options types.ContainerListOptions) ([]types.Container, error) {
+		listOptions = options
+		return []types.Container{
+			{Names: []string{"/web-1"}},
+			{Names: []string{"/web-2"}},
+			{Names: []string{"/db"}},
+		}, nil
+	}
+	dockerCli := test.NewFakeCli(fakeClient)
+	cmd := NewExecCommand(dockerCli)
+	cmd.SetOutput(ioutil.Discard)
+	cmd.SetArgs([]string{"--filter", "label=com.example.app=web", "uptime", "-p"})
+	err := cmd.Execute()
+
+	assert.Equal(t, []string{"com.example.app=web"}, listOptions.Filters.Get("label"))
+	assert.False(t, listOptions.All)
+	assert.Equal(t, []string{
+		"db    | load: 0.5",
+		"db    | ready",
+		"web-1 | load: 0.5",
+		"web-1 | ready",
+		"web-2 | load: 0.5",
+		"web-2 | ready",
+	}, sortedLines(dockerCli.OutBuffer().String()))
+	assert.Equal(t, []string{
+		"db    | warning",
+		"web-1 | warning",
+		"web-2 | warning",
+	}, sortedLines(dockerCli.ErrBuffer().String()))
+	assert.Equal(t, cli.StatusError{Status: "web-2: exited with status 3", StatusCode: 3}, err)
+}
+
+func TestExecFailFast(t *testing.T) {
+	options := withDefaultOpts(execOptions{
+		container: "a,b,a,c",
+		failFast:  true,
+		parallel:  1,
+	})
+	fakeClient := multiExecClient("", "", map[string]int{"a": 1})
+	err := runExec(test.NewFakeCli(fakeClient), options)
+	assert.Equal(t, cli.StatusError{Status: "a: exited with status 1\nb: cancelled\nc: cancelled", StatusCode: 1}, err)
+}
+
+func TestExecMultipleContainersConflicts(t *testing.T) {
+	for _, options := range []execOptions{
+		{container: "a,b", tty: true},
+		{container: "a,b", interactive: true},
+		{container: "a,b", record: "session.cast"},
+	} {
+		err := runExec(test.NewFakeCli(&fakeClient{}), withDefaultOpts(options))
+		testutil.ErrorContains(t, err, "cannot be used with multiple containers")
+	}
+}
+
+func TestPrefixWriter(t *testing.T) {
+	var mu sync.Mutex
+	buf := new(bytes.Buffer)
+	w := newPrefixWriter(buf, &mu, "web | ")
+	for _, s := range []string{"one\ntw", "o\n", "\nthree"} {
+		n, err := w.Write([]byte(s))
+		require.NoError(t, err)
+		assert.Equal(t, len(s), n)
+	}
+	assert.Equal(t, "web | one\nweb | two\nweb | \n", buf.String())
+	require.NoError(t, w.Flush())
+	assert.Equal(t, "web | one\nweb | two\nweb | \nweb | three\n", buf.String())
+}
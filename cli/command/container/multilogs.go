@@ -0,0 +1,466 @@
+package container
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// logsMergeWindow is how long log lines are buffered when following the logs
+// of multiple containers, so that lines received from different containers
+// around the same time are printed in timestamp order.
+const logsMergeWindow = 200 * time.Millisecond
+
+// logsColors are the ANSI colors the names of the containers are printed in.
+var logsColors = []int{36, 33, 32, 35, 34, 31}
+
+// A logEntry is a line of the logs of a container.
+type logEntry struct {
+	container string
+	stderr    bool
+	timestamp time.Time
+	// rawTimestamp is the timestamp, as sent by the daemon
+	rawTimestamp string
+	message      []byte
+	// received is when the entry was received
+	received time.Time
+}
+
+// runMultiLogs fetches the logs of multiple containers, and prints them
+// merged in timestamp order, each line prefixed with the name of its
+// container.
+func runMultiLogs(dockerCli command.Cli, opts *logsOptions) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		eventC    <-chan events.Message
+		eventErrC <-chan error
+	)
+	watch := opts.follow && opts.filter.Value().Len() > 0
+	if watch {
+		// Events are subscribed to before listing the containers, so that
+		// no container starting in between is missed.
+		eventC, eventErrC = dockerCli.Client().Events(ctx, types.EventsOptions{
+			Filters: filters.NewArgs(filters.Arg("type", "container"), filters.Arg("event", "start")),
+		})
+	}
+
+	containers, err := logsContainers(ctx, dockerCli, opts, true)
+	if err != nil {
+		return err
+	}
+	if len(containers) == 0 && !watch {
+		return errors.New("no container matches the filters")
+	}
+
+	printer := newLogPrinter(dockerCli, opts)
+	printer.add(containers...)
+
+	// Timestamps are always fetched, to merge the logs.
+	options := types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Since:      opts.since,
+		Until:      opts.until,
+		Timestamps: true,
+		Follow:     opts.follow,
+		Tail:       opts.tail,
+		Details:    opts.details,
+	}
+	if !opts.follow {
+		return mergeLogs(ctx, dockerCli, containers, options, printer)
+	}
+
+	f := &logsFollower{
+		dockerCli: dockerCli,
+		opts:      opts,
+		printer:   printer,
+		entries:   make(chan logEntry, 64),
+		results:   make(chan logsResult),
+		streaming: make(map[string]bool),
+		restarted: make(map[string]types.ContainerLogsOptions),
+	}
+	return f.follow(ctx, containers, options, eventC, eventErrC)
+}
+
+// logsContainers returns the containers passed as arguments, or the
+// containers matching the filters, including the stopped ones if all is set.
+func logsContainers(ctx context.Context, dockerCli command.Cli, opts *logsOptions, all bool) ([]string, error) {
+	if opts.filter.Value().Len() == 0 {
+		return opts.containers, nil
+	}
+	list, err := dockerCli.Client().ContainerList(ctx, types.ContainerListOptions{
+		All:     all,
+		Filters: opts.filter.Value(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	containers := make([]string, 0, len(list))
+	for _, c := range list {
+		containers = append(containers, containerName(c))
+	}
+	return containers, nil
+}
+
+// mergeLogs prints the logs of the containers in timestamp order. The logs
+// of each container are already ordered, so the oldest of the next lines of
+// each container is printed first.
+func mergeLogs(ctx context.Context, dockerCli command.Cli, containers []string, options types.ContainerLogsOptions, printer *logPrinter) error {
+	sources := make([]chan logEntry, len(containers))
+	errs := make([]error, len(containers))
+	for i, container := range containers {
+		sources[i] = make(chan logEntry, 64)
+		go func(i int, container string) {
+			defer close(sources[i])
+			errs[i] = streamLogs(ctx, dockerCli, container, options, sources[i])
+		}(i, container)
+	}
+
+	next := make([]*logEntry, len(sources))
+	for {
+		oldest := -1
+		for i, source := range sources {
+			if next[i] == nil && source != nil {
+				if entry, ok := <-source; ok {
+					next[i] = &entry
+				} else {
+					sources[i] = nil
+				}
+			}
+			if next[i] != nil && (oldest < 0 || next[i].timestamp.Before(next[oldest].timestamp)) {
+				oldest = i
+			}
+		}
+		if oldest < 0 {
+			break
+		}
+		if err := printer.print(*next[oldest]); err != nil {
+			return err
+		}
+		next[oldest] = nil
+	}
+
+	var messages []string
+	for _, err := range errs {
+		if err != nil {
+			messages = append(messages, err.Error())
+		}
+	}
+	if len(messages) > 0 {
+		return errors.New(strings.Join(messages, "\n"))
+	}
+	return nil
+}
+
+type logsResult struct {
+	container string
+	err       error
+}
+
+// A logsFollower follows the logs of multiple containers, and of the
+// containers matching the filters that start later.
+type logsFollower struct {
+	dockerCli command.Cli
+	opts      *logsOptions
+	printer   *logPrinter
+
+	entries   chan logEntry
+	results   chan logsResult
+	streaming map[string]bool
+	// restarted holds the options to follow the new logs of the containers
+	// that started again before the stream of their previous logs ended.
+	restarted map[string]types.ContainerLogsOptions
+	buffer    []logEntry
+	errs      []string
+}
+
+func (f *logsFollower) follow(ctx context.Context, containers []string, options types.ContainerLogsOptions, eventC <-chan events.Message, eventErrC <-chan error) error {
+	for _, container := range containers {
+		f.start(ctx, container, options)
+	}
+
+	ticker := time.NewTicker(logsMergeWindow / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case entry := <-f.entries:
+			entry.received = time.Now()
+			f.buffer = append(f.buffer, entry)
+		case <-ticker.C:
+			if err := f.flush(time.Now().Add(-logsMergeWindow)); err != nil {
+				return err
+			}
+		case result := <-f.results:
+			delete(f.streaming, result.container)
+			if result.err != nil {
+				f.errs = append(f.errs, result.err.Error())
+			}
+			if restartOptions, ok := f.restarted[result.container]; ok {
+				delete(f.restarted, result.container)
+				f.start(ctx, result.container, restartOptions)
+			}
+			if len(f.streaming) == 0 && eventC == nil {
+				return f.close(nil)
+			}
+		case event := <-eventC:
+			f.startMatching(ctx, event, options)
+		case err := <-eventErrC:
+			return f.close(err)
+		}
+	}
+}
+
+// start follows the logs of the container.
+func (f *logsFollower) start(ctx context.Context, container string, options types.ContainerLogsOptions) {
+	f.streaming[container] = true
+	go func() {
+		result := logsResult{container: container, err: streamLogs(ctx, f.dockerCli, container, options, f.entries)}
+		select {
+		case f.results <- result:
+		case <-ctx.Done():
+		}
+	}()
+}
+
+// startMatching follows the logs of the running containers matching the
+// filters that are not followed yet, from the time of the start event. If
+// the container of the event restarted while its previous logs are still
+// followed, its new logs are followed once the previous stream ends.
+func (f *logsFollower) startMatching(ctx context.Context, event events.Message, options types.ContainerLogsOptions) {
+	containers, err := logsContainers(ctx, f.dockerCli, f.opts, false)
+	if err != nil {
+		f.errs = append(f.errs, err.Error())
+		return
+	}
+	options.Since = fmt.Sprintf("%d.%09d", event.TimeNano/int64(time.Second), event.TimeNano%int64(time.Second))
+	options.Tail = "all"
+	for _, container := range containers {
+		switch {
+		case !f.streaming[container]:
+			f.printer.add(container)
+			f.start(ctx, container, options)
+		case container == event.Actor.Attributes["name"]:
+			f.restarted[container] = options
+		}
+	}
+}
+
+// flush prints, in timestamp order, the buffered entries received before
+// cutoff, along with the entries received since with older timestamps.
+func (f *logsFollower) flush(cutoff time.Time) error {
+	var (
+		last  time.Time
+		found bool
+	)
+	for _, entry := range f.buffer {
+		if !entry.received.After(cutoff) && (!found || entry.timestamp.After(last)) {
+			last = entry.timestamp
+			found = true
+		}
+	}
+	if !found {
+		return nil
+	}
+
+	sort.SliceStable(f.buffer, func(i, j int) bool {
+		return f.buffer[i].timestamp.Before(f.buffer[j].timestamp)
+	})
+	n := 0
+	for n < len(f.buffer) && !f.buffer[n].timestamp.After(last) {
+		if err := f.printer.print(f.buffer[n]); err != nil {
+			return err
+		}
+		n++
+	}
+	f.buffer = append(f.buffer[:0], f.buffer[n:]...)
+	return nil
+}
+
+// close prints the remaining entries, and returns the errors that occurred.
+func (f *logsFollower) close(err error) error {
+	// Streams send their entries before their result, so all the entries
+	// of the streams that ended are available.
+	for drained := false; !drained; {
+		select {
+		case entry := <-f.entries:
+			f.buffer = append(f.buffer, entry)
+		default:
+			drained = true
+		}
+	}
+	if flushErr := f.flush(time.Now()); flushErr != nil {
+		return flushErr
+	}
+	if err != nil {
+		f.errs = append(f.errs, err.Error())
+	}
+	if len(f.errs) > 0 {
+		return errors.New(strings.Join(f.errs, "\n"))
+	}
+	return nil
+}
+
+// streamLogs sends the lines of the logs of the container to entries.
+func streamLogs(ctx context.Context, dockerCli command.Cli, container string, options types.ContainerLogsOptions, entries chan<- logEntry) error {
+	c, err := dockerCli.Client().ContainerInspect(ctx, container)
+	if err != nil {
+		return err
+	}
+	responseBody, err := dockerCli.Client().ContainerLogs(ctx, container, options)
+	if err != nil {
+		return err
+	}
+	defer responseBody.Close()
+
+	stdout := &logLineWriter{ctx: ctx, container: container, entries: entries}
+	stderr := &logLineWriter{ctx: ctx, container: container, entries: entries, stderr: true}
+	if c.Config.Tty {
+		_, err = io.Copy(stdout, responseBody)
+	} else {
+		_, err = stdcopy.StdCopy(stdout, stderr, responseBody)
+	}
+	if err != nil {
+		return err
+	}
+	if err := stdout.Flush(); err != nil {
+		return err
+	}
+	return stderr.Flush()
+}
+
+// A logLineWriter splits the logs written to it into entries.
+type logLineWriter struct {
+	ctx       context.Context
+	container string
+	stderr    bool
+	entries   chan<- logEntry
+	buf       []byte
+	// last is the timestamp of the last entry, used for the lines
+	// without a timestamp
+	last time.Time
+}
+
+func (w *logLineWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		if err := w.send(w.buf[:i+1]); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[i+1:]
+	}
+}
+
+// Flush sends the last line, if it is not terminated by a newline.
+func (w *logLineWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	line := append(w.buf, '\n')
+	w.buf = nil
+	return w.send(line)
+}
+
+func (w *logLineWriter) send(line []byte) error {
+	entry := logEntry{container: w.container, stderr: w.stderr, timestamp: w.last}
+	message := line
+	if i := bytes.IndexByte(line, ' '); i > 0 {
+		if timestamp, err := time.Parse(time.RFC3339Nano, string(line[:i])); err == nil {
+			entry.timestamp = timestamp
+			entry.rawTimestamp = string(line[:i])
+			message = line[i+1:]
+			w.last = timestamp
+		}
+	}
+	entry.message = append([]byte(nil), message...)
+
+	select {
+	case w.entries <- entry:
+		return nil
+	case <-w.ctx.Done():
+		return w.ctx.Err()
+	}
+}
+
+// A logPrinter prints log entries, prefixed with the name of their
+// container.
+type logPrinter struct {
+	out, err   io.Writer
+	timestamps bool
+	color      bool
+
+	width    int
+	colors   map[string]int
+	prefixes map[string]string
+}
+
+func newLogPrinter(dockerCli command.Cli, opts *logsOptions) *logPrinter {
+	return &logPrinter{
+		out:        dockerCli.Out(),
+		err:        dockerCli.Err(),
+		timestamps: opts.timestamps,
+		color:      dockerCli.Out().IsTerminal(),
+		colors:     make(map[string]int),
+		prefixes:   make(map[string]string),
+	}
+}
+
+// add assigns a color to the containers, and aligns the prefixes on the
+// longest name.
+func (p *logPrinter) add(containers ...string) {
+	for _, container := range containers {
+		if _, ok := p.colors[container]; !ok {
+			p.colors[container] = logsColors[len(p.colors)%len(logsColors)]
+		}
+		if len(container) > p.width {
+			p.width = len(container)
+			p.prefixes = make(map[string]string)
+		}
+	}
+}
+
+func (p *logPrinter) prefix(container string) string {
+	if prefix, ok := p.prefixes[container]; ok {
+		return prefix
+	}
+	prefix := fmt.Sprintf("%-*s |", p.width, container)
+	if p.color {
+		prefix = fmt.Sprintf("\x1b[%dm%s\x1b[0m", p.colors[container], prefix)
+	}
+	prefix += " "
+	p.prefixes[container] = prefix
+	return prefix
+}
+
+func (p *logPrinter) print(entry logEntry) error {
+	line := []byte(p.prefix(entry.container))
+	if p.timestamps && entry.rawTimestamp != "" {
+		line = append(line, entry.rawTimestamp...)
+		line = append(line, ' ')
+	}
+	line = append(line, entry.message...)
+
+	w := p.out
+	if entry.stderr {
+		w = p.err
+	}
+	_, err := w.Write(line)
+	return err
+}
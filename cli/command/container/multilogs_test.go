@@ -0,0 +1,220 @@
+package container
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/cli/internal/test/testutil"
+	dockeropts "github.com/docker/cli/opts"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// multiLogsClient returns a client serving the logs of the containers, where
+// messages starting with "!" are written to stderr.
+func multiLogsClient(logs map[string][]string) *fakeClient {
+	return &fakeClient{
+		inspectFunc: func(string) (types.ContainerJSON, error) {
+			return types.ContainerJSON{Config: &container.Config{}}, nil
+		},
+		logFunc: func(container string, options types.ContainerLogsOptions) (io.ReadCloser, error) {
+			lines, ok := logs[container]
+			if !ok {
+				return nil, errors.Errorf("Error: No such container: %s", container)
+			}
+			output := new(bytes.Buffer)
+			stdout := stdcopy.NewStdWriter(output, stdcopy.Stdout)
+			stderr := stdcopy.NewStdWriter(output, stdcopy.Stderr)
+			for _, line := range lines {
+				if strings.Contains(line, " !") {
+					stderr.Write([]byte(strings.Replace(line, " !", " ", 1) + "\n"))
+				} else {
+					stdout.Write([]byte(line + "\n"))
+				}
+			}
+			return ioutil.NopCloser(output), nil
+		},
+	}
+}
+
+var webAndDBLogs = map[string][]string{
+	"web": {
+		"2018-01-02T10:00:01.000000000Z listening on :80",
+		"2018-01-02T10:00:03.000000000Z !connection refused",
+		"2018-01-02T10:00:05.000000000Z GET /",
+	},
+	"database": {
+		"2018-01-02T10:00:00.000000000Z starting",
+		"2018-01-02T10:00:04.000000000Z ready",
+	},
+}
+
+func TestLogsMultipleContainers(t *testing.T) {
+	cli := test.NewFakeCli(multiLogsClient(webAndDBLogs))
+	cmd := NewLogsCommand(cli)
+	cmd.SetOutput(ioutil.Discard)
+	cmd.SetArgs([]string{"web", "database"})
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, `database | starting
+web      | listening on :80
+database | ready
+web      | GET /
+`, cli.OutBuffer().String())
+	assert.Equal(t, "web      | connection refused\n", cli.ErrBuffer().String())
+}
+
+func TestLogsMultipleContainersTimestamps(t *testing.T) {
+	cli := test.NewFakeCli(multiLogsClient(webAndDBLogs))
+	err := runMultiLogs(cli, &logsOptions{containers: []string{"web", "database", "missing"}, timestamps: true})
+	testutil.ErrorContains(t, err, "No such container: missing")
+
+	assert.Equal(t, `database | 2018-01-02T10:00:00.000000000Z starting
+web      | 2018-01-02T10:00:01.000000000Z listening on :80
+database | 2018-01-02T10:00:04.000000000Z ready
+web      | 2018-01-02T10:00:05.000000000Z GET /
+`, cli.OutBuffer().String())
+}
+
+func TestLogsWithFilter(t *testing.T) {
+	var listOptions types.ContainerListOptions
+	fakeClient := multiLogsClient(webAndDBLogs)
+	fakeClient.containerListFunc = func(options types.ContainerListOptions) ([]types.Container, error) {
+		listOptions = options
+		return []types.Container{{Names: []string{"/web"}}}, nil
+	}
+	cli := test.NewFakeCli(fakeClient)
+	cmd := NewLogsCommand(cli)
+	cmd.SetOutput(ioutil.Discard)
+	cmd.SetArgs([]string{"--filter", "label=com.example.app=shop"})
+	require.NoError(t, cmd.Execute())
+
+	assert.True(t, listOptions.All)
+	assert.Equal(t, []string{"com.example.app=shop"}, listOptions.Filters.Get("label"))
+	assert.Equal(t, "web | listening on :80\nweb | GET /\n", cli.OutBuffer().String())
+
+	cmd = NewLogsCommand(cli)
+	cmd.SetOutput(ioutil.Discard)
+	cmd.SetArgs([]string{"--filter", "label=com.example.app=shop", "web"})
+	testutil.ErrorContains(t, cmd.Execute(), "accepts no container when --filter is specified")
+}
+
+func TestLogsFollowWithFilter(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		since = map[string]string{}
+	)
+	fakeClient := multiLogsClient(map[string][]string{
+		"web":    {"2018-01-02T10:00:01.000000000Z listening on :80"},
+		"worker": {"2018-01-02T10:00:07.000000000Z waiting for jobs"},
+	})
+	logFunc := fakeClient.logFunc
+	fakeClient.logFunc = func(container string, options types.ContainerLogsOptions) (io.ReadCloser, error) {
+		mu.Lock()
+		since[container] = options.Since
+		mu.Unlock()
+		return logFunc(container, options)
+	}
+	fakeClient.containerListFunc = func(options types.ContainerListOptions) ([]types.Container, error) {
+		if options.All {
+			return []types.Container{{Names: []string{"/web"}}}, nil
+		}
+		return []types.Container{{Names: []string{"/web"}}, {Names: []string{"/worker"}}}, nil
+	}
+	fakeClient.eventsFunc = func(types.EventsOptions) (<-chan events.Message, <-chan error) {
+		eventC := make(chan events.Message, 1)
+		errC := make(chan error, 1)
+		eventC <- events.Message{Type: "container", Action: "start", TimeNano: time.Unix(1514887206, 5).UnixNano()}
+		go func() {
+			time.Sleep(2 * logsMergeWindow)
+			errC <- errors.New("unexpected EOF")
+		}()
+		return eventC, errC
+	}
+
+	cli := test.NewFakeCli(fakeClient)
+	opts := &logsOptions{follow: true, filter: dockeropts.NewFilterOpt()}
+	require.NoError(t, opts.filter.Set("label=com.example.app=shop"))
+	err := runMultiLogs(cli, opts)
+	testutil.ErrorContains(t, err, "unexpected EOF")
+
+	assert.Equal(t, "web    | listening on :80\nworker | waiting for jobs\n", cli.OutBuffer().String())
+	assert.Equal(t, map[string]string{"web": "", "worker": "1514887206.000000005"}, since)
+}
+
+func TestLogsFollowWithFilterRestartedContainer(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		since []string
+	)
+	oldLogs, oldLogsWriter := io.Pipe()
+	fakeClient := multiLogsClient(map[string][]string{
+		"web": {"2018-01-02T10:00:07.000000000Z listening on :80"},
+	})
+	logFunc := fakeClient.logFunc
+	fakeClient.logFunc = func(container string, options types.ContainerLogsOptions) (io.ReadCloser, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		since = append(since, options.Since)
+		if len(since) == 1 {
+			// the logs of the previous run are still streamed when the
+			// container starts again
+			return oldLogs, nil
+		}
+		return logFunc(container, options)
+	}
+	fakeClient.containerListFunc = func(options types.ContainerListOptions) ([]types.Container, error) {
+		return []types.Container{{Names: []string{"/web"}}}, nil
+	}
+	fakeClient.eventsFunc = func(types.EventsOptions) (<-chan events.Message, <-chan error) {
+		eventC := make(chan events.Message, 1)
+		errC := make(chan error, 1)
+		eventC <- events.Message{
+			Type:     "container",
+			Action:   "start",
+			Actor:    events.Actor{ID: "web-id", Attributes: map[string]string{"name": "web"}},
+			TimeNano: time.Unix(1514887206, 5).UnixNano(),
+		}
+		go func() {
+			time.Sleep(logsMergeWindow)
+			oldLogsWriter.Close()
+			time.Sleep(2 * logsMergeWindow)
+			errC <- errors.New("unexpected EOF")
+		}()
+		return eventC, errC
+	}
+
+	cli := test.NewFakeCli(fakeClient)
+	opts := &logsOptions{follow: true, filter: dockeropts.NewFilterOpt()}
+	require.NoError(t, opts.filter.Set("label=com.example.app=shop"))
+	err := runMultiLogs(cli, opts)
+	testutil.ErrorContains(t, err, "unexpected EOF")
+
+	assert.Equal(t, "web | listening on :80\n", cli.OutBuffer().String())
+	assert.Equal(t, []string{"", "1514887206.000000005"}, since)
+}
+
+func TestLogPrinterColors(t *testing.T) {
+	buf := new(bytes.Buffer)
+	printer := &logPrinter{out: buf, color: true, colors: map[string]int{}, prefixes: map[string]string{}}
+	printer.add("web")
+	require.NoError(t, printer.print(logEntry{container: "web", message: []byte("one\n")}))
+	printer.add("db", "worker")
+	require.NoError(t, printer.print(logEntry{container: "web", message: []byte("two\n")}))
+	require.NoError(t, printer.print(logEntry{container: "worker", message: []byte("three\n")}))
+
+	assert.Equal(t, "\x1b[36mweb |\x1b[0m one\n"+
+		"\x1b[36mweb    |\x1b[0m two\n"+
+		"\x1b[32mworker |\x1b[0m three\n", buf.String())
+}
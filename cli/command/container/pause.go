@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -12,6 +11,8 @@ import (
 )
 
 type pauseOptions struct {
+	bulk bulkOptions
+
 	containers []string
 }
 
@@ -19,22 +20,28 @@ type pauseOptions struct {
 func NewPauseCommand(dockerCli command.Cli) *cobra.Command {
 	var opts pauseOptions
 
-	return &cobra.Command{
-		Use:   "pause CONTAINER [CONTAINER...]",
+	cmd := &cobra.Command{
+		Use:   "pause [OPTIONS] CONTAINER [CONTAINER...]",
 		Short: "Pause all processes within one or more containers",
-		Args:  cli.RequiresMinArgs(1),
+		Args:  requiresContainers(&opts.bulk),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.containers = args
 			return runPause(dockerCli, &opts)
 		},
 	}
+	addBulkFlags(cmd.Flags(), &opts.bulk)
+	return cmd
 }
 
 func runPause(dockerCli command.Cli, opts *pauseOptions) error {
 	ctx := context.Background()
 
+	if opts.bulk.selected() {
+		return runBulkOperation(ctx, dockerCli, &opts.bulk, "pause", "paused", dockerCli.Client().ContainerPause)
+	}
+
 	var errs []string
-	errChan := parallelOperation(ctx, opts.containers, dockerCli.Client().ContainerPause)
+	errChan := parallelOperation(ctx, opts.containers, opts.bulk.parallel, dockerCli.Client().ContainerPause)
 	for _, container := range opts.containers {
 		if err := <-errChan; err != nil {
 			errs = append(errs, err.Error())
@@ -0,0 +1,169 @@
+package container
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/docker/cli/cli/command"
+	"github.com/pkg/errors"
+)
+
+// Terminal size stored in recordings when the output is not a terminal.
+const (
+	defaultRecordWidth  = 80
+	defaultRecordHeight = 24
+)
+
+// Codes of the events of an asciicast v2 recording.
+const (
+	recordOutputEvent = "o"
+	recordInputEvent  = "i"
+	recordResizeEvent = "r"
+)
+
+// recordingHeader is the first line of an asciicast v2 recording, see
+// https://github.com/asciinema/asciinema/blob/develop/doc/asciicast-v2.md
+type recordingHeader struct {
+	Version       int     `json:"version"`
+	Width         uint    `json:"width"`
+	Height        uint    `json:"height"`
+	Timestamp     int64   `json:"timestamp,omitempty"`
+	IdleTimeLimit float64 `json:"idle_time_limit,omitempty"`
+	Title         string  `json:"title,omitempty"`
+}
+
+// A sessionRecorder writes the streams of an interactive session to an
+// asciicast v2 recording, along with the resizes of the terminal.
+type sessionRecorder struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+	start  time.Time
+	now    func() time.Time
+
+	height, width uint
+}
+
+// createSessionRecorder creates the recording file at path, sized after the
+// terminal of the output stream.
+func createSessionRecorder(path string, streams command.Streams, title string) (*sessionRecorder, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create session recording")
+	}
+	var height, width uint
+	if streams.Out().IsTerminal() {
+		height, width = streams.Out().GetTtySize()
+	}
+	r, err := newSessionRecorder(f, height, width, title, time.Now)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	r.closer = f
+	return r, nil
+}
+
+func newSessionRecorder(w io.Writer, height, width uint, title string, now func() time.Time) (*sessionRecorder, error) {
+	if height == 0 || width == 0 {
+		height, width = defaultRecordHeight, defaultRecordWidth
+	}
+	r := &sessionRecorder{w: w, start: now(), now: now, height: height, width: width}
+	header, err := json.Marshal(recordingHeader{
+		Version:   2,
+		Width:     width,
+		Height:    height,
+		Timestamp: r.start.Unix(),
+		Title:     title,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := fmt.Fprintf(w, "%s\n", header); err != nil {
+		return nil, errors.Wrap(err, "unable to write session recording")
+	}
+	return r, nil
+}
+
+// record appends an event to the recording. Errors are not returned, so
+// that a failing recording never interrupts the session itself.
+func (r *sessionRecorder) record(code, data string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	elapsed := r.now().Sub(r.start).Seconds()
+	event, err := json.Marshal([]interface{}{elapsed, code, data})
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(r.w, "%s\n", event)
+}
+
+// resize records a resize event if the size of the terminal changed.
+func (r *sessionRecorder) resize(height, width uint) {
+	if height == 0 || width == 0 {
+		return
+	}
+	r.mu.Lock()
+	changed := height != r.height || width != r.width
+	r.height, r.width = height, width
+	r.mu.Unlock()
+	if changed {
+		r.record(recordResizeEvent, fmt.Sprintf("%dx%d", width, height))
+	}
+}
+
+// output returns a writer recording the data written to it as output events.
+func (r *sessionRecorder) output() io.Writer {
+	return &recordWriter{recorder: r, code: recordOutputEvent}
+}
+
+// input returns a writer recording the data written to it as input events.
+func (r *sessionRecorder) input() io.Writer {
+	return &recordWriter{recorder: r, code: recordInputEvent}
+}
+
+// Close closes the recording file.
+func (r *sessionRecorder) Close() error {
+	if r.closer == nil {
+		return nil
+	}
+	return r.closer.Close()
+}
+
+// A recordWriter records the data written to it as events. Recordings store
+// text, so a multi-byte character split across writes is held back until it
+// is complete.
+type recordWriter struct {
+	recorder *sessionRecorder
+	code     string
+	pending  []byte
+}
+
+func (w *recordWriter) Write(p []byte) (int, error) {
+	data := append(w.pending, p...)
+	n := len(data) - incompleteRuneSuffix(data)
+	w.pending = append([]byte(nil), data[n:]...)
+	if n > 0 {
+		w.recorder.record(w.code, string(data[:n]))
+	}
+	return len(p), nil
+}
+
+// incompleteRuneSuffix returns the length of the truncated UTF-8 encoded
+// character at the end of data, if any.
+func incompleteRuneSuffix(data []byte) int {
+	for i := 1; i < utf8.UTFMax && i <= len(data); i++ {
+		if utf8.RuneStart(data[len(data)-i]) {
+			if utf8.FullRune(data[len(data)-i:]) {
+				return 0
+			}
+			return i
+		}
+	}
+	return 0
+}
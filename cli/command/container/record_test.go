@@ -0,0 +1,90 @@
+package container
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+// fakeClock returns a clock starting at start, moving forward by step on
+// each reading.
+func fakeClock(start time.Time, step time.Duration) func() time.Time {
+	now := start.Add(-step)
+	return func() time.Time {
+		now = now.Add(step)
+		return now
+	}
+}
+
+func TestSessionRecorder(t *testing.T) {
+	buf := new(bytes.Buffer)
+	start := time.Unix(1500000000, 0)
+	recorder, err := newSessionRecorder(buf, 0, 0, "web", fakeClock(start, 500*time.Millisecond))
+	require.NoError(t, err)
+
+	recorder.output().Write([]byte("$ "))
+	recorder.input().Write([]byte("ls\r"))
+	recorder.resize(24, 80)
+	recorder.resize(40, 120)
+
+	// "é" split across two writes
+	output := recorder.output()
+	output.Write([]byte("caf\xc3"))
+	output.Write([]byte("\xa9\r\n"))
+
+	expected := `{"version":2,"width":80,"height":24,"timestamp":1500000000,"title":"web"}
+[0.5,"o","$ "]
+[1,"i","ls\r"]
+[1.5,"r","120x40"]
+[2,"o","caf"]
+[2.5,"o","é\r\n"]
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestHijackedIOStreamerRecording(t *testing.T) {
+	conn, server := net.Pipe()
+	defer conn.Close()
+
+	buf := new(bytes.Buffer)
+	recorder, err := newSessionRecorder(buf, 24, 80, "", fakeClock(time.Unix(0, 0), time.Second))
+	require.NoError(t, err)
+
+	go func() {
+		defer server.Close()
+		input := make([]byte, len("ping\n"))
+		if _, err := server.Read(input); err != nil {
+			return
+		}
+		stdcopy.NewStdWriter(server, stdcopy.Stdout).Write([]byte("pong\n"))
+		stdcopy.NewStdWriter(server, stdcopy.Stderr).Write([]byte("oops\n"))
+	}()
+
+	cli := test.NewFakeCli(&fakeClient{})
+	streamer := hijackedIOStreamer{
+		streams:      cli,
+		inputStream:  ioutil.NopCloser(strings.NewReader("ping\n")),
+		outputStream: cli.OutBuffer(),
+		errorStream:  cli.ErrBuffer(),
+		resp:         types.HijackedResponse{Conn: conn, Reader: bufio.NewReader(conn)},
+		recorder:     recorder,
+	}
+	require.NoError(t, streamer.stream(context.Background()))
+
+	assert.Equal(t, "pong\n", cli.OutBuffer().String())
+	assert.Equal(t, "oops\n", cli.ErrBuffer().String())
+	assert.Contains(t, buf.String(), `,"i","ping\n"]`)
+	assert.Contains(t, buf.String(), `,"o","pong\n"]`)
+	assert.Contains(t, buf.String(), `,"o","oops\n"]`)
+}
@@ -0,0 +1,142 @@
+package container
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type replayOptions struct {
+	file      string
+	speed     float64
+	idleLimit time.Duration
+}
+
+// NewReplayCommand creates a new cobra.Command for `docker container replay`
+func NewReplayCommand(dockerCli command.Cli) *cobra.Command {
+	var opts replayOptions
+
+	cmd := &cobra.Command{
+		Use:   "replay [OPTIONS] FILE",
+		Short: "Replay a recorded session",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.file = args[0]
+			return runReplay(dockerCli, &opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.Float64Var(&opts.speed, "speed", 1, "Playback speed factor")
+	flags.DurationVar(&opts.idleLimit, "idle-limit", 0, "Shorten pauses longer than this duration (0 to keep the recorded pauses)")
+	return cmd
+}
+
+func runReplay(dockerCli command.Cli, opts *replayOptions) error {
+	if opts.speed <= 0 {
+		return errors.Errorf("invalid speed %v: must be greater than 0", opts.speed)
+	}
+
+	f, err := os.Open(opts.file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return replaySession(dockerCli, f, opts, time.Sleep)
+}
+
+// replaySession writes the output of the recording read from r to the
+// output stream, pausing between events with sleep.
+func replaySession(dockerCli command.Cli, r io.Reader, opts *replayOptions, sleep func(time.Duration)) error {
+	reader := bufio.NewReader(r)
+
+	line, err := reader.ReadBytes('\n')
+	if err != nil && (err != io.EOF || len(line) == 0) {
+		return errors.New("invalid recording: missing header")
+	}
+	var header recordingHeader
+	if err := json.Unmarshal(line, &header); err != nil {
+		return errors.Wrap(err, "invalid recording header")
+	}
+	if header.Version != 2 {
+		return errors.Errorf("unsupported recording version %d", header.Version)
+	}
+
+	if dockerCli.Out().IsTerminal() {
+		height, width := dockerCli.Out().GetTtySize()
+		if height < header.Height || width < header.Width {
+			fmt.Fprintf(dockerCli.Err(), "WARNING: the session was recorded in a %dx%d terminal, larger than the current %dx%d one\n", header.Width, header.Height, width, height)
+		}
+	}
+
+	idleLimit := opts.idleLimit
+	if idleLimit == 0 && header.IdleTimeLimit > 0 {
+		idleLimit = time.Duration(header.IdleTimeLimit * float64(time.Second))
+	}
+
+	var last float64
+	for lineNumber := 2; ; lineNumber++ {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			elapsed, code, data, parseErr := parseRecordingEvent(line)
+			if parseErr != nil {
+				return errors.Wrapf(parseErr, "invalid event on line %d of the recording", lineNumber)
+			}
+			delay := time.Duration((elapsed - last) * float64(time.Second))
+			if idleLimit > 0 && delay > idleLimit {
+				delay = idleLimit
+			}
+			if delay > 0 {
+				sleep(time.Duration(float64(delay) / opts.speed))
+			}
+			last = elapsed
+
+			if code == recordOutputEvent {
+				if _, err := io.WriteString(dockerCli.Out(), data); err != nil {
+					return err
+				}
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// parseRecordingEvent parses an event of a recording, which is an array of
+// the time elapsed since the start of the recording in seconds, the code of
+// the event, and its data.
+func parseRecordingEvent(line []byte) (float64, string, string, error) {
+	var event []interface{}
+	if err := json.Unmarshal(line, &event); err != nil {
+		return 0, "", "", err
+	}
+	if len(event) != 3 {
+		return 0, "", "", errors.Errorf("expected 3 elements, got %d", len(event))
+	}
+	elapsed, ok := event[0].(float64)
+	if !ok {
+		return 0, "", "", errors.New("time must be a number")
+	}
+	code, ok := event[1].(string)
+	if !ok {
+		return 0, "", "", errors.New("event code must be a string")
+	}
+	data, ok := event[2].(string)
+	if !ok {
+		return 0, "", "", errors.New("event data must be a string")
+	}
+	return elapsed, code, data, nil
+}
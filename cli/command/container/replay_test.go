@@ -0,0 +1,74 @@
+package container
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/cli/internal/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const replayRecording = `{"version":2,"width":80,"height":24,"timestamp":1500000000}
+[0.5,"o","$ "]
+[1.0,"i","ls\r"]
+[1.5,"o","ls\r\n"]
+[1.5,"r","120x40"]
+[11.5,"o","bin  etc\r\n"]
+`
+
+func TestReplaySession(t *testing.T) {
+	testcases := []struct {
+		doc            string
+		options        replayOptions
+		expectedPauses []time.Duration
+	}{
+		{
+			doc:            "recorded pauses",
+			options:        replayOptions{speed: 1},
+			expectedPauses: []time.Duration{500 * time.Millisecond, 500 * time.Millisecond, 500 * time.Millisecond, 10 * time.Second},
+		},
+		{
+			doc:            "faster with idle limit",
+			options:        replayOptions{speed: 2, idleLimit: 2 * time.Second},
+			expectedPauses: []time.Duration{250 * time.Millisecond, 250 * time.Millisecond, 250 * time.Millisecond, time.Second},
+		},
+	}
+	for _, tc := range testcases {
+		var pauses []time.Duration
+		cli := test.NewFakeCli(&fakeClient{})
+		err := replaySession(cli, strings.NewReader(replayRecording), &tc.options, func(d time.Duration) {
+			pauses = append(pauses, d)
+		})
+		require.NoError(t, err, tc.doc)
+		assert.Equal(t, "$ ls\r\nbin  etc\r\n", cli.OutBuffer().String(), tc.doc)
+		assert.Equal(t, tc.expectedPauses, pauses, tc.doc)
+	}
+}
+
+func TestReplaySessionInvalidRecording(t *testing.T) {
+	testcases := []struct {
+		recording     string
+		expectedError string
+	}{
+		{
+			recording:     "",
+			expectedError: "invalid recording: missing header",
+		},
+		{
+			recording:     `{"version":1,"width":80,"height":24,"stdout":[]}`,
+			expectedError: "unsupported recording version 1",
+		},
+		{
+			recording:     "{\"version\":2,\"width\":80,\"height\":24}\n[0.5,\"o\"]\n",
+			expectedError: "invalid event on line 2 of the recording: expected 3 elements, got 2",
+		},
+	}
+	for _, tc := range testcases {
+		cli := test.NewFakeCli(&fakeClient{})
+		err := replaySession(cli, strings.NewReader(tc.recording), &replayOptions{speed: 1}, func(time.Duration) {})
+		testutil.ErrorContains(t, err, tc.expectedError)
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -15,6 +14,7 @@ import (
 type restartOptions struct {
 	nSeconds        int
 	nSecondsChanged bool
+	bulk            bulkOptions
 
 	containers []string
 }
@@ -26,7 +26,7 @@ func NewRestartCommand(dockerCli command.Cli) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "restart [OPTIONS] CONTAINER [CONTAINER...]",
 		Short: "Restart one or more containers",
-		Args:  cli.RequiresMinArgs(1),
+		Args:  requiresContainers(&opts.bulk),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.containers = args
 			opts.nSecondsChanged = cmd.Flags().Changed("time")
@@ -36,6 +36,7 @@ func NewRestartCommand(dockerCli command.Cli) *cobra.Command {
 
 	flags := cmd.Flags()
 	flags.IntVarP(&opts.nSeconds, "time", "t", 10, "Seconds to wait for stop before killing the container")
+	addBulkFlags(flags, &opts.bulk)
 	return cmd
 }
 
@@ -48,8 +49,16 @@ func runRestart(dockerCli command.Cli, opts *restartOptions) error {
 		timeout = &timeoutValue
 	}
 
+	restart := func(ctx context.Context, container string) error {
+		return dockerCli.Client().ContainerRestart(ctx, container, timeout)
+	}
+	if opts.bulk.selected() {
+		return runBulkOperation(ctx, dockerCli, &opts.bulk, "restart", "restarted", restart)
+	}
+
+	errChan := parallelOperation(ctx, opts.containers, opts.bulk.parallel, restart)
 	for _, name := range opts.containers {
-		if err := dockerCli.Client().ContainerRestart(ctx, name, timeout); err != nil {
+		if err := <-errChan; err != nil {
 			errs = append(errs, err.Error())
 			continue
 		}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/docker/api/types"
 	"github.com/pkg/errors"
@@ -16,18 +15,19 @@ type rmOptions struct {
 	rmVolumes bool
 	rmLink    bool
 	force     bool
+	bulk      bulkOptions
 
 	containers []string
 }
 
 // NewRmCommand creates a new cobra.Command for `docker rm`
 func NewRmCommand(dockerCli command.Cli) *cobra.Command {
-	var opts rmOptions
+	opts := rmOptions{bulk: bulkOptions{includeStopped: true}}
 
 	cmd := &cobra.Command{
 		Use:   "rm [OPTIONS] CONTAINER [CONTAINER...]",
 		Short: "Remove one or more containers",
-		Args:  cli.RequiresMinArgs(1),
+		Args:  requiresContainers(&opts.bulk),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.containers = args
 			return runRm(dockerCli, &opts)
@@ -38,6 +38,7 @@ func NewRmCommand(dockerCli command.Cli) *cobra.Command {
 	flags.BoolVarP(&opts.rmVolumes, "volumes", "v", false, "Remove the volumes associated with the container")
 	flags.BoolVarP(&opts.rmLink, "link", "l", false, "Remove the specified link")
 	flags.BoolVarP(&opts.force, "force", "f", false, "Force the removal of a running container (uses SIGKILL)")
+	addBulkFlags(flags, &opts.bulk)
 	return cmd
 }
 
@@ -51,13 +52,18 @@ func runRm(dockerCli command.Cli, opts *rmOptions) error {
 		Force:         opts.force,
 	}
 
-	errChan := parallelOperation(ctx, opts.containers, func(ctx context.Context, container string) error {
+	remove := func(ctx context.Context, container string) error {
 		container = strings.Trim(container, "/")
 		if container == "" {
 			return errors.New("Container name cannot be empty")
 		}
 		return dockerCli.Client().ContainerRemove(ctx, container, options)
-	})
+	}
+	if opts.bulk.selected() {
+		return runBulkOperation(ctx, dockerCli, &opts.bulk, "remove", "removed", remove)
+	}
+
+	errChan := parallelOperation(ctx, opts.containers, opts.bulk.parallel, remove)
 
 	for _, name := range opts.containers {
 		if err := <-errChan; err != nil {
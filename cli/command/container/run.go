@@ -30,6 +30,7 @@ type runOptions struct {
 	name       string
 	detachKeys string
 	platform   string
+	record     string
 }
 
 // NewRunCommand create a new `docker run` command
@@ -58,6 +59,7 @@ func NewRunCommand(dockerCli command.Cli) *cobra.Command {
 	flags.BoolVar(&opts.sigProxy, "sig-proxy", true, "Proxy received signals to the process")
 	flags.StringVar(&opts.name, "name", "", "Assign a name to the container")
 	flags.StringVar(&opts.detachKeys, "detach-keys", "", "Override the key sequence for detaching a container")
+	flags.StringVar(&opts.record, "record", "", "Record the session to an asciicast file")
 
 	// Add an explicit help that doesn't have a `-h` to prevent the conflict
 	// with hostname
@@ -141,6 +143,9 @@ func runContainer(dockerCli command.Cli, opts *runOptions, copts *containerOptio
 		if copts.attach.Len() != 0 {
 			return errors.New("Conflicting options: -a and -d")
 		}
+		if opts.record != "" {
+			return errors.New("Conflicting options: --record and -d")
+		}
 
 		config.AttachStdin = false
 		config.AttachStdout = false
@@ -160,6 +165,19 @@ func runContainer(dockerCli command.Cli, opts *runOptions, copts *containerOptio
 		hostConfig.ConsoleSize[0], hostConfig.ConsoleSize[1] = dockerCli.Out().GetTtySize()
 	}
 
+	var recorder *sessionRecorder
+	if opts.record != "" {
+		title := opts.name
+		if title == "" {
+			title = config.Image
+		}
+		var err error
+		if recorder, err = createSessionRecorder(opts.record, dockerCli, title); err != nil {
+			return err
+		}
+		defer recorder.Close()
+	}
+
 	ctx, cancelFun := context.WithCancel(context.Background())
 
 	createResponse, err := createContainer(ctx, dockerCli, containerConfig, opts.name, opts.platform)
@@ -190,7 +208,7 @@ func runContainer(dockerCli command.Cli, opts *runOptions, copts *containerOptio
 			dockerCli.ConfigFile().DetachKeys = opts.detachKeys
 		}
 
-		close, err := attachContainer(ctx, dockerCli, &errCh, config, createResponse.ID)
+		close, err := attachContainer(ctx, dockerCli, &errCh, config, createResponse.ID, recorder)
 
 		if err != nil {
 			return err
@@ -219,7 +237,7 @@ func runContainer(dockerCli command.Cli, opts *runOptions, copts *containerOptio
 	}
 
 	if (config.AttachStdin || config.AttachStdout || config.AttachStderr) && config.Tty && dockerCli.Out().IsTerminal() {
-		if err := MonitorTtySize(ctx, dockerCli, createResponse.ID, false); err != nil {
+		if err := monitorTtySize(ctx, dockerCli, createResponse.ID, false, recorder); err != nil {
 			fmt.Fprintln(stderr, "Error monitoring TTY size:", err)
 		}
 	}
@@ -256,6 +274,7 @@ func attachContainer(
 	errCh *chan error,
 	config *container.Config,
 	containerID string,
+	recorder *sessionRecorder,
 ) (func(), error) {
 	stdout, stderr := dockerCli.Out(), dockerCli.Err()
 	var (
@@ -305,6 +324,7 @@ func attachContainer(
 				resp:         resp,
 				tty:          config.Tty,
 				detachKeys:   options.DetachKeys,
+				recorder:     recorder,
 			}
 
 			if errHijack := streamer.stream(ctx); errHijack != nil {
@@ -0,0 +1,668 @@
+package container
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/blkiodev"
+	"github.com/docker/docker/api/types/container"
+	mounttypes "github.com/docker/docker/api/types/mount"
+	"github.com/docker/go-connections/nat"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	// runCommandFormat is the `docker container inspect` format printing
+	// the `docker run` command line to recreate a container
+	runCommandFormat = "runcmd"
+	// runSpecFormat is the `docker container inspect` format printing the
+	// run spec of a container, as accepted by `docker create --from-spec`
+	runSpecFormat = "runspec"
+)
+
+// defaultShmSize is the size of /dev/shm set by the daemon when --shm-size
+// is not specified
+const defaultShmSize = 64 * 1024 * 1024
+
+// runSpec describes how to create a container, with the options of
+// `docker run` that differ from their default value.
+type runSpec struct {
+	Name    string                   `yaml:"name,omitempty"`
+	Image   string                   `yaml:"image"`
+	Command []string                 `yaml:"command,omitempty"`
+	Options map[string]runSpecValues `yaml:"options,omitempty"`
+}
+
+func (s *runSpec) add(option string, values ...string) {
+	if len(values) == 0 {
+		return
+	}
+	if s.Options == nil {
+		s.Options = map[string]runSpecValues{}
+	}
+	s.Options[option] = append(s.Options[option], values...)
+}
+
+func (s *runSpec) addString(option, value string) {
+	if value != "" {
+		s.add(option, value)
+	}
+}
+
+func (s *runSpec) addBool(option string, value bool) {
+	if value {
+		s.add(option, "true")
+	}
+}
+
+func (s *runSpec) addInt(option string, value int64) {
+	if value != 0 {
+		s.add(option, strconv.FormatInt(value, 10))
+	}
+}
+
+func (s *runSpec) addMemBytes(option string, value int64) {
+	if value != 0 {
+		s.add(option, formatMemBytes(value))
+	}
+}
+
+// args returns the command line arguments of `docker run` for the spec
+func (s *runSpec) args() []string {
+	var args []string
+	if s.Name != "" {
+		args = append(args, "--name", s.Name)
+	}
+	options := make([]string, 0, len(s.Options))
+	for option := range s.Options {
+		options = append(options, option)
+	}
+	sort.Strings(options)
+	for _, option := range options {
+		for _, value := range s.Options[option] {
+			if value == "true" && isBoolFlag(option) {
+				args = append(args, "--"+option)
+				continue
+			}
+			args = append(args, "--"+option+"="+value)
+		}
+	}
+	args = append(args, s.Image)
+	return append(args, s.Command...)
+}
+
+// runSpecValues holds the values of an option. An option with a single
+// value is written as a scalar.
+type runSpecValues []string
+
+// MarshalYAML implements yaml.Marshaler
+func (v runSpecValues) MarshalYAML() (interface{}, error) {
+	if len(v) == 1 {
+		return yamlScalar(v[0]), nil
+	}
+	return []string(v), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler
+func (v *runSpecValues) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var values []string
+	if err := unmarshal(&values); err == nil {
+		*v = values
+		return nil
+	}
+	var value string
+	if err := unmarshal(&value); err != nil {
+		return err
+	}
+	*v = runSpecValues{value}
+	return nil
+}
+
+// yamlScalar returns booleans and integers as such, so that they are not
+// quoted in the YAML output.
+func yamlScalar(value string) interface{} {
+	if b, err := strconv.ParseBool(value); err == nil && strconv.FormatBool(b) == value {
+		return b
+	}
+	if i, err := strconv.ParseInt(value, 10, 64); err == nil && strconv.FormatInt(i, 10) == value {
+		return i
+	}
+	return value
+}
+
+// containerFlags returns the flags that can be set in a run spec
+func containerFlags() *pflag.FlagSet {
+	flags := pflag.NewFlagSet("spec", pflag.ContinueOnError)
+	addFlags(flags)
+	return flags
+}
+
+func isBoolFlag(option string) bool {
+	flag := containerFlags().Lookup(option)
+	return flag != nil && flag.Value.Type() == "bool"
+}
+
+// newRunSpec returns the run spec of a container. Values which are set by
+// the image of the container are left out, so imageConfig may be nil if the
+// image is not available.
+func newRunSpec(c types.ContainerJSON, imageConfig *container.Config) *runSpec {
+	if imageConfig == nil {
+		imageConfig = &container.Config{}
+	}
+	spec := &runSpec{
+		Name:  strings.TrimPrefix(c.Name, "/"),
+		Image: c.Config.Image,
+	}
+	addConfigOptions(spec, c, imageConfig)
+	addHostConfigOptions(spec, c.HostConfig)
+	addResourcesOptions(spec, c.HostConfig.Resources)
+	addNetworkingOptions(spec, c)
+	return spec
+}
+
+// nolint: gocyclo
+func addConfigOptions(spec *runSpec, c types.ContainerJSON, imageConfig *container.Config) {
+	config := c.Config
+
+	// The daemon uses the short ID of the container as hostname by default,
+	// or the hostname of the host or container whose network is shared,
+	// which can't be combined with --hostname.
+	networkMode := c.HostConfig.NetworkMode
+	if !strings.HasPrefix(c.ID, config.Hostname) && !networkMode.IsHost() && !networkMode.IsContainer() {
+		spec.addString("hostname", config.Hostname)
+	}
+	if config.User != imageConfig.User {
+		spec.addString("user", config.User)
+	}
+	spec.addBool("tty", config.Tty)
+	spec.addBool("interactive", config.OpenStdin)
+	spec.add("env", subtractStrings(config.Env, imageConfig.Env)...)
+
+	entrypointChanged := !equalStrings(config.Entrypoint, imageConfig.Entrypoint)
+	if entrypointChanged {
+		// --entrypoint only sets the executable, its arguments are passed
+		// as the command.
+		if len(config.Entrypoint) == 0 {
+			spec.add("entrypoint", "")
+		} else {
+			spec.add("entrypoint", config.Entrypoint[0])
+			spec.Command = append(spec.Command, config.Entrypoint[1:]...)
+		}
+	}
+	if entrypointChanged || !equalStrings(config.Cmd, imageConfig.Cmd) {
+		spec.Command = append(spec.Command, config.Cmd...)
+	}
+
+	if config.WorkingDir != imageConfig.WorkingDir {
+		spec.addString("workdir", config.WorkingDir)
+	}
+	spec.addString("mac-address", config.MacAddress)
+
+	var labels []string
+	for key, value := range config.Labels {
+		if imageValue, ok := imageConfig.Labels[key]; !ok || imageValue != value {
+			labels = append(labels, key+"="+value)
+		}
+	}
+	sort.Strings(labels)
+	spec.add("label", labels...)
+
+	if config.Healthcheck != nil && !reflect.DeepEqual(config.Healthcheck, imageConfig.Healthcheck) {
+		addHealthcheckOptions(spec, config.Healthcheck)
+	}
+	if config.StopSignal != imageConfig.StopSignal {
+		spec.addString("stop-signal", config.StopSignal)
+	}
+	if config.StopTimeout != nil {
+		spec.add("stop-timeout", strconv.Itoa(*config.StopTimeout))
+	}
+
+	// Bind mounts are set on the host config, so anonymous volumes are
+	// added after them.
+	var volumes []string
+	for volume := range config.Volumes {
+		if _, ok := imageConfig.Volumes[volume]; !ok {
+			volumes = append(volumes, volume)
+		}
+	}
+	sort.Strings(volumes)
+	spec.add("volume", c.HostConfig.Binds...)
+	spec.add("volume", volumes...)
+
+	// Published ports are exposed by --publish
+	var exposed []string
+	for port := range config.ExposedPorts {
+		if _, ok := imageConfig.ExposedPorts[port]; ok {
+			continue
+		}
+		if _, ok := c.HostConfig.PortBindings[port]; ok {
+			continue
+		}
+		exposed = append(exposed, formatPort(port))
+	}
+	sort.Strings(exposed)
+	spec.add("expose", exposed...)
+}
+
+func addHealthcheckOptions(spec *runSpec, healthcheck *container.HealthConfig) {
+	if len(healthcheck.Test) > 0 {
+		switch healthcheck.Test[0] {
+		case "NONE":
+			spec.addBool("no-healthcheck", true)
+			return
+		case "CMD-SHELL":
+			spec.add("health-cmd", strings.Join(healthcheck.Test[1:], " "))
+		case "CMD":
+			spec.add("health-cmd", shellJoin(healthcheck.Test[1:]))
+		}
+	}
+	if healthcheck.Interval != 0 {
+		spec.add("health-interval", healthcheck.Interval.String())
+	}
+	if healthcheck.Timeout != 0 {
+		spec.add("health-timeout", healthcheck.Timeout.String())
+	}
+	if healthcheck.StartPeriod != 0 {
+		spec.add("health-start-period", healthcheck.StartPeriod.String())
+	}
+	spec.addInt("health-retries", int64(healthcheck.Retries))
+}
+
+// nolint: gocyclo
+func addHostConfigOptions(spec *runSpec, hostConfig *container.HostConfig) {
+	spec.addString("cidfile", hostConfig.ContainerIDFile)
+	spec.addInt("oom-score-adj", int64(hostConfig.OomScoreAdj))
+	spec.addBool("rm", hostConfig.AutoRemove)
+	spec.addBool("privileged", hostConfig.Privileged)
+	spec.addBool("publish-all", hostConfig.PublishAllPorts)
+
+	var ports []string
+	for port := range hostConfig.PortBindings {
+		ports = append(ports, string(port))
+	}
+	sort.Strings(ports)
+	for _, port := range ports {
+		for _, binding := range hostConfig.PortBindings[nat.Port(port)] {
+			spec.add("publish", formatPortBinding(nat.Port(port), binding))
+		}
+	}
+
+	for _, link := range hostConfig.Links {
+		spec.add("link", formatLink(link))
+	}
+	spec.add("dns", hostConfig.DNS...)
+	spec.add("dns-search", hostConfig.DNSSearch...)
+	spec.add("dns-option", hostConfig.DNSOptions...)
+	spec.add("add-host", hostConfig.ExtraHosts...)
+	spec.add("volumes-from", hostConfig.VolumesFrom...)
+
+	switch mode := string(hostConfig.NetworkMode); mode {
+	case "", "default", "bridge":
+	default:
+		spec.add("network", mode)
+	}
+	// The daemon sets the default IPC mode on the container
+	if mode := string(hostConfig.IpcMode); mode != "shareable" {
+		spec.addString("ipc", mode)
+	}
+	spec.addString("pid", string(hostConfig.PidMode))
+	spec.addString("uts", string(hostConfig.UTSMode))
+	spec.addString("userns", string(hostConfig.UsernsMode))
+	spec.add("cap-add", hostConfig.CapAdd...)
+	spec.add("cap-drop", hostConfig.CapDrop...)
+	spec.add("group-add", hostConfig.GroupAdd...)
+
+	if policy := hostConfig.RestartPolicy; !policy.IsNone() {
+		if policy.IsOnFailure() && policy.MaximumRetryCount > 0 {
+			spec.add("restart", fmt.Sprintf("%s:%d", policy.Name, policy.MaximumRetryCount))
+		} else {
+			spec.add("restart", policy.Name)
+		}
+	}
+
+	spec.add("security-opt", hostConfig.SecurityOpt...)
+	spec.add("storage-opt", formatKeyValues(hostConfig.StorageOpt)...)
+	spec.addBool("read-only", hostConfig.ReadonlyRootfs)
+	// The daemon sets the default logging driver on the container
+	if driver := hostConfig.LogConfig.Type; driver != "json-file" {
+		spec.addString("log-driver", driver)
+	}
+	spec.add("log-opt", formatKeyValues(hostConfig.LogConfig.Config)...)
+	spec.addString("volume-driver", hostConfig.VolumeDriver)
+	if !hostConfig.Isolation.IsDefault() {
+		spec.add("isolation", string(hostConfig.Isolation))
+	}
+	if hostConfig.ShmSize != defaultShmSize {
+		spec.addMemBytes("shm-size", hostConfig.ShmSize)
+	}
+
+	var tmpfs []string
+	for target, options := range hostConfig.Tmpfs {
+		if options != "" {
+			target += ":" + options
+		}
+		tmpfs = append(tmpfs, target)
+	}
+	sort.Strings(tmpfs)
+	spec.add("tmpfs", tmpfs...)
+
+	spec.add("sysctl", formatKeyValues(hostConfig.Sysctls)...)
+	// The daemon sets the default runtime on the container
+	if hostConfig.Runtime != "runc" {
+		spec.addString("runtime", hostConfig.Runtime)
+	}
+	for _, mount := range hostConfig.Mounts {
+		spec.add("mount", formatMount(mount))
+	}
+	if hostConfig.Init != nil {
+		spec.add("init", strconv.FormatBool(*hostConfig.Init))
+	}
+}
+
+// nolint: gocyclo
+func addResourcesOptions(spec *runSpec, resources container.Resources) {
+	spec.addString("cgroup-parent", resources.CgroupParent)
+	spec.addMemBytes("memory", resources.Memory)
+	spec.addMemBytes("memory-reservation", resources.MemoryReservation)
+	// The daemon sets the swap limit to twice the memory limit by default
+	if resources.MemorySwap != 2*resources.Memory {
+		spec.addMemBytes("memory-swap", resources.MemorySwap)
+	}
+	spec.addMemBytes("kernel-memory", resources.KernelMemory)
+	if swappiness := resources.MemorySwappiness; swappiness != nil && *swappiness != -1 {
+		spec.add("memory-swappiness", strconv.FormatInt(*swappiness, 10))
+	}
+	if resources.OomKillDisable != nil {
+		spec.addBool("oom-kill-disable", *resources.OomKillDisable)
+	}
+	if resources.NanoCPUs != 0 {
+		spec.add("cpus", strconv.FormatFloat(float64(resources.NanoCPUs)/1e9, 'f', -1, 64))
+	}
+	spec.addInt("cpu-count", resources.CPUCount)
+	spec.addInt("cpu-percent", resources.CPUPercent)
+	spec.addInt("cpu-shares", resources.CPUShares)
+	spec.addInt("cpu-period", resources.CPUPeriod)
+	spec.addInt("cpu-quota", resources.CPUQuota)
+	spec.addInt("cpu-rt-period", resources.CPURealtimePeriod)
+	spec.addInt("cpu-rt-runtime", resources.CPURealtimeRuntime)
+	spec.addString("cpuset-cpus", resources.CpusetCpus)
+	spec.addString("cpuset-mems", resources.CpusetMems)
+	spec.addInt("pids-limit", resources.PidsLimit)
+	spec.addInt("blkio-weight", int64(resources.BlkioWeight))
+	for _, device := range resources.BlkioWeightDevice {
+		spec.add("blkio-weight-device", fmt.Sprintf("%s:%d", device.Path, device.Weight))
+	}
+	for option, devices := range map[string][]*blkiodev.ThrottleDevice{
+		"device-read-bps":   resources.BlkioDeviceReadBps,
+		"device-write-bps":  resources.BlkioDeviceWriteBps,
+		"device-read-iops":  resources.BlkioDeviceReadIOps,
+		"device-write-iops": resources.BlkioDeviceWriteIOps,
+	} {
+		for _, device := range devices {
+			spec.add(option, fmt.Sprintf("%s:%d", device.Path, device.Rate))
+		}
+	}
+	spec.addInt("io-maxiops", int64(resources.IOMaximumIOps))
+	spec.addMemBytes("io-maxbandwidth", int64(resources.IOMaximumBandwidth))
+	for _, ulimit := range resources.Ulimits {
+		spec.add("ulimit", fmt.Sprintf("%s=%d:%d", ulimit.Name, ulimit.Soft, ulimit.Hard))
+	}
+	spec.add("device-cgroup-rule", resources.DeviceCgroupRules...)
+	for _, device := range resources.Devices {
+		spec.add("device", formatDevice(device))
+	}
+}
+
+func addNetworkingOptions(spec *runSpec, c types.ContainerJSON) {
+	if c.NetworkSettings == nil {
+		return
+	}
+	network := string(c.HostConfig.NetworkMode)
+	if network == "default" {
+		network = "bridge"
+	}
+	endpoint, ok := c.NetworkSettings.Networks[network]
+	if !ok || endpoint == nil {
+		return
+	}
+	if endpoint.IPAMConfig != nil {
+		spec.addString("ip", endpoint.IPAMConfig.IPv4Address)
+		spec.addString("ip6", endpoint.IPAMConfig.IPv6Address)
+		spec.add("link-local-ip", endpoint.IPAMConfig.LinkLocalIPs...)
+	}
+	for _, alias := range endpoint.Aliases {
+		// The daemon adds the short ID of the container as alias
+		if !strings.HasPrefix(c.ID, alias) {
+			spec.add("network-alias", alias)
+		}
+	}
+}
+
+// applyRunSpec sets the options of the spec on the flags of `docker create`.
+// Options which are set on the command line take precedence over the spec.
+func applyRunSpec(flags *pflag.FlagSet, spec *runSpec, name *string, copts *containerOptions) error {
+	specFlags := containerFlags()
+	options := make([]string, 0, len(spec.Options))
+	for option := range spec.Options {
+		options = append(options, option)
+	}
+	sort.Strings(options)
+	for _, option := range options {
+		if specFlags.Lookup(option) == nil {
+			return errors.Errorf("unknown option %q in run spec", option)
+		}
+		if flags.Changed(option) {
+			continue
+		}
+		for _, value := range spec.Options[option] {
+			if err := flags.Set(option, value); err != nil {
+				return errors.Errorf("invalid value %q for option %q in run spec: %v", value, option, err)
+			}
+		}
+	}
+	if !flags.Changed("name") {
+		*name = spec.Name
+	}
+	if copts.Image == "" {
+		copts.Image = spec.Image
+		copts.Args = spec.Command
+	}
+	return nil
+}
+
+func loadRunSpec(filename string) (*runSpec, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	spec := &runSpec{}
+	if err := yaml.Unmarshal(content, spec); err != nil {
+		return nil, errors.Wrapf(err, "invalid run spec %s", filename)
+	}
+	return spec, nil
+}
+
+func marshalRunSpec(spec *runSpec) ([]byte, error) {
+	return yaml.Marshal(spec)
+}
+
+// runCommandLine returns the `docker run` command line for a spec. detach
+// adds the --detach flag, which is not part of the spec.
+func runCommandLine(spec *runSpec, detach bool) string {
+	args := []string{"docker", "run"}
+	if detach {
+		args = append(args, "--detach")
+	}
+	return shellJoin(append(args, spec.args()...))
+}
+
+var shellSafeRegexp = regexp.MustCompile(`^[a-zA-Z0-9_@%+=:,./-]+$`)
+
+// shellJoin quotes the words that contain special characters for a POSIX
+// shell, and joins them with spaces.
+func shellJoin(words []string) string {
+	quoted := make([]string, 0, len(words))
+	for _, word := range words {
+		if !shellSafeRegexp.MatchString(word) {
+			word = "'" + strings.Replace(word, "'", `'\''`, -1) + "'"
+		}
+		quoted = append(quoted, word)
+	}
+	return strings.Join(quoted, " ")
+}
+
+func formatPort(port nat.Port) string {
+	if port.Proto() == "tcp" {
+		return port.Port()
+	}
+	return string(port)
+}
+
+func formatPortBinding(port nat.Port, binding nat.PortBinding) string {
+	switch {
+	case binding.HostIP != "":
+		return fmt.Sprintf("%s:%s:%s", binding.HostIP, binding.HostPort, formatPort(port))
+	case binding.HostPort != "":
+		return fmt.Sprintf("%s:%s", binding.HostPort, formatPort(port))
+	default:
+		return formatPort(port)
+	}
+}
+
+// formatLink converts a link as stored by the daemon ("/db:/web/alias") to
+// the --link format ("db:alias").
+func formatLink(link string) string {
+	parts := strings.SplitN(link, ":", 2)
+	name := strings.TrimPrefix(parts[0], "/")
+	if len(parts) == 1 {
+		return name
+	}
+	if alias := path.Base(parts[1]); alias != name {
+		return name + ":" + alias
+	}
+	return name
+}
+
+func formatDevice(device container.DeviceMapping) string {
+	value := device.PathOnHost
+	if device.PathInContainer != device.PathOnHost || device.CgroupPermissions != "rwm" {
+		value += ":" + device.PathInContainer
+	}
+	if device.CgroupPermissions != "rwm" {
+		value += ":" + device.CgroupPermissions
+	}
+	return value
+}
+
+func formatMount(mount mounttypes.Mount) string {
+	fields := []string{"type=" + string(mount.Type)}
+	if mount.Source != "" {
+		fields = append(fields, "source="+mount.Source)
+	}
+	fields = append(fields, "target="+mount.Target)
+	if mount.ReadOnly {
+		fields = append(fields, "readonly")
+	}
+	if mount.Consistency != "" {
+		fields = append(fields, "consistency="+string(mount.Consistency))
+	}
+	if mount.BindOptions != nil && mount.BindOptions.Propagation != "" {
+		fields = append(fields, "bind-propagation="+string(mount.BindOptions.Propagation))
+	}
+	if options := mount.VolumeOptions; options != nil {
+		if options.NoCopy {
+			fields = append(fields, "volume-nocopy")
+		}
+		for _, label := range formatKeyValues(options.Labels) {
+			fields = append(fields, "volume-label="+label)
+		}
+		if options.DriverConfig != nil {
+			if options.DriverConfig.Name != "" {
+				fields = append(fields, "volume-driver="+options.DriverConfig.Name)
+			}
+			for _, option := range formatKeyValues(options.DriverConfig.Options) {
+				fields = append(fields, "volume-opt="+option)
+			}
+		}
+	}
+	if options := mount.TmpfsOptions; options != nil {
+		if options.SizeBytes != 0 {
+			fields = append(fields, "tmpfs-size="+formatMemBytes(options.SizeBytes))
+		}
+		if options.Mode != 0 {
+			fields = append(fields, fmt.Sprintf("tmpfs-mode=%o", options.Mode))
+		}
+	}
+
+	// --mount is parsed as CSV, so fields containing a comma are quoted
+	buf := &bytes.Buffer{}
+	w := csv.NewWriter(buf)
+	w.Write(fields)
+	w.Flush()
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// formatMemBytes formats a number of bytes in the largest unit accepted by
+// the memory options that represents it exactly.
+func formatMemBytes(value int64) string {
+	for _, unit := range []struct {
+		suffix string
+		size   int64
+	}{
+		{"g", 1 << 30},
+		{"m", 1 << 20},
+		{"k", 1 << 10},
+	} {
+		if value >= unit.size && value%unit.size == 0 {
+			return strconv.FormatInt(value/unit.size, 10) + unit.suffix
+		}
+	}
+	return strconv.FormatInt(value, 10)
+}
+
+func formatKeyValues(values map[string]string) []string {
+	formatted := make([]string, 0, len(values))
+	for key, value := range values {
+		formatted = append(formatted, key+"="+value)
+	}
+	sort.Strings(formatted)
+	return formatted
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// subtractStrings returns the values of a which are not in b
+func subtractStrings(a, b []string) []string {
+	set := map[string]bool{}
+	for _, value := range b {
+		set[value] = true
+	}
+	var values []string
+	for _, value := range a {
+		if !set[value] {
+			values = append(values, value)
+		}
+	}
+	return values
+}
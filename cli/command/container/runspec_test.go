@@ -0,0 +1,293 @@
+package container
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/cli/internal/test/testutil"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+	"github.com/gotestyourself/gotestyourself/fs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const runSpecContainerID = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func runSpecContainer(config *containerConfig) types.ContainerJSON {
+	return types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{
+			ID:         runSpecContainerID,
+			Name:       "/web",
+			Image:      "sha256:e5d2ed1b4e2a",
+			HostConfig: config.HostConfig,
+		},
+		Config:          config.Config,
+		NetworkSettings: &types.NetworkSettings{Networks: config.NetworkingConfig.EndpointsConfig},
+	}
+}
+
+func TestRunSpecRoundTrip(t *testing.T) {
+	args := []string{
+		"--add-host=db:10.0.0.3",
+		"--cap-add=NET_ADMIN",
+		"--cpus=1.5",
+		"--device=/dev/sda:/dev/xvda:r",
+		"--dns=8.8.8.8",
+		"--entrypoint=/bin/sh",
+		"--env=A=1",
+		"--env=B=two words",
+		"--expose=9000",
+		"--health-cmd=curl -f http://localhost/",
+		"--health-interval=30s",
+		"--hostname=web",
+		"--init",
+		"--interactive",
+		"--ip=10.0.0.2",
+		"--label=com.example.role=frontend",
+		"--link=db:database",
+		"--log-driver=syslog",
+		"--log-opt=tag=web",
+		"--memory=512m",
+		"--mount=type=volume,source=data,target=/data,volume-nocopy",
+		"--network=mynet",
+		"--network-alias=www",
+		"--publish=127.0.0.1:8080:80",
+		"--publish=53/udp",
+		"--read-only",
+		"--restart=on-failure:3",
+		"--security-opt=label=disable",
+		"--shm-size=128m",
+		"--stop-signal=SIGINT",
+		"--stop-timeout=20",
+		"--sysctl=net.core.somaxconn=1024",
+		"--tmpfs=/run:size=64m",
+		"--tty",
+		"--ulimit=nofile=1024:2048",
+		"--user=1000:1000",
+		"--volume=/srv/www:/var/www:ro",
+		"--volume=/cache",
+		"--workdir=/app",
+	}
+	flags, copts := setupRunFlags()
+	require.NoError(t, flags.Parse(args))
+	copts.Image = "nginx"
+	copts.Args = []string{"-c", "nginx -g 'daemon off;'"}
+	expected, err := parse(flags, copts)
+	require.NoError(t, err)
+
+	spec := newRunSpec(runSpecContainer(expected), nil)
+	assert.Equal(t, "web", spec.Name)
+	assert.Equal(t, "nginx", spec.Image)
+
+	flags, copts = setupRunFlags()
+	require.NoError(t, flags.Parse(nil))
+	var name string
+	require.NoError(t, applyRunSpec(flags, spec, &name, copts))
+	actual, err := parse(flags, copts)
+	require.NoError(t, err)
+
+	assert.Equal(t, "web", name)
+	assert.Equal(t, expected.Config, actual.Config)
+	assert.Equal(t, expected.HostConfig, actual.HostConfig)
+	assert.Equal(t, expected.NetworkingConfig, actual.NetworkingConfig)
+}
+
+func TestNewRunSpecOmitsDefaults(t *testing.T) {
+	imageConfig := &container.Config{
+		Env:          []string{"PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"},
+		Cmd:          []string{"nginx", "-g", "daemon off;"},
+		WorkingDir:   "/usr/share/nginx/html",
+		Labels:       map[string]string{"maintainer": "NGINX"},
+		ExposedPorts: nat.PortSet{"80/tcp": {}},
+		Volumes:      map[string]struct{}{"/var/cache/nginx": {}},
+		StopSignal:   "SIGTERM",
+	}
+	swappiness := int64(-1)
+	c := types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{
+			ID:   runSpecContainerID,
+			Name: "/web",
+			HostConfig: &container.HostConfig{
+				NetworkMode: "mynet",
+				IpcMode:     "shareable",
+				LogConfig:   container.LogConfig{Type: "json-file"},
+				ShmSize:     defaultShmSize,
+				Runtime:     "runc",
+				Resources:   container.Resources{MemorySwappiness: &swappiness},
+			},
+		},
+		Config: &container.Config{
+			Hostname:     runSpecContainerID[:12],
+			Image:        "nginx:alpine",
+			Env:          append([]string{"APP_ENV=production"}, imageConfig.Env...),
+			Cmd:          imageConfig.Cmd,
+			WorkingDir:   imageConfig.WorkingDir,
+			Labels:       map[string]string{"maintainer": "NGINX", "role": "frontend"},
+			ExposedPorts: imageConfig.ExposedPorts,
+			Volumes:      imageConfig.Volumes,
+			StopSignal:   "SIGTERM",
+		},
+		NetworkSettings: &types.NetworkSettings{
+			Networks: map[string]*network.EndpointSettings{
+				"mynet": {Aliases: []string{runSpecContainerID[:12], "www"}},
+			},
+		},
+	}
+
+	spec := newRunSpec(c, imageConfig)
+	assert.Equal(t, &runSpec{
+		Name:  "web",
+		Image: "nginx:alpine",
+		Options: map[string]runSpecValues{
+			"env":           {"APP_ENV=production"},
+			"label":         {"role=frontend"},
+			"network":       {"mynet"},
+			"network-alias": {"www"},
+		},
+	}, spec)
+}
+
+func TestNewRunSpecContainerNetworkHostname(t *testing.T) {
+	c := types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{
+			ID:         runSpecContainerID,
+			HostConfig: &container.HostConfig{NetworkMode: "container:db"},
+		},
+		// The daemon copies the hostname of the container whose network is
+		// shared.
+		Config: &container.Config{Image: "busybox", Hostname: "db.example.com"},
+	}
+
+	spec := newRunSpec(c, &container.Config{})
+	assert.NotContains(t, spec.Options, "hostname")
+	assert.Equal(t, runSpecValues{"container:db"}, spec.Options["network"])
+}
+
+func TestNewRunSpecEntrypointArguments(t *testing.T) {
+	imageConfig := &container.Config{Entrypoint: []string{"docker-entrypoint.sh"}, Cmd: []string{"postgres"}}
+	c := types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{ID: runSpecContainerID, HostConfig: &container.HostConfig{}},
+		Config: &container.Config{
+			Image:      "postgres",
+			Entrypoint: []string{"/bin/sh", "-c"},
+			Cmd:        []string{"exec postgres"},
+		},
+	}
+
+	spec := newRunSpec(c, imageConfig)
+	assert.Equal(t, runSpecValues{"/bin/sh"}, spec.Options["entrypoint"])
+	assert.Equal(t, []string{"-c", "exec postgres"}, spec.Command)
+}
+
+func TestRunCommandLine(t *testing.T) {
+	spec := &runSpec{
+		Name:    "web",
+		Image:   "nginx",
+		Command: []string{"nginx", "-g", "daemon off;"},
+		Options: map[string]runSpecValues{
+			"env":     {"GREETING=it's me", "A=1"},
+			"publish": {"8080:80"},
+			"rm":      {"true"},
+		},
+	}
+	expected := `docker run --detach --name web '--env=GREETING=it'\''s me' --env=A=1 --publish=8080:80 --rm nginx nginx -g 'daemon off;'`
+	assert.Equal(t, expected, runCommandLine(spec, true))
+}
+
+func TestRunSpecYAML(t *testing.T) {
+	spec := &runSpec{
+		Name:  "web",
+		Image: "nginx",
+		Options: map[string]runSpecValues{
+			"env":          {"A=1", "B=2"},
+			"publish":      {"8080:80"},
+			"rm":           {"true"},
+			"stop-timeout": {"20"},
+		},
+	}
+	content, err := marshalRunSpec(spec)
+	require.NoError(t, err)
+	assert.Equal(t, `name: web
+image: nginx
+options:
+  env:
+  - A=1
+  - B=2
+  publish: 8080:80
+  rm: true
+  stop-timeout: 20
+`, string(content))
+
+	dir := fs.NewDir(t, "runspec-test", fs.WithFile("web.yml", string(content)))
+	defer dir.Remove()
+	loaded, err := loadRunSpec(dir.Join("web.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, spec, loaded)
+}
+
+func TestApplyRunSpecUnknownOption(t *testing.T) {
+	flags, copts := setupRunFlags()
+	require.NoError(t, flags.Parse(nil))
+	var name string
+	spec := &runSpec{Image: "nginx", Options: map[string]runSpecValues{"detach": {"true"}}}
+	err := applyRunSpec(flags, spec, &name, copts)
+	testutil.ErrorContains(t, err, `unknown option "detach" in run spec`)
+}
+
+func TestInspectRunCommandFormat(t *testing.T) {
+	flags, copts := setupRunFlags()
+	require.NoError(t, flags.Parse([]string{"--publish=8080:80", "--env=A=1"}))
+	copts.Image = "nginx"
+	config, err := parse(flags, copts)
+	require.NoError(t, err)
+	config.Config.AttachStdout = false
+	config.Config.AttachStderr = false
+
+	fakeClient := &fakeClient{
+		inspectFunc: func(string) (types.ContainerJSON, error) {
+			return runSpecContainer(config), nil
+		},
+	}
+	cli := test.NewFakeCli(fakeClient)
+	err = runInspect(cli, inspectOptions{format: runCommandFormat, refs: []string{"web"}})
+	require.NoError(t, err)
+	assert.Equal(t, "docker run --detach --name web --env=A=1 --publish=8080:80 nginx\n", cli.OutBuffer().String())
+}
+
+func TestCreateFromSpec(t *testing.T) {
+	dir := fs.NewDir(t, "runspec-test", fs.WithFile("web.yml", `name: web
+image: nginx
+command: [nginx, -g, "daemon off;"]
+options:
+  env: [A=1, B=2]
+  publish: 8080:80
+`))
+	defer dir.Remove()
+
+	var (
+		name   string
+		config *container.Config
+	)
+	fakeClient := &fakeClient{
+		createContainerFunc: func(c *container.Config, _ *container.HostConfig, _ *network.NetworkingConfig, containerName string) (container.ContainerCreateCreatedBody, error) {
+			name = containerName
+			config = c
+			return container.ContainerCreateCreatedBody{ID: "abc123"}, nil
+		},
+	}
+	cmd := NewCreateCommand(test.NewFakeCli(fakeClient))
+	cmd.SetOutput(ioutil.Discard)
+	cmd.SetArgs([]string{"--from-spec", dir.Join("web.yml"), "--env=C=3"})
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, "web", name)
+	assert.Equal(t, "nginx", config.Image)
+	assert.Equal(t, []string{"nginx", "-g", "daemon off;"}, []string(config.Cmd))
+	// Options of the command line take precedence over the spec
+	assert.Equal(t, []string{"C=3"}, config.Env)
+	assert.Contains(t, config.ExposedPorts, nat.Port("80/tcp"))
+}
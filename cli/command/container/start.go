@@ -22,18 +22,19 @@ type startOptions struct {
 	detachKeys    string
 	checkpoint    string
 	checkpointDir string
+	bulk          bulkOptions
 
 	containers []string
 }
 
 // NewStartCommand creates a new cobra.Command for `docker start`
 func NewStartCommand(dockerCli command.Cli) *cobra.Command {
-	var opts startOptions
+	opts := startOptions{bulk: bulkOptions{includeStopped: true}}
 
 	cmd := &cobra.Command{
 		Use:   "start [OPTIONS] CONTAINER [CONTAINER...]",
 		Short: "Start one or more stopped containers",
-		Args:  cli.RequiresMinArgs(1),
+		Args:  requiresContainers(&opts.bulk),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.containers = args
 			return runStart(dockerCli, &opts)
@@ -49,6 +50,7 @@ func NewStartCommand(dockerCli command.Cli) *cobra.Command {
 	flags.SetAnnotation("checkpoint", "experimental", nil)
 	flags.StringVar(&opts.checkpointDir, "checkpoint-dir", "", "Use a custom checkpoint storage directory")
 	flags.SetAnnotation("checkpoint-dir", "experimental", nil)
+	addBulkFlags(flags, &opts.bulk)
 	return cmd
 }
 
@@ -56,6 +58,15 @@ func NewStartCommand(dockerCli command.Cli) *cobra.Command {
 func runStart(dockerCli command.Cli, opts *startOptions) error {
 	ctx, cancelFun := context.WithCancel(context.Background())
 
+	if opts.bulk.selected() {
+		if opts.attach || opts.openStdin || opts.checkpoint != "" {
+			return errors.New("you cannot attach to or restore containers selected with --all or --filter")
+		}
+		return runBulkOperation(ctx, dockerCli, &opts.bulk, "start", "started", func(ctx context.Context, container string) error {
+			return dockerCli.Client().ContainerStart(ctx, container, types.ContainerStartOptions{})
+		})
+	}
+
 	if opts.attach || opts.openStdin {
 		// We're going to attach to a container.
 		// 1. Ensure we only have one container.
@@ -3,6 +3,8 @@ package container
 import (
 	"fmt"
 	"io"
+	"net"
+	"net/http"
 	"strings"
 	"sync"
 	"time"
@@ -14,6 +16,7 @@ import (
 	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"golang.org/x/net/context"
 )
@@ -22,7 +25,10 @@ type statsOptions struct {
 	all        bool
 	noStream   bool
 	noTrunc    bool
+	noRedraw   bool
 	format     string
+	interval   time.Duration
+	serve      string
 	containers []string
 }
 
@@ -44,7 +50,10 @@ func NewStatsCommand(dockerCli command.Cli) *cobra.Command {
 	flags.BoolVarP(&opts.all, "all", "a", false, "Show all containers (default shows just running)")
 	flags.BoolVar(&opts.noStream, "no-stream", false, "Disable streaming stats and only pull the first result")
 	flags.BoolVar(&opts.noTrunc, "no-trunc", false, "Do not truncate output")
-	flags.StringVar(&opts.format, "format", "", "Pretty-print images using a Go template")
+	flags.StringVar(&opts.format, "format", "", "Pretty-print images using a Go template, or write samples as json or csv")
+	flags.BoolVar(&opts.noRedraw, "no-redraw", false, "Print each sample after the previous one instead of redrawing the screen")
+	flags.DurationVar(&opts.interval, "interval", 500*time.Millisecond, "Interval between samples")
+	flags.StringVar(&opts.serve, "serve", "", "Serve the statistics as Prometheus metrics on ADDR instead of printing them")
 	return cmd
 }
 
@@ -52,6 +61,13 @@ func NewStatsCommand(dockerCli command.Cli) *cobra.Command {
 // This shows real-time information on CPU usage, memory usage, and network I/O.
 // nolint: gocyclo
 func runStats(dockerCli command.Cli, opts *statsOptions) error {
+	if opts.interval <= 0 {
+		return errors.Errorf("invalid interval %s: must be greater than 0", opts.interval)
+	}
+	if opts.serve != "" && (opts.noStream || opts.format != "") {
+		return errors.New("conflicting options: --serve cannot be used with --no-stream or --format")
+	}
+
 	showAll := len(opts.containers) == 0
 	closeChan := make(chan error)
 
@@ -188,35 +204,15 @@ func runStats(dockerCli command.Cli, opts *statsOptions) error {
 
 	// before print to screen, make sure each container get at least one valid stat data
 	waitFirst.Wait()
-	format := opts.format
-	if len(format) == 0 {
-		if len(dockerCli.ConfigFile().StatsFormat) > 0 {
-			format = dockerCli.ConfigFile().StatsFormat
-		} else {
-			format = formatter.TableFormatKey
-		}
-	}
-	statsCtx := formatter.Context{
-		Output: dockerCli.Out(),
-		Format: formatter.NewStatsFormat(format, daemonOSType),
-	}
-	cleanScreen := func() {
-		if !opts.noStream {
-			fmt.Fprint(dockerCli.Out(), "\033[2J")
-			fmt.Fprint(dockerCli.Out(), "\033[H")
-		}
+
+	if opts.serve != "" {
+		return serveStats(dockerCli, opts.serve, &cStats, closeChan)
 	}
 
+	writeStats := newStatsWriter(dockerCli, opts)
 	var err error
-	for range time.Tick(500 * time.Millisecond) {
-		cleanScreen()
-		ccstats := []formatter.StatsEntry{}
-		cStats.mu.Lock()
-		for _, c := range cStats.cs {
-			ccstats = append(ccstats, c.GetStatistics())
-		}
-		cStats.mu.Unlock()
-		if err = formatter.ContainerStatsWrite(statsCtx, ccstats, daemonOSType, !opts.noTrunc); err != nil {
+	for range time.Tick(opts.interval) {
+		if err = writeStats(cStats.entries()); err != nil {
 			break
 		}
 		if len(cStats.cs) == 0 && !showAll {
@@ -243,3 +239,91 @@ func runStats(dockerCli command.Cli, opts *statsOptions) error {
 	}
 	return err
 }
+
+// newStatsWriter returns a function writing a sample of the statistics of
+// the containers, in the format of the options.
+func newStatsWriter(dockerCli command.Cli, opts *statsOptions) func([]formatter.StatsEntry) error {
+	format := opts.format
+	if len(format) == 0 {
+		if len(dockerCli.ConfigFile().StatsFormat) > 0 {
+			format = dockerCli.ConfigFile().StatsFormat
+		} else {
+			format = formatter.TableFormatKey
+		}
+	}
+
+	switch format {
+	case formatter.JSONFormatKey:
+		return func(entries []formatter.StatsEntry) error {
+			return formatter.ContainerStatsJSONWrite(dockerCli.Out(), time.Now(), entries)
+		}
+	case formatter.StatsCSVFormatKey:
+		header := true
+		return func(entries []formatter.StatsEntry) error {
+			err := formatter.ContainerStatsCSVWrite(dockerCli.Out(), time.Now(), entries, header)
+			header = false
+			return err
+		}
+	}
+
+	statsCtx := formatter.Context{
+		Output: dockerCli.Out(),
+		Format: formatter.NewStatsFormat(format, daemonOSType),
+	}
+	return func(entries []formatter.StatsEntry) error {
+		if !opts.noStream && !opts.noRedraw {
+			fmt.Fprint(dockerCli.Out(), "\033[2J")
+			fmt.Fprint(dockerCli.Out(), "\033[H")
+		}
+		return formatter.ContainerStatsWrite(statsCtx, entries, daemonOSType, !opts.noTrunc)
+	}
+}
+
+// serveStats serves the statistics of the containers as Prometheus metrics
+// on addr, until the monitoring of the containers fails.
+func serveStats(dockerCli command.Cli, addr string, cStats *stats, closeChan chan error) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", statsMetricsHandler(cStats))
+	server := &http.Server{Handler: mux}
+	defer server.Close()
+
+	fmt.Fprintf(dockerCli.Out(), "Serving metrics on http://%s/metrics\n", listener.Addr())
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(listener)
+	}()
+
+	for {
+		select {
+		case err := <-serveErr:
+			return err
+		case err, ok := <-closeChan:
+			if !ok {
+				// closeChan is closed when monitoring given containers
+				closeChan = nil
+				continue
+			}
+			// this is suppressing "unexpected EOF" in the cli when the
+			// daemon restarts so it shutdowns cleanly
+			if err == io.ErrUnexpectedEOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// statsMetricsHandler returns a handler exposing the statistics of the
+// containers as Prometheus metrics.
+func statsMetricsHandler(cStats *stats) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		if err := formatter.ContainerStatsPrometheusWrite(w, cStats.entries(), daemonOSType); err != nil {
+			logrus.Debugf("Error writing metrics: %s", err)
+		}
+	})
+}
@@ -43,6 +43,17 @@ func (s *stats) remove(id string) {
 	s.mu.Unlock()
 }
 
+// entries returns the current statistics of the containers.
+func (s *stats) entries() []formatter.StatsEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries := make([]formatter.StatsEntry, 0, len(s.cs))
+	for _, c := range s.cs {
+		entries = append(entries, c.GetStatistics())
+	}
+	return entries
+}
+
 func (s *stats) isKnownContainer(cid string) (int, bool) {
 	for i, c := range s.cs {
 		if c.Container == cid {
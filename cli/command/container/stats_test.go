@@ -0,0 +1,94 @@
+package container
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/cli/cli/command/formatter"
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/cli/internal/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsWriter(t *testing.T) {
+	entries := []formatter.StatsEntry{{Container: "web", Name: "/web", ID: "0123456789ab", CPUPercentage: 1.5}}
+	testcases := []struct {
+		doc      string
+		options  statsOptions
+		expected []string
+	}{
+		{
+			doc:      "csv",
+			options:  statsOptions{format: "csv"},
+			expected: []string{"Time,Container,Name,", ",web,web,0123456789ab,1.50,", ",web,web,0123456789ab,1.50,"},
+		},
+		{
+			doc:      "json",
+			options:  statsOptions{format: "json"},
+			expected: []string{`{"Time":`, `{"Time":`},
+		},
+		{
+			doc:      "no redraw",
+			options:  statsOptions{format: "{{.Name}} {{.CPUPerc}}", noRedraw: true},
+			expected: []string{"web 1.50%", "web 1.50%"},
+		},
+	}
+	for _, tc := range testcases {
+		cli := test.NewFakeCli(&fakeClient{})
+		writeStats := newStatsWriter(cli, &tc.options)
+		require.NoError(t, writeStats(entries), tc.doc)
+		require.NoError(t, writeStats(entries), tc.doc)
+
+		lines := strings.Split(strings.TrimSuffix(cli.OutBuffer().String(), "\n"), "\n")
+		require.Len(t, lines, len(tc.expected), tc.doc)
+		for i, expected := range tc.expected {
+			assert.Contains(t, lines[i], expected, tc.doc)
+		}
+	}
+}
+
+func TestStatsRedraw(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{})
+	writeStats := newStatsWriter(cli, &statsOptions{format: "{{.Name}}"})
+	require.NoError(t, writeStats([]formatter.StatsEntry{{Name: "/web"}}))
+	assert.Equal(t, "\033[2J\033[Hweb\n", cli.OutBuffer().String())
+}
+
+func TestStatsMetricsHandler(t *testing.T) {
+	cStats := &stats{}
+	s := formatter.NewContainerStats("web")
+	s.SetStatistics(formatter.StatsEntry{Name: "/web", ID: "0123456789ab", CPUPercentage: 1.5})
+	cStats.add(s)
+
+	recorder := httptest.NewRecorder()
+	statsMetricsHandler(cStats).ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, "text/plain; version=0.0.4", recorder.Header().Get("Content-Type"))
+	assert.Contains(t, recorder.Body.String(), `docker_container_cpu_percent{id="0123456789ab",name="web"} 1.5`)
+}
+
+func TestRunStatsInvalidOptions(t *testing.T) {
+	testcases := []struct {
+		options       statsOptions
+		expectedError string
+	}{
+		{
+			options:       statsOptions{},
+			expectedError: "invalid interval 0s: must be greater than 0",
+		},
+		{
+			options:       statsOptions{interval: time.Second, serve: ":9323", noStream: true},
+			expectedError: "conflicting options: --serve cannot be used with --no-stream or --format",
+		},
+		{
+			options:       statsOptions{interval: time.Second, serve: ":9323", format: "json"},
+			expectedError: "conflicting options: --serve cannot be used with --no-stream or --format",
+		},
+	}
+	for _, tc := range testcases {
+		err := runStats(test.NewFakeCli(&fakeClient{}), &tc.options)
+		testutil.ErrorContains(t, err, tc.expectedError)
+	}
+}
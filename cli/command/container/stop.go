@@ -5,7 +5,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -15,6 +14,7 @@ import (
 type stopOptions struct {
 	time        int
 	timeChanged bool
+	bulk        bulkOptions
 
 	containers []string
 }
@@ -26,7 +26,7 @@ func NewStopCommand(dockerCli command.Cli) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "stop [OPTIONS] CONTAINER [CONTAINER...]",
 		Short: "Stop one or more running containers",
-		Args:  cli.RequiresMinArgs(1),
+		Args:  requiresContainers(&opts.bulk),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.containers = args
 			opts.timeChanged = cmd.Flags().Changed("time")
@@ -36,6 +36,7 @@ func NewStopCommand(dockerCli command.Cli) *cobra.Command {
 
 	flags := cmd.Flags()
 	flags.IntVarP(&opts.time, "time", "t", 10, "Seconds to wait for stop before killing it")
+	addBulkFlags(flags, &opts.bulk)
 	return cmd
 }
 
@@ -48,11 +49,15 @@ func runStop(dockerCli command.Cli, opts *stopOptions) error {
 		timeout = &timeoutValue
 	}
 
-	var errs []string
-
-	errChan := parallelOperation(ctx, opts.containers, func(ctx context.Context, id string) error {
+	stop := func(ctx context.Context, id string) error {
 		return dockerCli.Client().ContainerStop(ctx, id, timeout)
-	})
+	}
+	if opts.bulk.selected() {
+		return runBulkOperation(ctx, dockerCli, &opts.bulk, "stop", "stopped", stop)
+	}
+
+	var errs []string
+	errChan := parallelOperation(ctx, opts.containers, opts.bulk.parallel, stop)
 	for _, container := range opts.containers {
 		if err := <-errChan; err != nil {
 			errs = append(errs, err.Error())
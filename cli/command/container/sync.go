@@ -0,0 +1,398 @@
+package container
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/image/build"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/fileutils"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+type syncOptions struct {
+	source      string
+	destination string
+	excludes    []string
+	noIgnore    bool
+	once        bool
+	interval    time.Duration
+	debounce    time.Duration
+	quiet       bool
+}
+
+// NewSyncCommand creates a new `docker container sync` command
+func NewSyncCommand(dockerCli command.Cli) *cobra.Command {
+	var opts syncOptions
+
+	cmd := &cobra.Command{
+		Use:   "sync [OPTIONS] SRC_PATH CONTAINER:DEST_PATH",
+		Short: "Synchronize a local directory into a running container",
+		Args:  cli.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.source = args[0]
+			opts.destination = args[1]
+			return runSync(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringSliceVar(&opts.excludes, "exclude", []string{}, "Exclude files matching a pattern, using the .dockerignore syntax")
+	flags.BoolVar(&opts.noIgnore, "no-ignore", false, "Do not exclude the files listed in the .dockerignore file of SRC_PATH")
+	flags.BoolVar(&opts.once, "once", false, "Exit after the initial synchronization")
+	flags.DurationVar(&opts.interval, "interval", 500*time.Millisecond, "Interval between checks for changes")
+	flags.DurationVar(&opts.debounce, "debounce", 300*time.Millisecond, "Time without changes to wait for before synchronizing")
+	flags.BoolVarP(&opts.quiet, "quiet", "q", false, "Only display errors")
+	return cmd
+}
+
+// syncConfig is the configuration of a synchronization
+type syncConfig struct {
+	srcDir    string
+	container string
+	destPath  string
+	excludes  *fileutils.PatternMatcher
+	quiet     bool
+}
+
+func runSync(dockerCli command.Cli, opts syncOptions) error {
+	container, destPath := splitCpArg(opts.destination)
+	if container == "" {
+		return errors.New("destination must be a path in a container (CONTAINER:DEST_PATH)")
+	}
+	if destPath == "" {
+		destPath = "/"
+	}
+
+	srcDir, err := filepath.Abs(opts.source)
+	if err != nil {
+		return err
+	}
+	if stat, err := os.Stat(srcDir); err != nil {
+		return err
+	} else if !stat.IsDir() {
+		return errors.Errorf("source %s must be a directory", opts.source)
+	}
+
+	patterns := opts.excludes
+	if !opts.noIgnore {
+		ignored, err := build.ReadDockerignore(srcDir)
+		if err != nil {
+			return errors.Wrap(err, "failed to read the .dockerignore file")
+		}
+		patterns = append(ignored, patterns...)
+	}
+	config := syncConfig{
+		srcDir:    srcDir,
+		container: container,
+		destPath:  destPath,
+		quiet:     opts.quiet,
+	}
+	if len(patterns) > 0 {
+		if config.excludes, err = fileutils.NewPatternMatcher(patterns); err != nil {
+			return err
+		}
+	}
+
+	ctx := context.Background()
+	stat, err := dockerCli.Client().ContainerStatPath(ctx, container, destPath)
+	if err != nil {
+		return err
+	}
+	if !stat.Mode.IsDir() {
+		return errors.Errorf("destination \"%s:%s\" must be a directory", container, destPath)
+	}
+
+	// The initial synchronization copies all the files, as the content of
+	// the container is unknown.
+	current, err := scanSyncDir(config)
+	if err != nil {
+		return err
+	}
+	if err := applySyncChanges(ctx, dockerCli, config, diffSyncSnapshots(nil, current)); err != nil {
+		return err
+	}
+	if opts.once {
+		return nil
+	}
+
+	for {
+		time.Sleep(opts.interval)
+		next, err := scanSyncDir(config)
+		if err != nil {
+			return err
+		}
+		if diffSyncSnapshots(current, next).empty() {
+			continue
+		}
+		// Wait for the burst of changes to be over, so that the files are
+		// not copied while they are being written.
+		for {
+			time.Sleep(opts.debounce)
+			settled, err := scanSyncDir(config)
+			if err != nil {
+				return err
+			}
+			if diffSyncSnapshots(next, settled).empty() {
+				break
+			}
+			next = settled
+		}
+		current = syncSnapshots(ctx, dockerCli, config, current, next)
+	}
+}
+
+// syncSnapshots applies the changes between two snapshots, and returns the
+// snapshot the container is synchronized with. If the changes fail to apply,
+// the error is reported and the previous snapshot is returned, so that they
+// are applied again after the next scan.
+func syncSnapshots(ctx context.Context, dockerCli command.Cli, config syncConfig, current, next syncSnapshot) syncSnapshot {
+	if err := applySyncChanges(ctx, dockerCli, config, diffSyncSnapshots(current, next)); err != nil {
+		fmt.Fprintf(dockerCli.Err(), "%s Failed to synchronize %s:%s: %v\n",
+			time.Now().Format("15:04:05"), config.container, config.destPath, err)
+		return current
+	}
+	return next
+}
+
+// syncFileState is the state of a file, used to detect changes
+type syncFileState struct {
+	mode       os.FileMode
+	size       int64
+	modTime    time.Time
+	linkTarget string
+}
+
+// syncSnapshot holds the state of the files in the source directory, by
+// slash separated path relative to the directory.
+type syncSnapshot map[string]syncFileState
+
+func scanSyncDir(config syncConfig) (syncSnapshot, error) {
+	snapshot := syncSnapshot{}
+	err := filepath.Walk(config.srcDir, func(filePath string, f os.FileInfo, err error) error {
+		if err != nil {
+			// Files can be removed while the directory is walked
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		relPath, err := filepath.Rel(config.srcDir, filePath)
+		if err != nil {
+			return err
+		}
+		if relPath == "." {
+			return nil
+		}
+		if config.excludes != nil {
+			skip, err := config.excludes.Matches(relPath)
+			if err != nil {
+				return err
+			}
+			if skip {
+				// The files in an excluded directory may be included by an
+				// exception.
+				if f.IsDir() && !config.excludes.Exclusions() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+		}
+		// Named pipes, sockets and devices can't be copied
+		if !f.Mode().IsRegular() && !f.IsDir() && f.Mode()&os.ModeSymlink == 0 {
+			return nil
+		}
+
+		state := syncFileState{mode: f.Mode(), size: f.Size(), modTime: f.ModTime()}
+		if f.Mode()&os.ModeSymlink != 0 {
+			if state.linkTarget, err = os.Readlink(filePath); err != nil {
+				return err
+			}
+		}
+		snapshot[filepath.ToSlash(relPath)] = state
+		return nil
+	})
+	return snapshot, err
+}
+
+// syncChanges are the paths to copy and remove to synchronize a container
+type syncChanges struct {
+	copied  []string
+	removed []string
+}
+
+func (c syncChanges) empty() bool {
+	return len(c.copied) == 0 && len(c.removed) == 0
+}
+
+// diffSyncSnapshots returns the changes between two snapshots. Paths are
+// sorted, so that directories are copied before their content. The content
+// of removed directories is not listed, as it is removed with them.
+func diffSyncSnapshots(previous, current syncSnapshot) syncChanges {
+	var changes syncChanges
+	for name, state := range current {
+		if prev, ok := previous[name]; !ok || prev != state {
+			// Only the metadata of a directory is copied, a change of its
+			// modification time means that its content changed, which is
+			// detected on the files themselves.
+			if ok && state.mode.IsDir() && prev.mode == state.mode {
+				continue
+			}
+			changes.copied = append(changes.copied, name)
+		}
+	}
+	for name := range previous {
+		if _, ok := current[name]; !ok {
+			changes.removed = append(changes.removed, name)
+		}
+	}
+	sort.Strings(changes.copied)
+	sort.Strings(changes.removed)
+
+	var removed []string
+	for _, name := range changes.removed {
+		if len(removed) > 0 && strings.HasPrefix(name, removed[len(removed)-1]+"/") {
+			continue
+		}
+		removed = append(removed, name)
+	}
+	changes.removed = removed
+	return changes
+}
+
+func applySyncChanges(ctx context.Context, dockerCli command.Cli, config syncConfig, changes syncChanges) error {
+	if len(changes.removed) > 0 {
+		if err := removeSyncedPaths(ctx, dockerCli, config, changes.removed); err != nil {
+			return err
+		}
+	}
+	if len(changes.copied) > 0 {
+		content := syncArchive(config.srcDir, changes.copied)
+		defer content.Close()
+		options := types.CopyToContainerOptions{AllowOverwriteDirWithFile: true}
+		if err := dockerCli.Client().CopyToContainer(ctx, config.container, config.destPath, content, options); err != nil {
+			return err
+		}
+	}
+	if !config.quiet && !changes.empty() {
+		fmt.Fprintf(dockerCli.Out(), "%s Synchronized %s:%s: %d copied, %d removed\n",
+			time.Now().Format("15:04:05"), config.container, config.destPath, len(changes.copied), len(changes.removed))
+	}
+	return nil
+}
+
+// syncArchive returns a tar archive of the given paths of srcDir. Unlike
+// archive.TarWithOptions, directories are added without their content.
+func syncArchive(srcDir string, paths []string) io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		tw := tar.NewWriter(pw)
+		for _, name := range paths {
+			if err := addSyncArchiveEntry(tw, srcDir, name); err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+		pw.CloseWithError(tw.Close())
+	}()
+	return pr
+}
+
+func addSyncArchiveEntry(tw *tar.Writer, srcDir, name string) error {
+	filePath := filepath.Join(srcDir, filepath.FromSlash(name))
+	f, err := os.Lstat(filePath)
+	if err != nil {
+		// The file was removed after the directory was scanned, which
+		// will be synchronized next time.
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	var link string
+	if f.Mode()&os.ModeSymlink != 0 {
+		if link, err = os.Readlink(filePath); err != nil {
+			return err
+		}
+	}
+	var file *os.File
+	if f.Mode().IsRegular() {
+		if file, err = os.Open(filePath); err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		defer file.Close()
+	}
+	header, err := archive.FileInfoHeader(name, f, link)
+	if err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	if file == nil {
+		return nil
+	}
+	return copySyncFile(tw, file, header.Size)
+}
+
+// copySyncFile copies size bytes of a file to an archive. A file that was
+// truncated after its header was written is padded with zeros, its new
+// content is synchronized next time, as its size changed.
+func copySyncFile(w io.Writer, r io.Reader, size int64) error {
+	n, err := io.CopyN(w, r, size)
+	if err == io.EOF {
+		_, err = w.Write(make([]byte, size-n))
+	}
+	return err
+}
+
+// removeSyncedPaths removes paths from the container. There is no API to
+// remove files from a container, so `rm` is executed in the container.
+func removeSyncedPaths(ctx context.Context, dockerCli command.Cli, config syncConfig, paths []string) error {
+	client := dockerCli.Client()
+
+	cmd := []string{"rm", "-rf", "--"}
+	for _, name := range paths {
+		cmd = append(cmd, path.Join(config.destPath, name))
+	}
+	execConfig := types.ExecConfig{Cmd: cmd, AttachStdout: true, AttachStderr: true}
+	response, err := client.ContainerExecCreate(ctx, config.container, execConfig)
+	if err != nil {
+		return err
+	}
+	resp, err := client.ContainerExecAttach(ctx, response.ID, types.ExecStartCheck{})
+	if err != nil {
+		return err
+	}
+	defer resp.Close()
+	output := &bytes.Buffer{}
+	if _, err := stdcopy.StdCopy(output, output, resp.Reader); err != nil {
+		return err
+	}
+
+	inspect, err := client.ContainerExecInspect(ctx, response.ID)
+	if err != nil {
+		return err
+	}
+	if inspect.ExitCode != 0 {
+		return errors.Errorf("failed to remove files from the container: %s", strings.TrimSpace(output.String()))
+	}
+	return nil
+}
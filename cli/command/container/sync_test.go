@@ -0,0 +1,198 @@
+package container
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/cli/internal/test/testutil"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/fileutils"
+	"github.com/gotestyourself/gotestyourself/fs"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func TestDiffSyncSnapshots(t *testing.T) {
+	modTime := time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+	previous := syncSnapshot{
+		"app":             {mode: os.ModeDir | 0755, modTime: modTime},
+		"app/main.go":     {mode: 0644, size: 10, modTime: modTime},
+		"app/unchanged":   {mode: 0644, size: 10, modTime: modTime},
+		"old":             {mode: os.ModeDir | 0755, modTime: modTime},
+		"old/file":        {mode: 0644, size: 10, modTime: modTime},
+		"old/dir":         {mode: os.ModeDir | 0755, modTime: modTime},
+		"old/dir/file":    {mode: 0644, size: 10, modTime: modTime},
+		"link":            {mode: os.ModeSymlink | 0777, linkTarget: "app"},
+		"removed.txt":     {mode: 0644, size: 10, modTime: modTime},
+		"becomes-a-dir.d": {mode: 0644, size: 10, modTime: modTime},
+	}
+	current := syncSnapshot{
+		"app":             {mode: os.ModeDir | 0755, modTime: modTime.Add(time.Second)},
+		"app/main.go":     {mode: 0644, size: 12, modTime: modTime.Add(time.Second)},
+		"app/unchanged":   {mode: 0644, size: 10, modTime: modTime},
+		"app/new.go":      {mode: 0644, size: 10, modTime: modTime},
+		"link":            {mode: os.ModeSymlink | 0777, linkTarget: "old"},
+		"becomes-a-dir.d": {mode: os.ModeDir | 0755, modTime: modTime},
+	}
+
+	changes := diffSyncSnapshots(previous, current)
+	assert.Equal(t, []string{"app/main.go", "app/new.go", "becomes-a-dir.d", "link"}, changes.copied)
+	assert.Equal(t, []string{"old", "removed.txt"}, changes.removed)
+
+	assert.True(t, diffSyncSnapshots(current, current).empty())
+
+	initial := diffSyncSnapshots(nil, current)
+	assert.Equal(t, []string{"app", "app/main.go", "app/new.go", "app/unchanged", "becomes-a-dir.d", "link"}, initial.copied)
+	assert.Len(t, initial.removed, 0)
+}
+
+func TestScanSyncDirWithExcludes(t *testing.T) {
+	srcDir := fs.NewDir(t, "sync-test",
+		fs.WithFile("main.go", "package main\n"),
+		fs.WithDir("node_modules",
+			fs.WithFile("module.js", "")),
+		fs.WithDir("logs",
+			fs.WithFile("debug.log", ""),
+			fs.WithFile("keep.log", "")))
+	defer srcDir.Remove()
+
+	excludes, err := fileutils.NewPatternMatcher([]string{"node_modules", "logs/*", "!logs/keep.log"})
+	require.NoError(t, err)
+	snapshot, err := scanSyncDir(syncConfig{srcDir: srcDir.Path(), excludes: excludes})
+	require.NoError(t, err)
+
+	var names []string
+	for name := range snapshot {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	assert.Equal(t, []string{"logs", "logs/keep.log", "main.go"}, names)
+}
+
+func TestRunSyncOnce(t *testing.T) {
+	srcDir := fs.NewDir(t, "sync-test",
+		fs.WithFile(".dockerignore", "*.tmp\n"),
+		fs.WithFile("index.html", "<html></html>\n"),
+		fs.WithFile("scratch.tmp", ""),
+		fs.WithDir("css",
+			fs.WithFile("style.css", "body {}\n")))
+	defer srcDir.Remove()
+
+	var (
+		copiedTo string
+		entries  map[string]string
+	)
+	fakeClient := &fakeClient{
+		containerStatPathFunc: func(container, path string) (types.ContainerPathStat, error) {
+			return types.ContainerPathStat{Mode: os.ModeDir | 0755}, nil
+		},
+		containerCopyToFunc: func(container, path string, content io.Reader, options types.CopyToContainerOptions) error {
+			copiedTo = container + ":" + path
+			entries = tarEntries(t, content)
+			return nil
+		},
+	}
+	cli := test.NewFakeCli(fakeClient)
+	err := runSync(cli, syncOptions{source: srcDir.Path(), destination: "web:/usr/share/nginx/html", once: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, "web:/usr/share/nginx/html", copiedTo)
+	assert.Equal(t, map[string]string{
+		".dockerignore": "*.tmp\n",
+		"css/":          "",
+		"css/style.css": "body {}\n",
+		"index.html":    "<html></html>\n",
+	}, entries)
+	assert.Contains(t, cli.OutBuffer().String(), "Synchronized web:/usr/share/nginx/html: 4 copied, 0 removed")
+}
+
+func TestSyncSnapshotsRetriesFailedChanges(t *testing.T) {
+	srcDir := fs.NewDir(t, "sync-test", fs.WithFile("index.html", "<html></html>\n"))
+	defer srcDir.Remove()
+
+	copyErr := errors.New("container is restarting")
+	fakeClient := &fakeClient{
+		containerCopyToFunc: func(container, path string, content io.Reader, options types.CopyToContainerOptions) error {
+			return copyErr
+		},
+	}
+	cli := test.NewFakeCli(fakeClient)
+	config := syncConfig{srcDir: srcDir.Path(), container: "web", destPath: "/app"}
+	next, err := scanSyncDir(config)
+	require.NoError(t, err)
+
+	current := syncSnapshots(context.Background(), cli, config, syncSnapshot{}, next)
+	assert.Equal(t, syncSnapshot{}, current)
+	assert.Contains(t, cli.ErrBuffer().String(), "Failed to synchronize web:/app: container is restarting")
+
+	copyErr = nil
+	current = syncSnapshots(context.Background(), cli, config, current, next)
+	assert.Equal(t, next, current)
+	assert.Contains(t, cli.OutBuffer().String(), "Synchronized web:/app: 1 copied, 0 removed")
+}
+
+func TestCopySyncFilePadsTruncatedFile(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.NoError(t, copySyncFile(buf, strings.NewReader("abc"), 5))
+	assert.Equal(t, []byte("abc\x00\x00"), buf.Bytes())
+
+	buf.Reset()
+	require.NoError(t, copySyncFile(buf, strings.NewReader("abcdefg"), 5))
+	assert.Equal(t, "abcde", buf.String())
+}
+
+func TestRunSyncDestinationNotADirectory(t *testing.T) {
+	srcDir := fs.NewDir(t, "sync-test")
+	defer srcDir.Remove()
+
+	fakeClient := &fakeClient{
+		containerStatPathFunc: func(container, path string) (types.ContainerPathStat, error) {
+			return types.ContainerPathStat{Mode: 0644}, nil
+		},
+	}
+	err := runSync(test.NewFakeCli(fakeClient), syncOptions{source: srcDir.Path(), destination: "web:/etc/hosts", once: true})
+	testutil.ErrorContains(t, err, `destination "web:/etc/hosts" must be a directory`)
+
+	err = runSync(test.NewFakeCli(fakeClient), syncOptions{source: srcDir.Path(), destination: "/etc/hosts", once: true})
+	testutil.ErrorContains(t, err, "destination must be a path in a container")
+}
+
+func TestRemoveSyncedPaths(t *testing.T) {
+	var (
+		execConfig types.ExecConfig
+		exitCode   int
+	)
+	fakeClient := &fakeClient{
+		execCreateFunc: func(container string, config types.ExecConfig) (types.IDResponse, error) {
+			execConfig = config
+			return types.IDResponse{ID: "exec-id"}, nil
+		},
+		execAttachFunc: func(execID string, config types.ExecStartCheck) (types.HijackedResponse, error) {
+			server, conn := net.Pipe()
+			server.Close()
+			return types.HijackedResponse{Conn: conn, Reader: bufio.NewReader(&bytes.Buffer{})}, nil
+		},
+		execInspectFunc: func(execID string) (types.ContainerExecInspect, error) {
+			return types.ContainerExecInspect{ExitCode: exitCode}, nil
+		},
+	}
+	config := syncConfig{container: "web", destPath: "/app"}
+
+	err := removeSyncedPaths(context.Background(), test.NewFakeCli(fakeClient), config, []string{"old", "dir/file.txt"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"rm", "-rf", "--", "/app/old", "/app/dir/file.txt"}, execConfig.Cmd)
+
+	exitCode = 1
+	err = removeSyncedPaths(context.Background(), test.NewFakeCli(fakeClient), config, []string{"old"})
+	testutil.ErrorContains(t, err, "failed to remove files from the container")
+}
@@ -40,9 +40,18 @@ func resizeTtyTo(ctx context.Context, client client.ContainerAPIClient, id strin
 
 // MonitorTtySize updates the container tty size when the terminal tty changes size
 func MonitorTtySize(ctx context.Context, cli command.Cli, id string, isExec bool) error {
+	return monitorTtySize(ctx, cli, id, isExec, nil)
+}
+
+// monitorTtySize is MonitorTtySize, also recording the resizes of the
+// terminal if a recorder is given.
+func monitorTtySize(ctx context.Context, cli command.Cli, id string, isExec bool, recorder *sessionRecorder) error {
 	resizeTty := func() {
 		height, width := cli.Out().GetTtySize()
 		resizeTtyTo(ctx, cli.Client(), id, height, width, isExec)
+		if recorder != nil {
+			recorder.resize(height, width)
+		}
 	}
 
 	resizeTty()
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -12,6 +11,8 @@ import (
 )
 
 type unpauseOptions struct {
+	bulk bulkOptions
+
 	containers []string
 }
 
@@ -20,22 +21,27 @@ func NewUnpauseCommand(dockerCli command.Cli) *cobra.Command {
 	var opts unpauseOptions
 
 	cmd := &cobra.Command{
-		Use:   "unpause CONTAINER [CONTAINER...]",
+		Use:   "unpause [OPTIONS] CONTAINER [CONTAINER...]",
 		Short: "Unpause all processes within one or more containers",
-		Args:  cli.RequiresMinArgs(1),
+		Args:  requiresContainers(&opts.bulk),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.containers = args
 			return runUnpause(dockerCli, &opts)
 		},
 	}
+	addBulkFlags(cmd.Flags(), &opts.bulk)
 	return cmd
 }
 
 func runUnpause(dockerCli command.Cli, opts *unpauseOptions) error {
 	ctx := context.Background()
 
+	if opts.bulk.selected() {
+		return runBulkOperation(ctx, dockerCli, &opts.bulk, "unpause", "unpaused", dockerCli.Client().ContainerUnpause)
+	}
+
 	var errs []string
-	errChan := parallelOperation(ctx, opts.containers, dockerCli.Client().ContainerUnpause)
+	errChan := parallelOperation(ctx, opts.containers, opts.bulk.parallel, dockerCli.Client().ContainerUnpause)
 	for _, container := range opts.containers {
 		if err := <-errChan; err != nil {
 			errs = append(errs, err.Error())
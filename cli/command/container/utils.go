@@ -1,7 +1,10 @@
 package container
 
 import (
+	"bytes"
+	"io"
 	"strconv"
+	"sync"
 
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/docker/api/types"
@@ -124,12 +127,18 @@ func legacyWaitExitOrRemoved(ctx context.Context, dockerCli command.Cli, contain
 	return statusChan
 }
 
-func parallelOperation(ctx context.Context, containers []string, op func(ctx context.Context, container string) error) chan error {
+// defaultParallel is the default number of containers operated on
+// concurrently by parallelOperation
+const defaultParallel = 50
+
+func parallelOperation(ctx context.Context, containers []string, parallel int, op func(ctx context.Context, container string) error) chan error {
 	if len(containers) == 0 {
 		return nil
 	}
-	const defaultParallel int = 50
-	sem := make(chan struct{}, defaultParallel)
+	if parallel <= 0 {
+		parallel = defaultParallel
+	}
+	sem := make(chan struct{}, parallel)
 	errChan := make(chan error)
 
 	// make sure result is printed in correct order
@@ -155,3 +164,54 @@ func parallelOperation(ctx context.Context, containers []string, op func(ctx con
 	}()
 	return errChan
 }
+
+// A prefixWriter prefixes each line written to it. Lines are only written
+// once complete, under a lock that can be shared by several prefixWriters so
+// that their lines are not mixed.
+type prefixWriter struct {
+	mu     *sync.Mutex
+	w      io.Writer
+	prefix []byte
+	buf    []byte
+}
+
+func newPrefixWriter(w io.Writer, mu *sync.Mutex, prefix string) *prefixWriter {
+	return &prefixWriter{mu: mu, w: w, prefix: []byte(prefix)}
+}
+
+func (p *prefixWriter) Write(b []byte) (int, error) {
+	p.buf = append(p.buf, b...)
+	i := bytes.LastIndexByte(p.buf, '\n')
+	if i < 0 {
+		return len(b), nil
+	}
+	if err := p.writeLines(p.buf[:i+1]); err != nil {
+		return 0, err
+	}
+	p.buf = append(p.buf[:0], p.buf[i+1:]...)
+	return len(b), nil
+}
+
+// Flush writes the last line, if it is not terminated by a newline.
+func (p *prefixWriter) Flush() error {
+	if len(p.buf) == 0 {
+		return nil
+	}
+	err := p.writeLines(append(p.buf, '\n'))
+	p.buf = p.buf[:0]
+	return err
+}
+
+func (p *prefixWriter) writeLines(lines []byte) error {
+	var out []byte
+	for _, line := range bytes.SplitAfter(lines, []byte("\n")) {
+		if len(line) > 0 {
+			out = append(out, p.prefix...)
+			out = append(out, line...)
+		}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, err := p.w.Write(out)
+	return err
+}
@@ -3,9 +3,14 @@ package container
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"golang.org/x/net/context"
@@ -13,6 +18,9 @@ import (
 
 type waitOptions struct {
 	containers []string
+	condition  string
+	healthy    bool
+	timeout    time.Duration
 }
 
 // NewWaitCommand creates a new cobra.Command for `docker wait`
@@ -20,7 +28,7 @@ func NewWaitCommand(dockerCli command.Cli) *cobra.Command {
 	var opts waitOptions
 
 	cmd := &cobra.Command{
-		Use:   "wait CONTAINER [CONTAINER...]",
+		Use:   "wait [OPTIONS] CONTAINER [CONTAINER...]",
 		Short: "Block until one or more containers stop, then print their exit codes",
 		Args:  cli.RequiresMinArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -29,25 +37,148 @@ func NewWaitCommand(dockerCli command.Cli) *cobra.Command {
 		},
 	}
 
+	flags := cmd.Flags()
+	flags.StringVar(&opts.condition, "condition", "", "Wait until the container is not-running, next-exit, or removed")
+	flags.SetAnnotation("condition", "version", []string{"1.30"})
+	flags.BoolVar(&opts.healthy, "healthy", false, "Wait until the containers are healthy, then print their names")
+	flags.DurationVar(&opts.timeout, "timeout", 0, "Maximum time to wait (0 to wait indefinitely)")
 	return cmd
 }
 
+type waitResult struct {
+	output string
+	err    error
+}
+
 func runWait(dockerCli command.Cli, opts *waitOptions) error {
+	if opts.healthy && opts.condition != "" {
+		return errors.New("conflicting options: --condition and --healthy")
+	}
+	switch container.WaitCondition(opts.condition) {
+	case "", container.WaitConditionNotRunning, container.WaitConditionNextExit, container.WaitConditionRemoved:
+	default:
+		return errors.Errorf("invalid condition %q: must be one of not-running, next-exit, removed", opts.condition)
+	}
+
 	ctx := context.Background()
+	if opts.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.timeout)
+		defer cancel()
+	}
 
-	var errs []string
-	for _, container := range opts.containers {
-		resultC, errC := dockerCli.Client().ContainerWait(ctx, container, "")
+	// Wait on all the containers at once, but print the results in the
+	// order of the arguments.
+	results := make([]chan waitResult, len(opts.containers))
+	for i, ref := range opts.containers {
+		results[i] = make(chan waitResult, 1)
+		go func(ref string, resultC chan<- waitResult) {
+			var result waitResult
+			if opts.healthy {
+				result.output, result.err = waitHealthy(ctx, dockerCli, ref)
+			} else {
+				result.output, result.err = waitExit(ctx, dockerCli, ref, container.WaitCondition(opts.condition))
+			}
+			if result.err != nil && ctx.Err() == context.DeadlineExceeded {
+				result.err = errors.Errorf("timed out waiting for container %s", ref)
+			}
+			resultC <- result
+		}(ref, results[i])
+	}
 
-		select {
-		case result := <-resultC:
-			fmt.Fprintf(dockerCli.Out(), "%d\n", result.StatusCode)
-		case err := <-errC:
-			errs = append(errs, err.Error())
+	var errs []string
+	for _, resultC := range results {
+		result := <-resultC
+		if result.err != nil {
+			errs = append(errs, result.err.Error())
+			continue
 		}
+		fmt.Fprintln(dockerCli.Out(), result.output)
 	}
 	if len(errs) > 0 {
 		return errors.New(strings.Join(errs, "\n"))
 	}
 	return nil
 }
+
+// waitExit waits for the condition on the container, and returns its exit
+// code.
+func waitExit(ctx context.Context, dockerCli command.Cli, ref string, condition container.WaitCondition) (string, error) {
+	resultC, errC := dockerCli.Client().ContainerWait(ctx, ref, condition)
+	select {
+	case result := <-resultC:
+		return fmt.Sprintf("%d", result.StatusCode), nil
+	case err := <-errC:
+		return "", err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// waitHealthy waits for the health check of the container to pass, and
+// returns the name of the container. It fails if the container becomes
+// unhealthy or stops.
+func waitHealthy(ctx context.Context, dockerCli command.Cli, ref string) (string, error) {
+	client := dockerCli.Client()
+
+	// Events are subscribed to before inspecting the container, so that
+	// no change of status is missed in between.
+	since := time.Now()
+	f := filters.NewArgs()
+	f.Add("type", "container")
+	f.Add("container", ref)
+	f.Add("event", "health_status")
+	f.Add("event", "die")
+	f.Add("event", "destroy")
+	eventC, errC := client.Events(ctx, types.EventsOptions{
+		Since:   fmt.Sprintf("%d.%09d", since.Unix(), since.Nanosecond()),
+		Filters: f,
+	})
+
+	c, err := client.ContainerInspect(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+	if c.State == nil || c.State.Health == nil {
+		return "", errors.Errorf("container %s has no health check", ref)
+	}
+	if !c.State.Running {
+		return "", errors.Errorf("container %s is not running", ref)
+	}
+	switch c.State.Health.Status {
+	case types.Healthy:
+		return ref, nil
+	case types.Unhealthy:
+		return "", errors.Errorf("container %s is unhealthy", ref)
+	}
+
+	for {
+		select {
+		case event := <-eventC:
+			if done, err := handleHealthEvent(ref, event); done {
+				if err != nil {
+					return "", err
+				}
+				return ref, nil
+			}
+		case err := <-errC:
+			return "", err
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+}
+
+// handleHealthEvent returns whether waiting for the container to be healthy
+// is over after an event, and if so whether it failed.
+func handleHealthEvent(ref string, event events.Message) (bool, error) {
+	switch event.Action {
+	case "health_status: " + types.Healthy:
+		return true, nil
+	case "health_status: " + types.Unhealthy:
+		return true, errors.Errorf("container %s is unhealthy", ref)
+	case "die", "destroy":
+		return true, errors.Errorf("container %s stopped before becoming healthy", ref)
+	}
+	return false, nil
+}
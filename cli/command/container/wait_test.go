@@ -0,0 +1,145 @@
+package container
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/cli/internal/test/testutil"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func waitFn(statusCodes map[string]int64, delays map[string]time.Duration) func(string, container.WaitCondition) (<-chan container.ContainerWaitOKBody, <-chan error) {
+	return func(ref string, condition container.WaitCondition) (<-chan container.ContainerWaitOKBody, <-chan error) {
+		resultC := make(chan container.ContainerWaitOKBody, 1)
+		errC := make(chan error, 1)
+		statusCode, ok := statusCodes[ref]
+		if !ok {
+			errC <- errors.Errorf("Error: No such container: %s", ref)
+			return resultC, errC
+		}
+		go func() {
+			time.Sleep(delays[ref])
+			resultC <- container.ContainerWaitOKBody{StatusCode: statusCode}
+		}()
+		return resultC, errC
+	}
+}
+
+func TestRunWaitPrintsInArgumentOrder(t *testing.T) {
+	fakeClient := &fakeClient{
+		waitFunc: waitFn(
+			map[string]int64{"slow": 1, "fast": 0},
+			map[string]time.Duration{"slow": 20 * time.Millisecond},
+		),
+	}
+	cli := test.NewFakeCli(fakeClient)
+	err := runWait(cli, &waitOptions{containers: []string{"slow", "missing", "fast"}})
+	testutil.ErrorContains(t, err, "No such container: missing")
+	assert.Equal(t, "1\n0\n", cli.OutBuffer().String())
+}
+
+func TestRunWaitCondition(t *testing.T) {
+	var condition container.WaitCondition
+	fakeClient := &fakeClient{
+		waitFunc: func(ref string, c container.WaitCondition) (<-chan container.ContainerWaitOKBody, <-chan error) {
+			condition = c
+			return waitFn(map[string]int64{ref: 0}, nil)(ref, c)
+		},
+	}
+	err := runWait(test.NewFakeCli(fakeClient), &waitOptions{containers: []string{"web"}, condition: "removed"})
+	require.NoError(t, err)
+	assert.Equal(t, container.WaitConditionRemoved, condition)
+
+	err = runWait(test.NewFakeCli(fakeClient), &waitOptions{containers: []string{"web"}, condition: "stopped"})
+	testutil.ErrorContains(t, err, `invalid condition "stopped"`)
+
+	err = runWait(test.NewFakeCli(fakeClient), &waitOptions{containers: []string{"web"}, condition: "removed", healthy: true})
+	testutil.ErrorContains(t, err, "conflicting options: --condition and --healthy")
+}
+
+func TestRunWaitTimeout(t *testing.T) {
+	fakeClient := &fakeClient{
+		waitFunc: waitFn(
+			map[string]int64{"fast": 0, "slow": 0},
+			map[string]time.Duration{"slow": time.Minute},
+		),
+	}
+	cli := test.NewFakeCli(fakeClient)
+	err := runWait(cli, &waitOptions{containers: []string{"fast", "slow"}, timeout: 10 * time.Millisecond})
+	testutil.ErrorContains(t, err, "timed out waiting for container slow")
+	assert.Equal(t, "0\n", cli.OutBuffer().String())
+}
+
+func TestRunWaitHealthy(t *testing.T) {
+	testcases := []struct {
+		doc           string
+		state         *types.ContainerState
+		events        []string
+		expectedError string
+	}{
+		{
+			doc:    "already healthy",
+			state:  &types.ContainerState{Running: true, Health: &types.Health{Status: types.Healthy}},
+			events: nil,
+		},
+		{
+			doc:    "becomes healthy",
+			state:  &types.ContainerState{Running: true, Health: &types.Health{Status: types.Starting}},
+			events: []string{"health_status: healthy"},
+		},
+		{
+			doc:           "becomes unhealthy",
+			state:         &types.ContainerState{Running: true, Health: &types.Health{Status: types.Starting}},
+			events:        []string{"health_status: unhealthy"},
+			expectedError: "container web is unhealthy",
+		},
+		{
+			doc:           "stops",
+			state:         &types.ContainerState{Running: true, Health: &types.Health{Status: types.Starting}},
+			events:        []string{"die"},
+			expectedError: "container web stopped before becoming healthy",
+		},
+		{
+			doc:           "not running",
+			state:         &types.ContainerState{Health: &types.Health{Status: types.Unhealthy}},
+			expectedError: "container web is not running",
+		},
+		{
+			doc:           "no health check",
+			state:         &types.ContainerState{Running: true},
+			expectedError: "container web has no health check",
+		},
+	}
+
+	for _, tc := range testcases {
+		var options types.EventsOptions
+		fakeClient := &fakeClient{
+			inspectFunc: func(string) (types.ContainerJSON, error) {
+				return types.ContainerJSON{ContainerJSONBase: &types.ContainerJSONBase{State: tc.state}}, nil
+			},
+			eventsFunc: func(o types.EventsOptions) (<-chan events.Message, <-chan error) {
+				options = o
+				eventC := make(chan events.Message, len(tc.events))
+				for _, action := range tc.events {
+					eventC <- events.Message{Type: "container", Action: action}
+				}
+				return eventC, make(chan error)
+			},
+		}
+		cli := test.NewFakeCli(fakeClient)
+		err := runWait(cli, &waitOptions{containers: []string{"web"}, healthy: true})
+		if tc.expectedError != "" {
+			testutil.ErrorContains(t, err, tc.expectedError)
+			continue
+		}
+		require.NoError(t, err, tc.doc)
+		assert.Equal(t, "web\n", cli.OutBuffer().String(), tc.doc)
+		assert.Equal(t, []string{"web"}, options.Filters.Get("container"), tc.doc)
+	}
+}
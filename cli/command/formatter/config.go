@@ -1,6 +1,7 @@
 package formatter
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -16,6 +17,7 @@ const (
 	configIDHeader                     = "ID"
 	configCreatedHeader                = "CREATED"
 	configUpdatedHeader                = "UPDATED"
+	configServicesHeader               = "SERVICES"
 	configInspectPrettyTemplate Format = `ID:			{{.ID}}
 Name:			{{.Name}}
 {{- if .Labels }}
@@ -43,11 +45,13 @@ func NewConfigFormat(source string, quiet bool) Format {
 	return Format(source)
 }
 
-// ConfigWrite writes the context
-func ConfigWrite(ctx Context, configs []swarm.Config) error {
+// ConfigWrite writes the context. services maps config IDs to the services
+// using them, it can be nil if the Services column is not used, in which
+// case the services are left out of the JSON representation.
+func ConfigWrite(ctx Context, configs []swarm.Config, services map[string][]ServiceReference) error {
 	render := func(format func(subContext subContext) error) error {
 		for _, config := range configs {
-			configCtx := &configContext{c: config}
+			configCtx := &configContext{c: config, services: services[config.ID], hasServices: services != nil}
 			if err := format(configCtx); err != nil {
 				return err
 			}
@@ -66,17 +70,28 @@ func newConfigContext() *configContext {
 		"CreatedAt": configCreatedHeader,
 		"UpdatedAt": configUpdatedHeader,
 		"Labels":    labelsHeader,
+		"Services":  configServicesHeader,
 	}
 	return cCtx
 }
 
 type configContext struct {
 	HeaderContext
-	c swarm.Config
+	c        swarm.Config
+	services []ServiceReference
+	// hasServices is false if the services using the config are unknown
+	hasServices bool
 }
 
 func (c *configContext) MarshalJSON() ([]byte, error) {
-	return marshalJSON(c)
+	m, err := marshalMap(c)
+	if err != nil {
+		return nil, err
+	}
+	if !c.hasServices {
+		delete(m, "Services")
+	}
+	return json.Marshal(m)
 }
 
 func (c *configContext) ID() string {
@@ -114,6 +129,10 @@ func (c *configContext) Label(name string) string {
 	return c.c.Spec.Annotations.Labels[name]
 }
 
+func (c *configContext) Services() string {
+	return joinServiceReferences(c.services)
+}
+
 // ConfigInspectWrite renders the context for a list of configs
 func ConfigInspectWrite(ctx Context, refs []string, getRef inspect.GetRefFunc) error {
 	if ctx.Format != configInspectPrettyTemplate {
@@ -40,6 +40,10 @@ id_rsa
 		{Context{Format: NewConfigFormat("{{.ID}}-{{.Name}}", false)},
 			`1-passwords
 2-id_rsa
+`},
+		{Context{Format: NewConfigFormat("{{.Name}}={{.Services}}", false)},
+			`passwords=web:/etc/passwords
+id_rsa=
 `},
 	}
 
@@ -51,10 +55,13 @@ id_rsa
 			Meta: swarm.Meta{CreatedAt: time.Now(), UpdatedAt: time.Now()},
 			Spec: swarm.ConfigSpec{Annotations: swarm.Annotations{Name: "id_rsa"}}},
 	}
+	services := map[string][]ServiceReference{
+		"1": {{Service: "web", Target: "/etc/passwords"}},
+	}
 	for _, testcase := range cases {
 		out := bytes.NewBufferString("")
 		testcase.context.Output = out
-		if err := ConfigWrite(testcase.context, configs); err != nil {
+		if err := ConfigWrite(testcase.context, configs, services); err != nil {
 			assert.Error(t, err, testcase.expected)
 		} else {
 			assert.Equal(t, out.String(), testcase.expected)
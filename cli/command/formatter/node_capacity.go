@@ -0,0 +1,192 @@
+package formatter
+
+import (
+	"fmt"
+
+	"github.com/docker/cli/cli/command/placement"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
+	units "github.com/docker/go-units"
+)
+
+const (
+	defaultNodeResourcesTableFormat       = "table {{.ID}} {{if .Self}}*{{else}} {{ end }}\t{{.Hostname}}\t{{.Status}}\t{{.Availability}}\t{{.Tasks}}\t{{.CPU}}\t{{.Memory}}"
+	defaultNodeCapacityTableFormat        = "table {{.ID}}\t{{.Hostname}}\t{{.Tasks}}\t{{.CPUReserved}}\t{{.CPULimit}}\t{{.CPUFree}}\t{{.MemoryReserved}}\t{{.MemoryLimit}}\t{{.MemoryFree}}\t{{.GenericFree}}"
+	defaultNodeCapacityServiceTableFormat = "table {{.ID}}\t{{.Hostname}}\t{{.CPUFree}}\t{{.MemoryFree}}\t{{.GenericFree}}\t{{.Eligible}}\t{{.Reason}}"
+
+	capacityTasksHeader          = "TASKS"
+	capacityCPUHeader            = "CPU"
+	capacityMemoryHeader         = "MEMORY"
+	capacityCPUReservedHeader    = "CPU RESERVED"
+	capacityCPULimitHeader       = "CPU LIMIT"
+	capacityCPUFreeHeader        = "CPU FREE"
+	capacityMemoryReservedHeader = "MEMORY RESERVED"
+	capacityMemoryLimitHeader    = "MEMORY LIMIT"
+	capacityMemoryFreeHeader     = "MEMORY FREE"
+	capacityGenericFreeHeader    = "GENERIC FREE"
+	capacityEligibleHeader       = "ELIGIBLE"
+	capacityReasonHeader         = "REASON"
+)
+
+// NodeCapacity is the resource usage of a node, and optionally whether a task
+// of a given service could be scheduled on it.
+type NodeCapacity struct {
+	Node  swarm.Node
+	Usage placement.Usage
+	// Placement is the reason why a task can't be scheduled on the node, if
+	// Checked is set.
+	Placement error
+	Checked   bool
+}
+
+// NewNodeResourcesFormat returns a Format for rendering nodes along with
+// their resource usage, as done by `docker node ls --resources`.
+func NewNodeResourcesFormat(source string) Format {
+	switch source {
+	case TableFormatKey:
+		return defaultNodeResourcesTableFormat
+	case JSONFormatKey:
+		return "{{json .}}"
+	}
+	return Format(source)
+}
+
+// NewNodeCapacityFormat returns a Format for rendering using a
+// nodeCapacityContext. If checked is set, the default table shows whether
+// the nodes can run a task of a service.
+func NewNodeCapacityFormat(source string, checked bool) Format {
+	switch source {
+	case TableFormatKey:
+		if checked {
+			return defaultNodeCapacityServiceTableFormat
+		}
+		return defaultNodeCapacityTableFormat
+	case JSONFormatKey:
+		return "{{json .}}"
+	}
+	return Format(source)
+}
+
+// NodeCapacityWrite writes the resource usage of nodes
+func NodeCapacityWrite(ctx Context, capacities []NodeCapacity, info types.Info) error {
+	render := func(format func(subContext subContext) error) error {
+		for _, c := range capacities {
+			if err := format(newNodeCapacityContext(c, info)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	capacityCtx := &nodeCapacityContext{}
+	capacityCtx.header = map[string]string{
+		"ID":             nodeIDHeader,
+		"Self":           selfHeader,
+		"Hostname":       hostnameHeader,
+		"Status":         statusHeader,
+		"Availability":   availabilityHeader,
+		"ManagerStatus":  managerStatusHeader,
+		"TLSStatus":      tlsStatusHeader,
+		"Tasks":          capacityTasksHeader,
+		"CPU":            capacityCPUHeader,
+		"Memory":         capacityMemoryHeader,
+		"CPUReserved":    capacityCPUReservedHeader,
+		"CPULimit":       capacityCPULimitHeader,
+		"CPUFree":        capacityCPUFreeHeader,
+		"MemoryReserved": capacityMemoryReservedHeader,
+		"MemoryLimit":    capacityMemoryLimitHeader,
+		"MemoryFree":     capacityMemoryFreeHeader,
+		"GenericFree":    capacityGenericFreeHeader,
+		"Eligible":       capacityEligibleHeader,
+		"Reason":         capacityReasonHeader,
+	}
+	return ctx.Write(capacityCtx, render)
+}
+
+type nodeCapacityContext struct {
+	HeaderContext
+	nodeContext
+	c         NodeCapacity
+	total     placement.Resources
+	available placement.Resources
+}
+
+func newNodeCapacityContext(c NodeCapacity, info types.Info) *nodeCapacityContext {
+	return &nodeCapacityContext{
+		nodeContext: nodeContext{n: c.Node, info: info},
+		c:           c,
+		total:       placement.NewResources(&c.Node.Description.Resources),
+		available:   placement.Available(c.Node, &c.Usage),
+	}
+}
+
+func (c *nodeCapacityContext) MarshalJSON() ([]byte, error) {
+	return marshalJSON(c)
+}
+
+func (c *nodeCapacityContext) Tasks() int {
+	return c.c.Usage.Tasks
+}
+
+// CPU returns the reserved and total CPUs of the node.
+func (c *nodeCapacityContext) CPU() string {
+	return fmt.Sprintf("%s / %s", placement.FormatCPUs(c.c.Usage.Reservations.NanoCPUs), placement.FormatCPUs(c.total.NanoCPUs))
+}
+
+// Memory returns the reserved and total memory of the node.
+func (c *nodeCapacityContext) Memory() string {
+	return fmt.Sprintf("%s / %s", formatBytes(c.c.Usage.Reservations.MemoryBytes), formatBytes(c.total.MemoryBytes))
+}
+
+func (c *nodeCapacityContext) CPUReserved() string {
+	return placement.FormatCPUs(c.c.Usage.Reservations.NanoCPUs)
+}
+
+func (c *nodeCapacityContext) CPULimit() string {
+	return placement.FormatCPUs(c.c.Usage.Limits.NanoCPUs)
+}
+
+func (c *nodeCapacityContext) CPUFree() string {
+	return placement.FormatCPUs(c.available.NanoCPUs)
+}
+
+func (c *nodeCapacityContext) MemoryReserved() string {
+	return formatBytes(c.c.Usage.Reservations.MemoryBytes)
+}
+
+func (c *nodeCapacityContext) MemoryLimit() string {
+	return formatBytes(c.c.Usage.Limits.MemoryBytes)
+}
+
+func (c *nodeCapacityContext) MemoryFree() string {
+	return formatBytes(c.available.MemoryBytes)
+}
+
+// GenericFree returns the generic resources of the node that are not
+// reserved by tasks.
+func (c *nodeCapacityContext) GenericFree() string {
+	return placement.FormatGeneric(c.available.Generic)
+}
+
+func (c *nodeCapacityContext) Eligible() string {
+	if !c.c.Checked {
+		return ""
+	}
+	if c.c.Placement != nil {
+		return "no"
+	}
+	return "yes"
+}
+
+func (c *nodeCapacityContext) Reason() string {
+	if c.c.Placement == nil {
+		return ""
+	}
+	return c.c.Placement.Error()
+}
+
+func formatBytes(size int64) string {
+	if size < 0 {
+		return "-" + units.BytesSize(float64(-size))
+	}
+	return units.BytesSize(float64(size))
+}
@@ -0,0 +1,59 @@
+package formatter
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/docker/cli/cli/command/placement"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeCapacityWrite(t *testing.T) {
+	node := swarm.Node{
+		ID: "nodeID1",
+		Description: swarm.NodeDescription{
+			Hostname:  "node-1",
+			Resources: swarm.Resources{NanoCPUs: 2e9, MemoryBytes: 2 << 30},
+		},
+	}
+	capacities := []NodeCapacity{
+		{
+			Node: node,
+			Usage: placement.Usage{
+				Tasks:        1,
+				Reservations: placement.Resources{NanoCPUs: 25e7, MemoryBytes: 512 << 20},
+				Limits:       placement.Resources{NanoCPUs: 5e8},
+			},
+		},
+		{
+			Node:      node,
+			Checked:   true,
+			Placement: errors.New("node is down"),
+		},
+	}
+
+	cases := []struct {
+		context  Context
+		expected string
+	}{
+		{
+			Context{Format: NewNodeResourcesFormat("{{.ID}} {{.Tasks}} {{.CPU}} {{.Memory}}")},
+			"nodeID1 1 0.25 / 2 512MiB / 2GiB\nnodeID1 0 0 / 2 0B / 2GiB\n",
+		},
+		{
+			Context{Format: NewNodeCapacityFormat("{{.Hostname}} {{.CPUFree}} {{.CPULimit}} {{.MemoryFree}} {{.Eligible}} {{.Reason}}", true)},
+			"node-1 1.75 0.5 1.5GiB  \nnode-1 2 0 2GiB no node is down\n",
+		},
+	}
+
+	for _, testcase := range cases {
+		out := bytes.NewBufferString("")
+		testcase.context.Output = out
+		err := NodeCapacityWrite(testcase.context, capacities, types.Info{})
+		assert.NoError(t, err)
+		assert.Equal(t, testcase.expected, out.String())
+	}
+}
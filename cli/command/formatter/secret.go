@@ -1,6 +1,7 @@
 package formatter
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -16,6 +17,7 @@ const (
 	secretIDHeader                     = "ID"
 	secretCreatedHeader                = "CREATED"
 	secretUpdatedHeader                = "UPDATED"
+	secretServicesHeader               = "SERVICES"
 	secretInspectPrettyTemplate Format = `ID:              {{.ID}}
 Name:              {{.Name}}
 {{- if .Labels }}
@@ -42,11 +44,20 @@ func NewSecretFormat(source string, quiet bool) Format {
 	return Format(source)
 }
 
-// SecretWrite writes the context
-func SecretWrite(ctx Context, secrets []swarm.Secret) error {
+// ServiceReference is a reference from a service to a secret or config,
+// Target is the file name the object is exposed as inside the containers.
+type ServiceReference struct {
+	Service string
+	Target  string
+}
+
+// SecretWrite writes the context. services maps secret IDs to the services
+// using them, it can be nil if the Services column is not used, in which
+// case the services are left out of the JSON representation.
+func SecretWrite(ctx Context, secrets []swarm.Secret, services map[string][]ServiceReference) error {
 	render := func(format func(subContext subContext) error) error {
 		for _, secret := range secrets {
-			secretCtx := &secretContext{s: secret}
+			secretCtx := &secretContext{s: secret, services: services[secret.ID], hasServices: services != nil}
 			if err := format(secretCtx); err != nil {
 				return err
 			}
@@ -66,17 +77,28 @@ func newSecretContext() *secretContext {
 		"CreatedAt": secretCreatedHeader,
 		"UpdatedAt": secretUpdatedHeader,
 		"Labels":    labelsHeader,
+		"Services":  secretServicesHeader,
 	}
 	return sCtx
 }
 
 type secretContext struct {
 	HeaderContext
-	s swarm.Secret
+	s        swarm.Secret
+	services []ServiceReference
+	// hasServices is false if the services using the secret are unknown
+	hasServices bool
 }
 
 func (c *secretContext) MarshalJSON() ([]byte, error) {
-	return marshalJSON(c)
+	m, err := marshalMap(c)
+	if err != nil {
+		return nil, err
+	}
+	if !c.hasServices {
+		delete(m, "Services")
+	}
+	return json.Marshal(m)
 }
 
 func (c *secretContext) ID() string {
@@ -121,6 +143,18 @@ func (c *secretContext) Label(name string) string {
 	return c.s.Spec.Annotations.Labels[name]
 }
 
+func (c *secretContext) Services() string {
+	return joinServiceReferences(c.services)
+}
+
+func joinServiceReferences(refs []ServiceReference) string {
+	joined := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		joined = append(joined, ref.Service+":"+ref.Target)
+	}
+	return strings.Join(joined, ", ")
+}
+
 // SecretInspectWrite renders the context for a list of secrets
 func SecretInspectWrite(ctx Context, refs []string, getRef inspect.GetRefFunc) error {
 	if ctx.Format != secretInspectPrettyTemplate {
@@ -40,6 +40,11 @@ id_rsa
 		{Context{Format: NewSecretFormat("{{.ID}}-{{.Name}}", false)},
 			`1-passwords
 2-id_rsa
+`},
+		{Context{Format: NewSecretFormat("table {{.Name}}\t{{.Services}}", false)},
+			`NAME                SERVICES
+passwords           api:db_password, web:passwords
+id_rsa              
 `},
 	}
 
@@ -51,13 +56,25 @@ id_rsa
 			Meta: swarm.Meta{CreatedAt: time.Now(), UpdatedAt: time.Now()},
 			Spec: swarm.SecretSpec{Annotations: swarm.Annotations{Name: "id_rsa"}}},
 	}
+	services := map[string][]ServiceReference{
+		"1": {{Service: "api", Target: "db_password"}, {Service: "web", Target: "passwords"}},
+	}
 	for _, testcase := range cases {
 		out := bytes.NewBufferString("")
 		testcase.context.Output = out
-		if err := SecretWrite(testcase.context, secrets); err != nil {
+		if err := SecretWrite(testcase.context, secrets, services); err != nil {
 			assert.EqualError(t, err, testcase.expected)
 		} else {
 			assert.Equal(t, testcase.expected, out.String())
 		}
 	}
 }
+
+func TestSecretContextWriteJSONWithoutServices(t *testing.T) {
+	secrets := []swarm.Secret{{ID: "1", Spec: swarm.SecretSpec{Annotations: swarm.Annotations{Name: "passwords"}}}}
+	out := bytes.NewBufferString("")
+	err := SecretWrite(Context{Format: "{{json .}}", Output: out}, secrets, nil)
+	assert.NoError(t, err)
+	assert.NotContains(t, out.String(), `"Services"`)
+	assert.Contains(t, out.String(), `"Name":"passwords"`)
+}
@@ -0,0 +1,139 @@
+package formatter
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/docker/docker/pkg/stringid"
+)
+
+const (
+	defaultServiceHistoryTableFormat = "table {{.Time}}\t{{.Type}}\t{{.Slot}}\t{{.Node}}\t{{.Task}}\t{{.State}}\t{{.ExitCode}}\t{{.Message}}"
+
+	historyTimeHeader     = "TIME"
+	historyTypeHeader     = "TYPE"
+	historySlotHeader     = "SLOT"
+	historyTaskHeader     = "TASK"
+	historyStateHeader    = "STATE"
+	historyExitCodeHeader = "EXIT CODE"
+	historyMessageHeader  = "MESSAGE"
+)
+
+// ServiceHistoryEntry is an entry of the timeline of a service.
+type ServiceHistoryEntry struct {
+	Time time.Time
+	// Type is the kind of entry: "service", "spec", "update", "task" or
+	// "event".
+	Type   string
+	Slot   int
+	Node   string
+	TaskID string
+	State  string
+	// ExitCode is the exit code of the container of a task, if it exited.
+	ExitCode *int
+	Message  string
+}
+
+// NewServiceHistoryFormat returns a Format for rendering using a
+// serviceHistoryContext
+func NewServiceHistoryFormat(source string) Format {
+	switch source {
+	case TableFormatKey:
+		return defaultServiceHistoryTableFormat
+	case JSONFormatKey:
+		return "{{json .}}"
+	}
+	return Format(source)
+}
+
+// ServiceHistoryWrite writes the timeline of a service
+func ServiceHistoryWrite(ctx Context, entries []ServiceHistoryEntry) error {
+	render := func(format func(subContext subContext) error) error {
+		for _, entry := range entries {
+			if err := format(&serviceHistoryContext{trunc: ctx.Trunc, e: entry}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	historyCtx := &serviceHistoryContext{}
+	historyCtx.header = map[string]string{
+		"Time":     historyTimeHeader,
+		"Type":     historyTypeHeader,
+		"Slot":     historySlotHeader,
+		"Node":     nodeHeader,
+		"Task":     historyTaskHeader,
+		"State":    historyStateHeader,
+		"ExitCode": historyExitCodeHeader,
+		"Message":  historyMessageHeader,
+	}
+	return ctx.Write(historyCtx, render)
+}
+
+type serviceHistoryContext struct {
+	HeaderContext
+	trunc bool
+	e     ServiceHistoryEntry
+}
+
+func (c *serviceHistoryContext) MarshalJSON() ([]byte, error) {
+	m, err := marshalMap(c)
+	if err != nil {
+		return nil, err
+	}
+	// Slot and ExitCode are numbers, which are only set for some entries
+	delete(m, "Slot")
+	if c.e.Slot != 0 {
+		m["Slot"] = c.e.Slot
+	}
+	delete(m, "ExitCode")
+	if c.e.ExitCode != nil {
+		m["ExitCode"] = *c.e.ExitCode
+	}
+	return json.Marshal(m)
+}
+
+func (c *serviceHistoryContext) Time() string {
+	return c.e.Time.UTC().Format(time.RFC3339Nano)
+}
+
+func (c *serviceHistoryContext) Type() string {
+	return c.e.Type
+}
+
+func (c *serviceHistoryContext) Slot() string {
+	if c.e.Slot == 0 {
+		return ""
+	}
+	return strconv.Itoa(c.e.Slot)
+}
+
+func (c *serviceHistoryContext) Node() string {
+	return c.e.Node
+}
+
+func (c *serviceHistoryContext) Task() string {
+	if c.trunc {
+		return stringid.TruncateID(c.e.TaskID)
+	}
+	return c.e.TaskID
+}
+
+func (c *serviceHistoryContext) State() string {
+	return c.e.State
+}
+
+func (c *serviceHistoryContext) ExitCode() string {
+	if c.e.ExitCode == nil {
+		return ""
+	}
+	return strconv.Itoa(*c.e.ExitCode)
+}
+
+func (c *serviceHistoryContext) Message() string {
+	if c.trunc {
+		return Ellipsis(c.e.Message, 60)
+	}
+	return c.e.Message
+}
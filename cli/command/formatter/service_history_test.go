@@ -0,0 +1,54 @@
+package formatter
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceHistoryWrite(t *testing.T) {
+	exitCode := 137
+	entries := []ServiceHistoryEntry{
+		{
+			Time:  time.Date(2017, time.December, 1, 10, 0, 0, 0, time.UTC),
+			Type:  "service",
+			State: "created",
+		},
+		{
+			Time:     time.Date(2017, time.December, 1, 10, 5, 0, 0, time.UTC),
+			Type:     "task",
+			Slot:     2,
+			Node:     "worker1",
+			TaskID:   "yd8b9qrkoyyanm7i4v0vbmxm2",
+			State:    "failed",
+			ExitCode: &exitCode,
+			Message:  "task: non-zero exit (137)",
+		},
+	}
+
+	cases := []struct {
+		context  Context
+		expected string
+	}{
+		{
+			Context{Format: NewServiceHistoryFormat("{{.Time}} {{.Type}} {{.Slot}} {{.Task}} {{.ExitCode}}"), Trunc: true},
+			"2017-12-01T10:00:00Z service   \n2017-12-01T10:05:00Z task 2 yd8b9qrkoyya 137\n",
+		},
+		{
+			Context{Format: NewServiceHistoryFormat(JSONFormatKey)},
+			`{"Message":"","Node":"","State":"created","Task":"","Time":"2017-12-01T10:00:00Z","Type":"service"}
+{"ExitCode":137,"Message":"task: non-zero exit (137)","Node":"worker1","Slot":2,"State":"failed","Task":"yd8b9qrkoyyanm7i4v0vbmxm2","Time":"2017-12-01T10:05:00Z","Type":"task"}
+`,
+		},
+	}
+
+	for _, testcase := range cases {
+		out := bytes.NewBufferString("")
+		testcase.context.Output = out
+		err := ServiceHistoryWrite(testcase.context, entries)
+		assert.NoError(t, err)
+		assert.Equal(t, testcase.expected, out.String())
+	}
+}
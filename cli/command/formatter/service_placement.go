@@ -0,0 +1,93 @@
+package formatter
+
+import (
+	"strings"
+
+	"github.com/docker/docker/api/types/swarm"
+)
+
+const (
+	defaultServicePlacementTableFormat = "table {{.ID}}\t{{.Hostname}}\t{{.Result}}\t{{.Failing}}\t{{.Spread}}"
+
+	placementResultHeader  = "RESULT"
+	placementFailingHeader = "FAILING EXPRESSION"
+	placementSpreadHeader  = "SPREAD"
+)
+
+// ServicePlacement is the result of evaluating the placement of a service on
+// a node.
+type ServicePlacement struct {
+	Node swarm.Node
+	// Failing is the reason the node is excluded, as reported by
+	// placement.Check, or empty if the tasks of the service can be placed on
+	// the node.
+	Failing string
+	// Spread holds the group of the node for each placement preference of
+	// the service, as descriptor=value.
+	Spread []string
+}
+
+// NewServicePlacementFormat returns a Format for rendering using a
+// servicePlacementContext
+func NewServicePlacementFormat(source string) Format {
+	switch source {
+	case TableFormatKey:
+		return defaultServicePlacementTableFormat
+	case JSONFormatKey:
+		return "{{json .}}"
+	}
+	return Format(source)
+}
+
+// ServicePlacementWrite writes the placement of a service on nodes
+func ServicePlacementWrite(ctx Context, placements []ServicePlacement) error {
+	render := func(format func(subContext subContext) error) error {
+		for _, p := range placements {
+			if err := format(&servicePlacementContext{p: p}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	placementCtx := &servicePlacementContext{}
+	placementCtx.header = map[string]string{
+		"ID":       nodeIDHeader,
+		"Hostname": hostnameHeader,
+		"Result":   placementResultHeader,
+		"Failing":  placementFailingHeader,
+		"Spread":   placementSpreadHeader,
+	}
+	return ctx.Write(placementCtx, render)
+}
+
+type servicePlacementContext struct {
+	HeaderContext
+	p ServicePlacement
+}
+
+func (c *servicePlacementContext) MarshalJSON() ([]byte, error) {
+	return marshalJSON(c)
+}
+
+func (c *servicePlacementContext) ID() string {
+	return c.p.Node.ID
+}
+
+func (c *servicePlacementContext) Hostname() string {
+	return c.p.Node.Description.Hostname
+}
+
+func (c *servicePlacementContext) Result() string {
+	if c.p.Failing != "" {
+		return "fail"
+	}
+	return "pass"
+}
+
+func (c *servicePlacementContext) Failing() string {
+	return c.p.Failing
+}
+
+func (c *servicePlacementContext) Spread() string {
+	return strings.Join(c.p.Spread, ", ")
+}
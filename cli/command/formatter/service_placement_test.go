@@ -0,0 +1,46 @@
+package formatter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServicePlacementWrite(t *testing.T) {
+	placements := []ServicePlacement{
+		{
+			Node:    swarm.Node{ID: "nodeID1", Description: swarm.NodeDescription{Hostname: "node-1"}},
+			Failing: "node.role==worker",
+		},
+		{
+			Node:   swarm.Node{ID: "nodeID2", Description: swarm.NodeDescription{Hostname: "node-2"}},
+			Spread: []string{"node.labels.az=east", "node.labels.rack=r1"},
+		},
+	}
+
+	cases := []struct {
+		context  Context
+		expected string
+	}{
+		{
+			Context{Format: NewServicePlacementFormat("{{.Hostname}}: {{.Result}} {{.Failing}}{{.Spread}}")},
+			"node-1: fail node.role==worker\nnode-2: pass node.labels.az=east, node.labels.rack=r1\n",
+		},
+		{
+			Context{Format: NewServicePlacementFormat(JSONFormatKey)},
+			`{"Failing":"node.role==worker","Hostname":"node-1","ID":"nodeID1","Result":"fail","Spread":""}
+{"Failing":"","Hostname":"node-2","ID":"nodeID2","Result":"pass","Spread":"node.labels.az=east, node.labels.rack=r1"}
+`,
+		},
+	}
+
+	for _, testcase := range cases {
+		out := bytes.NewBufferString("")
+		testcase.context.Output = out
+		err := ServicePlacementWrite(testcase.context, placements)
+		assert.NoError(t, err)
+		assert.Equal(t, testcase.expected, out.String())
+	}
+}
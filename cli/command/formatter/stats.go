@@ -1,8 +1,14 @@
 package formatter
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"io"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/docker/docker/pkg/stringid"
 	units "github.com/docker/go-units"
@@ -21,6 +27,9 @@ const (
 	winMemUseHeader = "PRIV WORKING SET"  // Used only on Windows
 	memUseHeader    = "MEM USAGE / LIMIT" // Used only on Linux
 	pidsHeader      = "PIDS"              // Used only on Linux
+
+	// StatsCSVFormatKey is the format writing the statistics as CSV
+	StatsCSVFormatKey = "csv"
 )
 
 // StatsEntry represents represents the statistics data collected from a container
@@ -222,3 +231,148 @@ func (c *containerStatsContext) PIDs() string {
 	}
 	return fmt.Sprintf("%d", c.s.PidsCurrent)
 }
+
+// statsSample is a sample of the statistics of a container, as written by
+// ContainerStatsJSONWrite and ContainerStatsCSVWrite.
+type statsSample struct {
+	Time             time.Time
+	Container        string
+	Name             string
+	ID               string
+	CPUPercentage    float64
+	Memory           float64
+	MemoryLimit      float64
+	MemoryPercentage float64
+	NetworkRx        float64
+	NetworkTx        float64
+	BlockRead        float64
+	BlockWrite       float64
+	PidsCurrent      uint64
+}
+
+var statsCSVHeader = []string{
+	"Time", "Container", "Name", "ID", "CPUPercentage", "Memory", "MemoryLimit", "MemoryPercentage",
+	"NetworkRx", "NetworkTx", "BlockRead", "BlockWrite", "PidsCurrent",
+}
+
+func newStatsSample(t time.Time, s StatsEntry) statsSample {
+	return statsSample{
+		Time:             t,
+		Container:        s.Container,
+		Name:             strings.TrimPrefix(s.Name, "/"),
+		ID:               s.ID,
+		CPUPercentage:    s.CPUPercentage,
+		Memory:           s.Memory,
+		MemoryLimit:      s.MemoryLimit,
+		MemoryPercentage: s.MemoryPercentage,
+		NetworkRx:        s.NetworkRx,
+		NetworkTx:        s.NetworkTx,
+		BlockRead:        s.BlockRead,
+		BlockWrite:       s.BlockWrite,
+		PidsCurrent:      s.PidsCurrent,
+	}
+}
+
+// ContainerStatsJSONWrite writes the statistics of the containers taken at
+// time t as JSON, one line per container. Invalid statistics are skipped.
+func ContainerStatsJSONWrite(w io.Writer, t time.Time, containerStats []StatsEntry) error {
+	enc := json.NewEncoder(w)
+	for _, s := range containerStats {
+		if s.IsInvalid {
+			continue
+		}
+		if err := enc.Encode(newStatsSample(t, s)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ContainerStatsCSVWrite writes the statistics of the containers taken at
+// time t as CSV, one line per container, preceded by a header if header is
+// set. Invalid statistics are skipped.
+func ContainerStatsCSVWrite(w io.Writer, t time.Time, containerStats []StatsEntry, header bool) error {
+	cw := csv.NewWriter(w)
+	if header {
+		cw.Write(statsCSVHeader)
+	}
+	formatFloat := func(f float64, prec int) string {
+		return strconv.FormatFloat(f, 'f', prec, 64)
+	}
+	for _, s := range containerStats {
+		if s.IsInvalid {
+			continue
+		}
+		sample := newStatsSample(t, s)
+		cw.Write([]string{
+			sample.Time.Format(time.RFC3339Nano),
+			sample.Container,
+			sample.Name,
+			sample.ID,
+			formatFloat(sample.CPUPercentage, 2),
+			formatFloat(sample.Memory, 0),
+			formatFloat(sample.MemoryLimit, 0),
+			formatFloat(sample.MemoryPercentage, 2),
+			formatFloat(sample.NetworkRx, 0),
+			formatFloat(sample.NetworkTx, 0),
+			formatFloat(sample.BlockRead, 0),
+			formatFloat(sample.BlockWrite, 0),
+			strconv.FormatUint(sample.PidsCurrent, 10),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// statsMetric is a metric of the Prometheus exposition of the statistics.
+type statsMetric struct {
+	name  string
+	help  string
+	kind  string
+	value func(StatsEntry) float64
+	// linuxOnly is set for the statistics not collected on Windows
+	linuxOnly bool
+}
+
+var statsMetrics = []statsMetric{
+	{"docker_container_cpu_percent", "CPU usage of the container, in percent of one CPU.", "gauge", func(s StatsEntry) float64 { return s.CPUPercentage }, false},
+	{"docker_container_memory_usage_bytes", "Memory usage of the container, or private working set on Windows.", "gauge", func(s StatsEntry) float64 { return s.Memory }, false},
+	{"docker_container_memory_limit_bytes", "Memory limit of the container.", "gauge", func(s StatsEntry) float64 { return s.MemoryLimit }, true},
+	{"docker_container_memory_percent", "Memory usage of the container, in percent of its limit.", "gauge", func(s StatsEntry) float64 { return s.MemoryPercentage }, true},
+	{"docker_container_network_receive_bytes_total", "Bytes received by the container over the network.", "counter", func(s StatsEntry) float64 { return s.NetworkRx }, false},
+	{"docker_container_network_transmit_bytes_total", "Bytes sent by the container over the network.", "counter", func(s StatsEntry) float64 { return s.NetworkTx }, false},
+	{"docker_container_block_read_bytes_total", "Bytes read by the container from block devices.", "counter", func(s StatsEntry) float64 { return s.BlockRead }, false},
+	{"docker_container_block_write_bytes_total", "Bytes written by the container to block devices.", "counter", func(s StatsEntry) float64 { return s.BlockWrite }, false},
+	{"docker_container_pids", "Number of processes of the container.", "gauge", func(s StatsEntry) float64 { return float64(s.PidsCurrent) }, true},
+}
+
+var prometheusLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// ContainerStatsPrometheusWrite writes the statistics of the containers in
+// the Prometheus text exposition format. Invalid statistics are skipped.
+func ContainerStatsPrometheusWrite(w io.Writer, containerStats []StatsEntry, osType string) error {
+	var valid []StatsEntry
+	for _, s := range containerStats {
+		if !s.IsInvalid {
+			valid = append(valid, s)
+		}
+	}
+	for _, m := range statsMetrics {
+		if m.linuxOnly && osType == winOSType {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", m.name, m.help, m.name, m.kind); err != nil {
+			return err
+		}
+		for _, s := range valid {
+			_, err := fmt.Fprintf(w, "%s{id=\"%s\",name=\"%s\"} %s\n", m.name,
+				prometheusLabelEscaper.Replace(s.ID),
+				prometheusLabelEscaper.Replace(strings.TrimPrefix(s.Name, "/")),
+				strconv.FormatFloat(m.value(s), 'g', -1, 64))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package formatter
 import (
 	"bytes"
 	"testing"
+	"time"
 
 	"github.com/docker/docker/pkg/stringid"
 	"github.com/stretchr/testify/assert"
@@ -298,3 +299,92 @@ func TestContainerStatsContextWriteTrunc(t *testing.T) {
 		out.Reset()
 	}
 }
+
+var exportStats = []StatsEntry{
+	{
+		Container:        "web",
+		Name:             "/web",
+		ID:               "0123456789ab",
+		CPUPercentage:    12.345,
+		Memory:           52428800,
+		MemoryLimit:      1073741824,
+		MemoryPercentage: 4.8828125,
+		NetworkRx:        1024,
+		NetworkTx:        2048,
+		BlockRead:        4096,
+		BlockWrite:       8192,
+		PidsCurrent:      3,
+	},
+	{Container: "stopped", IsInvalid: true},
+}
+
+func TestContainerStatsJSONWrite(t *testing.T) {
+	out := bytes.NewBufferString("")
+	ts := time.Date(2018, 1, 2, 10, 0, 0, 0, time.UTC)
+	err := ContainerStatsJSONWrite(out, ts, exportStats)
+	assert.NoError(t, err)
+	expected := `{"Time":"2018-01-02T10:00:00Z","Container":"web","Name":"web","ID":"0123456789ab","CPUPercentage":12.345,"Memory":52428800,"MemoryLimit":1073741824,"MemoryPercentage":4.8828125,"NetworkRx":1024,"NetworkTx":2048,"BlockRead":4096,"BlockWrite":8192,"PidsCurrent":3}
+`
+	assert.Equal(t, expected, out.String())
+}
+
+func TestContainerStatsCSVWrite(t *testing.T) {
+	out := bytes.NewBufferString("")
+	ts := time.Date(2018, 1, 2, 10, 0, 0, 0, time.UTC)
+	assert.NoError(t, ContainerStatsCSVWrite(out, ts, exportStats, true))
+	assert.NoError(t, ContainerStatsCSVWrite(out, ts.Add(time.Second), exportStats, false))
+	expected := `Time,Container,Name,ID,CPUPercentage,Memory,MemoryLimit,MemoryPercentage,NetworkRx,NetworkTx,BlockRead,BlockWrite,PidsCurrent
+2018-01-02T10:00:00Z,web,web,0123456789ab,12.35,52428800,1073741824,4.88,1024,2048,4096,8192,3
+2018-01-02T10:00:01Z,web,web,0123456789ab,12.35,52428800,1073741824,4.88,1024,2048,4096,8192,3
+`
+	assert.Equal(t, expected, out.String())
+}
+
+func TestContainerStatsPrometheusWrite(t *testing.T) {
+	out := bytes.NewBufferString("")
+	stats := append([]StatsEntry{{Name: `/we"ird`, ID: "abc", PidsCurrent: 1}}, exportStats...)
+	assert.NoError(t, ContainerStatsPrometheusWrite(out, stats, ""))
+	expected := `# HELP docker_container_cpu_percent CPU usage of the container, in percent of one CPU.
+# TYPE docker_container_cpu_percent gauge
+docker_container_cpu_percent{id="abc",name="we\"ird"} 0
+docker_container_cpu_percent{id="0123456789ab",name="web"} 12.345
+# HELP docker_container_memory_usage_bytes Memory usage of the container, or private working set on Windows.
+# TYPE docker_container_memory_usage_bytes gauge
+docker_container_memory_usage_bytes{id="abc",name="we\"ird"} 0
+docker_container_memory_usage_bytes{id="0123456789ab",name="web"} 5.24288e+07
+# HELP docker_container_memory_limit_bytes Memory limit of the container.
+# TYPE docker_container_memory_limit_bytes gauge
+docker_container_memory_limit_bytes{id="abc",name="we\"ird"} 0
+docker_container_memory_limit_bytes{id="0123456789ab",name="web"} 1.073741824e+09
+# HELP docker_container_memory_percent Memory usage of the container, in percent of its limit.
+# TYPE docker_container_memory_percent gauge
+docker_container_memory_percent{id="abc",name="we\"ird"} 0
+docker_container_memory_percent{id="0123456789ab",name="web"} 4.8828125
+# HELP docker_container_network_receive_bytes_total Bytes received by the container over the network.
+# TYPE docker_container_network_receive_bytes_total counter
+docker_container_network_receive_bytes_total{id="abc",name="we\"ird"} 0
+docker_container_network_receive_bytes_total{id="0123456789ab",name="web"} 1024
+# HELP docker_container_network_transmit_bytes_total Bytes sent by the container over the network.
+# TYPE docker_container_network_transmit_bytes_total counter
+docker_container_network_transmit_bytes_total{id="abc",name="we\"ird"} 0
+docker_container_network_transmit_bytes_total{id="0123456789ab",name="web"} 2048
+# HELP docker_container_block_read_bytes_total Bytes read by the container from block devices.
+# TYPE docker_container_block_read_bytes_total counter
+docker_container_block_read_bytes_total{id="abc",name="we\"ird"} 0
+docker_container_block_read_bytes_total{id="0123456789ab",name="web"} 4096
+# HELP docker_container_block_write_bytes_total Bytes written by the container to block devices.
+# TYPE docker_container_block_write_bytes_total counter
+docker_container_block_write_bytes_total{id="abc",name="we\"ird"} 0
+docker_container_block_write_bytes_total{id="0123456789ab",name="web"} 8192
+# HELP docker_container_pids Number of processes of the container.
+# TYPE docker_container_pids gauge
+docker_container_pids{id="abc",name="we\"ird"} 1
+docker_container_pids{id="0123456789ab",name="web"} 3
+`
+	assert.Equal(t, expected, out.String())
+
+	out.Reset()
+	assert.NoError(t, ContainerStatsPrometheusWrite(out, exportStats, "windows"))
+	assert.NotContains(t, out.String(), "docker_container_pids")
+	assert.NotContains(t, out.String(), "docker_container_memory_limit_bytes")
+}
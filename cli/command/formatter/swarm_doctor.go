@@ -0,0 +1,75 @@
+package formatter
+
+const (
+	defaultSwarmCheckTableFormat = "table {{.Check}}\t{{.Status}}\t{{.Message}}"
+
+	swarmCheckHeader        = "CHECK"
+	swarmCheckStatusHeader  = "STATUS"
+	swarmCheckMessageHeader = "MESSAGE"
+)
+
+// Status of a swarm health check
+const (
+	SwarmCheckPass = "pass"
+	SwarmCheckWarn = "warn"
+	SwarmCheckFail = "fail"
+)
+
+// SwarmCheck is the result of a health check of the swarm.
+type SwarmCheck struct {
+	Check string
+	// Status is one of SwarmCheckPass, SwarmCheckWarn or SwarmCheckFail.
+	Status  string
+	Message string
+}
+
+// NewSwarmCheckFormat returns a Format for rendering using a swarmCheckContext
+func NewSwarmCheckFormat(source string) Format {
+	switch source {
+	case TableFormatKey:
+		return defaultSwarmCheckTableFormat
+	case JSONFormatKey:
+		return "{{json .}}"
+	}
+	return Format(source)
+}
+
+// SwarmCheckWrite writes the results of health checks of the swarm
+func SwarmCheckWrite(ctx Context, checks []SwarmCheck) error {
+	render := func(format func(subContext subContext) error) error {
+		for _, check := range checks {
+			if err := format(&swarmCheckContext{c: check}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	checkCtx := &swarmCheckContext{}
+	checkCtx.header = map[string]string{
+		"Check":   swarmCheckHeader,
+		"Status":  swarmCheckStatusHeader,
+		"Message": swarmCheckMessageHeader,
+	}
+	return ctx.Write(checkCtx, render)
+}
+
+type swarmCheckContext struct {
+	HeaderContext
+	c SwarmCheck
+}
+
+func (c *swarmCheckContext) MarshalJSON() ([]byte, error) {
+	return marshalJSON(c)
+}
+
+func (c *swarmCheckContext) Check() string {
+	return c.c.Check
+}
+
+func (c *swarmCheckContext) Status() string {
+	return c.c.Status
+}
+
+func (c *swarmCheckContext) Message() string {
+	return c.c.Message
+}
@@ -0,0 +1,39 @@
+package formatter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSwarmCheckWrite(t *testing.T) {
+	checks := []SwarmCheck{
+		{Check: "manager quorum", Status: SwarmCheckPass, Message: "3 of 3 managers reachable"},
+		{Check: "root CA", Status: SwarmCheckWarn, Message: "root CA certificate expires soon"},
+	}
+
+	cases := []struct {
+		context  Context
+		expected string
+	}{
+		{
+			Context{Format: NewSwarmCheckFormat("{{.Status}}: {{.Check}}")},
+			"pass: manager quorum\nwarn: root CA\n",
+		},
+		{
+			Context{Format: NewSwarmCheckFormat(JSONFormatKey)},
+			`{"Check":"manager quorum","Message":"3 of 3 managers reachable","Status":"pass"}
+{"Check":"root CA","Message":"root CA certificate expires soon","Status":"warn"}
+`,
+		},
+	}
+
+	for _, testcase := range cases {
+		out := bytes.NewBufferString("")
+		testcase.context.Output = out
+		err := SwarmCheckWrite(testcase.context, checks)
+		assert.NoError(t, err)
+		assert.Equal(t, testcase.expected, out.String())
+	}
+}
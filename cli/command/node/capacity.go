@@ -0,0 +1,113 @@
+package node
+
+import (
+	"sort"
+
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/formatter"
+	"github.com/docker/cli/cli/command/placement"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+type capacityOptions struct {
+	nodes   []string
+	service string
+	format  string
+}
+
+func newCapacityCommand(dockerCli command.Cli) *cobra.Command {
+	options := capacityOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "capacity [OPTIONS] [NODE...]",
+		Short: "Display the resources reserved by tasks and the free capacity of nodes",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.nodes = args
+			return runCapacity(dockerCli, options)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.service, "service", "", "Show whether the nodes can run a task of this service")
+	flags.StringVar(&options.format, "format", "", "Pretty-print capacity using a Go template, or \"json\"")
+	return cmd
+}
+
+func runCapacity(dockerCli command.Cli, options capacityOptions) error {
+	client := dockerCli.Client()
+	ctx := context.Background()
+
+	var nodes []swarm.Node
+	if len(options.nodes) == 0 {
+		var err error
+		if nodes, err = client.NodeList(ctx, types.NodeListOptions{}); err != nil {
+			return err
+		}
+		sort.Sort(byHostname(nodes))
+	}
+	for _, ref := range options.nodes {
+		nodeRef, err := Reference(ctx, client, ref)
+		if err != nil {
+			return err
+		}
+		node, _, err := client.NodeInspectWithRaw(ctx, nodeRef)
+		if err != nil {
+			return err
+		}
+		nodes = append(nodes, node)
+	}
+
+	var spec *swarm.TaskSpec
+	if options.service != "" {
+		service, _, err := client.ServiceInspectWithRaw(ctx, options.service, types.ServiceInspectOptions{})
+		if err != nil {
+			return err
+		}
+		spec = &service.Spec.TaskTemplate
+	}
+
+	capacities, err := nodeCapacities(ctx, dockerCli, nodes, spec)
+	if err != nil {
+		return err
+	}
+
+	format := options.format
+	if len(format) == 0 {
+		format = formatter.TableFormatKey
+	}
+	capacityCtx := formatter.Context{
+		Output: dockerCli.Out(),
+		Format: formatter.NewNodeCapacityFormat(format, spec != nil),
+	}
+	return formatter.NodeCapacityWrite(capacityCtx, capacities, types.Info{})
+}
+
+// nodeCapacities returns the resource usage of nodes. If spec is set, it also
+// checks whether a task with this spec could be scheduled on each node.
+func nodeCapacities(ctx context.Context, dockerCli command.Cli, nodes []swarm.Node, spec *swarm.TaskSpec) ([]formatter.NodeCapacity, error) {
+	filter := filters.NewArgs()
+	filter.Add("desired-state", string(swarm.TaskStateRunning))
+	tasks, err := dockerCli.Client().TaskList(ctx, types.TaskListOptions{Filters: filter})
+	if err != nil {
+		return nil, err
+	}
+	usage := placement.NodeUsage(tasks)
+
+	capacities := make([]formatter.NodeCapacity, 0, len(nodes))
+	for _, node := range nodes {
+		c := formatter.NodeCapacity{Node: node}
+		if u, ok := usage[node.ID]; ok {
+			c.Usage = *u
+		}
+		if spec != nil {
+			c.Checked = true
+			c.Placement = placement.Check(node, usage[node.ID], *spec)
+		}
+		capacities = append(capacities, c)
+	}
+	return capacities, nil
+}
@@ -0,0 +1,156 @@
+package node
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/gotestyourself/gotestyourself/golden"
+	"github.com/pkg/errors"
+	// Import builders to get the builder function as package function
+	. "github.com/docker/cli/internal/test/builders"
+	"github.com/docker/cli/internal/test/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func withResources(nanoCPUs, memoryBytes int64, gpus int64) func(*swarm.Node) {
+	return func(node *swarm.Node) {
+		node.Description.Resources = swarm.Resources{NanoCPUs: nanoCPUs, MemoryBytes: memoryBytes}
+		if gpus > 0 {
+			node.Description.Resources.GenericResources = []swarm.GenericResource{
+				{DiscreteResourceSpec: &swarm.DiscreteGenericResource{Kind: "gpu", Value: gpus}},
+			}
+		}
+	}
+}
+
+func reservingTask(nodeID string, nanoCPUs, memoryBytes int64) swarm.Task {
+	task := Task(TaskNodeID(nodeID), TaskDesiredState(swarm.TaskStateRunning), WithStatus(TaskState(swarm.TaskStateRunning)))
+	task.Spec.Resources = &swarm.ResourceRequirements{
+		Reservations: &swarm.Resources{NanoCPUs: nanoCPUs, MemoryBytes: memoryBytes},
+		Limits:       &swarm.Resources{NanoCPUs: 2 * nanoCPUs, MemoryBytes: 2 * memoryBytes},
+	}
+	return *task
+}
+
+func capacityTestClient() *fakeClient {
+	return &fakeClient{
+		nodeListFunc: func() ([]swarm.Node, error) {
+			return []swarm.Node{
+				*Node(NodeID("nodeID2"), Hostname("node-2"), withResources(2e9, 2<<30, 0)),
+				*Node(NodeID("nodeID1"), Hostname("node-1"), Manager(Leader()), withResources(4e9, 8<<30, 2)),
+			}, nil
+		},
+		infoFunc: func() (types.Info, error) {
+			return types.Info{Swarm: swarm.Info{NodeID: "nodeID1"}}, nil
+		},
+		taskListFunc: func(options types.TaskListOptions) ([]swarm.Task, error) {
+			return []swarm.Task{
+				reservingTask("nodeID1", 1e9, 1<<30),
+				reservingTask("nodeID1", 5e8, 512<<20),
+				reservingTask("nodeID2", 15e8, 1<<30),
+			}, nil
+		},
+	}
+}
+
+func TestNodeCapacityErrors(t *testing.T) {
+	testCases := []struct {
+		args               []string
+		flags              map[string]string
+		nodeInspectFunc    func() (swarm.Node, []byte, error)
+		serviceInspectFunc func(serviceID string) (swarm.Service, []byte, error)
+		taskListFunc       func(options types.TaskListOptions) ([]swarm.Task, error)
+		expectedError      string
+	}{
+		{
+			args: []string{"nodeID"},
+			nodeInspectFunc: func() (swarm.Node, []byte, error) {
+				return swarm.Node{}, []byte{}, errors.Errorf("error inspecting the node")
+			},
+			expectedError: "error inspecting the node",
+		},
+		{
+			flags: map[string]string{"service": "web"},
+			serviceInspectFunc: func(serviceID string) (swarm.Service, []byte, error) {
+				return swarm.Service{}, []byte{}, errors.Errorf("error inspecting the service")
+			},
+			expectedError: "error inspecting the service",
+		},
+		{
+			taskListFunc: func(options types.TaskListOptions) ([]swarm.Task, error) {
+				return nil, errors.Errorf("error listing the tasks")
+			},
+			expectedError: "error listing the tasks",
+		},
+	}
+	for _, tc := range testCases {
+		cmd := newCapacityCommand(
+			test.NewFakeCli(&fakeClient{
+				nodeInspectFunc:    tc.nodeInspectFunc,
+				serviceInspectFunc: tc.serviceInspectFunc,
+				taskListFunc:       tc.taskListFunc,
+			}))
+		cmd.SetArgs(tc.args)
+		for key, value := range tc.flags {
+			cmd.Flags().Set(key, value)
+		}
+		cmd.SetOutput(ioutil.Discard)
+		testutil.ErrorContains(t, cmd.Execute(), tc.expectedError)
+	}
+}
+
+func TestNodeCapacity(t *testing.T) {
+	cli := test.NewFakeCli(capacityTestClient())
+	cmd := newCapacityCommand(cli)
+	assert.NoError(t, cmd.Execute())
+	golden.Assert(t, cli.OutBuffer().String(), "node-capacity.golden")
+}
+
+func TestNodeCapacityService(t *testing.T) {
+	client := capacityTestClient()
+	client.serviceInspectFunc = func(serviceID string) (swarm.Service, []byte, error) {
+		service := Service(ServiceName(serviceID))
+		service.Spec.TaskTemplate.Placement = &swarm.Placement{Constraints: []string{"node.labels.zone!=west"}}
+		service.Spec.TaskTemplate.Resources = &swarm.ResourceRequirements{
+			Reservations: &swarm.Resources{NanoCPUs: 1e9},
+		}
+		return *service, []byte{}, nil
+	}
+	client.nodeListFunc = func() ([]swarm.Node, error) {
+		west := Node(NodeID("nodeID3"), Hostname("node-3"), withResources(4e9, 8<<30, 0))
+		west.Spec.Labels = map[string]string{"zone": "west"}
+		drained := Node(NodeID("nodeID4"), Hostname("node-4"), withResources(4e9, 8<<30, 0))
+		drained.Spec.Availability = swarm.NodeAvailabilityDrain
+		return []swarm.Node{
+			*Node(NodeID("nodeID1"), Hostname("node-1"), withResources(4e9, 8<<30, 2)),
+			*Node(NodeID("nodeID2"), Hostname("node-2"), withResources(2e9, 2<<30, 0)),
+			*west,
+			*drained,
+		}, nil
+	}
+
+	cli := test.NewFakeCli(client)
+	cmd := newCapacityCommand(cli)
+	cmd.Flags().Set("service", "web")
+	assert.NoError(t, cmd.Execute())
+	golden.Assert(t, cli.OutBuffer().String(), "node-capacity-service.golden")
+}
+
+func TestNodeListResources(t *testing.T) {
+	cli := test.NewFakeCli(capacityTestClient())
+	cmd := newListCommand(cli)
+	cmd.Flags().Set("resources", "true")
+	assert.NoError(t, cmd.Execute())
+	golden.Assert(t, cli.OutBuffer().String(), "node-list-resources.golden")
+}
+
+func TestNodeListResourcesQuiet(t *testing.T) {
+	cmd := newListCommand(test.NewFakeCli(capacityTestClient()))
+	cmd.Flags().Set("resources", "true")
+	cmd.Flags().Set("quiet", "true")
+	cmd.SetOutput(ioutil.Discard)
+	testutil.ErrorContains(t, cmd.Execute(), "--resources and --quiet cannot be used together")
+}
@@ -1,6 +1,8 @@
 package node
 
 import (
+	// Import builders to get the builder function as package function
+	. "github.com/docker/cli/internal/test/builders"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/swarm"
 	"github.com/docker/docker/client"
@@ -9,13 +11,15 @@ import (
 
 type fakeClient struct {
 	client.Client
-	infoFunc        func() (types.Info, error)
-	nodeInspectFunc func() (swarm.Node, []byte, error)
-	nodeListFunc    func() ([]swarm.Node, error)
-	nodeRemoveFunc  func() error
-	nodeUpdateFunc  func(nodeID string, version swarm.Version, node swarm.NodeSpec) error
-	taskInspectFunc func(taskID string) (swarm.Task, []byte, error)
-	taskListFunc    func(options types.TaskListOptions) ([]swarm.Task, error)
+	infoFunc           func() (types.Info, error)
+	nodeInspectFunc    func() (swarm.Node, []byte, error)
+	nodeListFunc       func() ([]swarm.Node, error)
+	nodeRemoveFunc     func() error
+	nodeUpdateFunc     func(nodeID string, version swarm.Version, node swarm.NodeSpec) error
+	serviceInspectFunc func(serviceID string) (swarm.Service, []byte, error)
+	serviceListFunc    func(options types.ServiceListOptions) ([]swarm.Service, error)
+	taskInspectFunc    func(taskID string) (swarm.Task, []byte, error)
+	taskListFunc       func(options types.TaskListOptions) ([]swarm.Task, error)
 }
 
 func (cli *fakeClient) NodeInspectWithRaw(ctx context.Context, ref string) (swarm.Node, []byte, error) {
@@ -53,6 +57,20 @@ func (cli *fakeClient) Info(ctx context.Context) (types.Info, error) {
 	return types.Info{}, nil
 }
 
+func (cli *fakeClient) ServiceInspectWithRaw(ctx context.Context, serviceID string, options types.ServiceInspectOptions) (swarm.Service, []byte, error) {
+	if cli.serviceInspectFunc != nil {
+		return cli.serviceInspectFunc(serviceID)
+	}
+	return *Service(ServiceID(serviceID), ServiceName(serviceID)), []byte{}, nil
+}
+
+func (cli *fakeClient) ServiceList(ctx context.Context, options types.ServiceListOptions) ([]swarm.Service, error) {
+	if cli.serviceListFunc != nil {
+		return cli.serviceListFunc(options)
+	}
+	return []swarm.Service{}, nil
+}
+
 func (cli *fakeClient) TaskInspectWithRaw(ctx context.Context, taskID string) (swarm.Task, []byte, error) {
 	if cli.taskInspectFunc != nil {
 		return cli.taskInspectFunc(taskID)
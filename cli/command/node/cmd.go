@@ -21,8 +21,11 @@ func NewNodeCommand(dockerCli command.Cli) *cobra.Command {
 		Annotations: map[string]string{"version": "1.24"},
 	}
 	cmd.AddCommand(
+		newCapacityCommand(dockerCli),
 		newDemoteCommand(dockerCli),
+		newDrainCommand(dockerCli),
 		newInspectCommand(dockerCli),
+		newLabelCommand(dockerCli),
 		newListCommand(dockerCli),
 		newPromoteCommand(dockerCli),
 		newRemoveCommand(dockerCli),
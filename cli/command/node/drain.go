@@ -0,0 +1,155 @@
+package node
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/service/progress"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+type drainOptions struct {
+	nodes   []string
+	wait    bool
+	timeout time.Duration
+	undrain bool
+	quiet   bool
+}
+
+func newDrainCommand(dockerCli command.Cli) *cobra.Command {
+	options := drainOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "drain [OPTIONS] NODE [NODE...]",
+		Short: "Drain one or more nodes",
+		Args:  cli.RequiresMinArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.nodes = args
+			return runDrain(dockerCli, options)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&options.wait, "wait", false, "Wait until the tasks of the nodes have been moved to other nodes")
+	flags.DurationVar(&options.timeout, "timeout", 0, "Maximum time to wait for the tasks to be moved, 0 waits indefinitely (ns|us|ms|s|m|h)")
+	flags.BoolVar(&options.undrain, "undrain", false, "Make the nodes active again")
+	flags.BoolVarP(&options.quiet, "quiet", "q", false, "Suppress progress output")
+	return cmd
+}
+
+func runDrain(dockerCli command.Cli, options drainOptions) error {
+	if options.undrain && options.wait {
+		return errors.New("--wait cannot be used with --undrain")
+	}
+	if options.timeout != 0 && !options.wait {
+		return errors.New("--timeout can only be used with --wait")
+	}
+	if options.timeout < 0 {
+		return errors.New("--timeout cannot be negative")
+	}
+
+	client := dockerCli.Client()
+	ctx := context.Background()
+
+	availability := swarm.NodeAvailabilityDrain
+	if options.undrain {
+		availability = swarm.NodeAvailabilityActive
+	}
+
+	nodeIDs := make([]string, 0, len(options.nodes))
+	for _, ref := range options.nodes {
+		nodeRef, err := Reference(ctx, client, ref)
+		if err != nil {
+			return err
+		}
+		node, _, err := client.NodeInspectWithRaw(ctx, nodeRef)
+		if err != nil {
+			return err
+		}
+		nodeIDs = append(nodeIDs, node.ID)
+	}
+
+	// The tasks must be listed before draining the nodes, as the orchestrator
+	// changes their desired state as soon as the nodes are drained.
+	var tasks []swarm.Task
+	if options.wait {
+		var err error
+		if tasks, err = listDrainedTasks(ctx, dockerCli, nodeIDs); err != nil {
+			return err
+		}
+	}
+
+	drain := func(node *swarm.Node) error {
+		if node.Spec.Availability == availability {
+			fmt.Fprintf(dockerCli.Out(), "Node %s availability is already %s.\n", node.ID, availability)
+			return errNoChange
+		}
+		node.Spec.Availability = availability
+		return nil
+	}
+	success := func(nodeID string) {
+		if options.undrain {
+			fmt.Fprintf(dockerCli.Out(), "Node %s is now active.\n", nodeID)
+		} else {
+			fmt.Fprintf(dockerCli.Out(), "Node %s drained.\n", nodeID)
+		}
+	}
+	if err := updateNodes(dockerCli, nodeIDs, drain, success); err != nil {
+		return err
+	}
+
+	if !options.wait {
+		return nil
+	}
+	if options.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.timeout)
+		defer cancel()
+	}
+	err := waitOnDrain(ctx, dockerCli, nodeIDs, tasks, options.quiet)
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		return errors.Errorf("timed out after %s waiting for the tasks to be moved; they continue to be moved in the background", options.timeout)
+	}
+	return err
+}
+
+// listDrainedTasks returns the tasks that are meant to be running on the
+// given nodes.
+func listDrainedTasks(ctx context.Context, dockerCli command.Cli, nodeIDs []string) ([]swarm.Task, error) {
+	filter := filters.NewArgs()
+	filter.Add("desired-state", string(swarm.TaskStateRunning))
+	for _, nodeID := range nodeIDs {
+		filter.Add("node", nodeID)
+	}
+	return dockerCli.Client().TaskList(ctx, types.TaskListOptions{Filters: filter})
+}
+
+func waitOnDrain(ctx context.Context, dockerCli command.Cli, nodeIDs []string, tasks []swarm.Task, quiet bool) error {
+	errChan := make(chan error, 1)
+	pipeReader, pipeWriter := io.Pipe()
+
+	go func() {
+		errChan <- progress.DrainProgress(ctx, dockerCli.Client(), nodeIDs, tasks, pipeWriter)
+	}()
+
+	if quiet {
+		go io.Copy(ioutil.Discard, pipeReader)
+		return <-errChan
+	}
+
+	err := jsonmessage.DisplayJSONMessagesToStream(pipeReader, dockerCli.Out(), nil)
+	if err == nil {
+		err = <-errChan
+	}
+	return err
+}
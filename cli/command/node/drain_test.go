@@ -0,0 +1,203 @@
+package node
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/pkg/errors"
+	// Import builders to get the builder function as package function
+	. "github.com/docker/cli/internal/test/builders"
+	"github.com/docker/cli/internal/test/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeDrainErrors(t *testing.T) {
+	testCases := []struct {
+		args            []string
+		flags           map[string]string
+		nodeInspectFunc func() (swarm.Node, []byte, error)
+		nodeUpdateFunc  func(nodeID string, version swarm.Version, node swarm.NodeSpec) error
+		taskListFunc    func(options types.TaskListOptions) ([]swarm.Task, error)
+		expectedError   string
+	}{
+		{
+			expectedError: "requires at least 1 argument",
+		},
+		{
+			args: []string{"nodeID"},
+			flags: map[string]string{
+				"wait":    "true",
+				"undrain": "true",
+			},
+			expectedError: "--wait cannot be used with --undrain",
+		},
+		{
+			args: []string{"nodeID"},
+			flags: map[string]string{
+				"timeout": "1m",
+			},
+			expectedError: "--timeout can only be used with --wait",
+		},
+		{
+			args: []string{"nodeID"},
+			nodeInspectFunc: func() (swarm.Node, []byte, error) {
+				return swarm.Node{}, []byte{}, errors.Errorf("error inspecting the node")
+			},
+			expectedError: "error inspecting the node",
+		},
+		{
+			args: []string{"nodeID"},
+			nodeUpdateFunc: func(nodeID string, version swarm.Version, node swarm.NodeSpec) error {
+				return errors.Errorf("error updating the node")
+			},
+			expectedError: "error updating the node",
+		},
+		{
+			args:  []string{"nodeID"},
+			flags: map[string]string{"wait": "true"},
+			taskListFunc: func(options types.TaskListOptions) ([]swarm.Task, error) {
+				return nil, errors.Errorf("error listing the tasks")
+			},
+			expectedError: "error listing the tasks",
+		},
+	}
+	for _, tc := range testCases {
+		cmd := newDrainCommand(
+			test.NewFakeCli(&fakeClient{
+				nodeInspectFunc: tc.nodeInspectFunc,
+				nodeUpdateFunc:  tc.nodeUpdateFunc,
+				taskListFunc:    tc.taskListFunc,
+			}))
+		cmd.SetArgs(tc.args)
+		for key, value := range tc.flags {
+			cmd.Flags().Set(key, value)
+		}
+		cmd.SetOutput(ioutil.Discard)
+		testutil.ErrorContains(t, cmd.Execute(), tc.expectedError)
+	}
+}
+
+func TestNodeDrain(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		nodeInspectFunc: func() (swarm.Node, []byte, error) {
+			return *Node(), []byte{}, nil
+		},
+		nodeUpdateFunc: func(nodeID string, version swarm.Version, node swarm.NodeSpec) error {
+			if node.Availability != swarm.NodeAvailabilityDrain {
+				return errors.Errorf("expected availability drain, got %s", node.Availability)
+			}
+			return nil
+		},
+	})
+	cmd := newDrainCommand(cli)
+	cmd.SetArgs([]string{"nodeID"})
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "Node nodeID drained.\n", cli.OutBuffer().String())
+}
+
+func TestNodeUndrain(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		nodeInspectFunc: func() (swarm.Node, []byte, error) {
+			node := Node()
+			node.Spec.Availability = swarm.NodeAvailabilityDrain
+			return *node, []byte{}, nil
+		},
+		nodeUpdateFunc: func(nodeID string, version swarm.Version, node swarm.NodeSpec) error {
+			if node.Availability != swarm.NodeAvailabilityActive {
+				return errors.Errorf("expected availability active, got %s", node.Availability)
+			}
+			return nil
+		},
+	})
+	cmd := newDrainCommand(cli)
+	cmd.SetArgs([]string{"nodeID"})
+	cmd.Flags().Set("undrain", "true")
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "Node nodeID is now active.\n", cli.OutBuffer().String())
+}
+
+func TestNodeDrainAlreadyDrained(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		nodeInspectFunc: func() (swarm.Node, []byte, error) {
+			node := Node()
+			node.Spec.Availability = swarm.NodeAvailabilityDrain
+			return *node, []byte{}, nil
+		},
+		nodeUpdateFunc: func(nodeID string, version swarm.Version, node swarm.NodeSpec) error {
+			return errors.Errorf("node should not be updated")
+		},
+	})
+	cmd := newDrainCommand(cli)
+	cmd.SetArgs([]string{"nodeID"})
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "Node nodeID availability is already drain.\n", cli.OutBuffer().String())
+}
+
+func TestNodeDrainWait(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		nodeInspectFunc: func() (swarm.Node, []byte, error) {
+			return *Node(), []byte{}, nil
+		},
+		serviceListFunc: func(options types.ServiceListOptions) ([]swarm.Service, error) {
+			return []swarm.Service{*Service(ServiceID("serviceID"), ServiceName("web"))}, nil
+		},
+		taskListFunc: func(options types.TaskListOptions) ([]swarm.Task, error) {
+			switch {
+			case options.Filters.Include("service"):
+				return []swarm.Task{
+					*Task(TaskID("task2"), TaskServiceID("serviceID"), TaskNodeID("otherNodeID"), WithStatus(TaskState(swarm.TaskStateRunning))),
+				}, nil
+			case options.Filters.Include("desired-state"):
+				return []swarm.Task{
+					*Task(TaskID("task1"), TaskServiceID("serviceID"), TaskNodeID("nodeID"), WithStatus(TaskState(swarm.TaskStateRunning))),
+				}, nil
+			default:
+				return []swarm.Task{
+					*Task(TaskID("task1"), TaskServiceID("serviceID"), TaskNodeID("nodeID"), WithStatus(TaskState(swarm.TaskStateShutdown))),
+				}, nil
+			}
+		},
+	})
+	cmd := newDrainCommand(cli)
+	cmd.SetArgs([]string{"nodeID"})
+	cmd.Flags().Set("wait", "true")
+	assert.NoError(t, cmd.Execute())
+	out := cli.OutBuffer().String()
+	assert.Contains(t, out, "Node nodeID drained.")
+	assert.Contains(t, out, "web.1: moved to otherNodeID")
+	assert.Contains(t, out, "overall progress: 1 out of 1 tasks moved")
+}
+
+func TestNodeDrainWaitTimeout(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		nodeInspectFunc: func() (swarm.Node, []byte, error) {
+			return *Node(), []byte{}, nil
+		},
+		serviceListFunc: func(options types.ServiceListOptions) ([]swarm.Service, error) {
+			return []swarm.Service{*Service(ServiceID("serviceID"), ServiceName("web"), ReplicatedService(1))}, nil
+		},
+		taskListFunc: func(options types.TaskListOptions) ([]swarm.Task, error) {
+			switch {
+			case options.Filters.Include("service"):
+				return []swarm.Task{}, nil
+			case options.Filters.Include("desired-state"):
+				return []swarm.Task{
+					*Task(TaskID("task1"), TaskServiceID("serviceID"), TaskNodeID("nodeID"), WithStatus(TaskState(swarm.TaskStateRunning))),
+				}, nil
+			default:
+				return []swarm.Task{
+					*Task(TaskID("task1"), TaskServiceID("serviceID"), TaskNodeID("nodeID"), WithStatus(TaskState(swarm.TaskStateShutdown))),
+				}, nil
+			}
+		},
+	})
+	cmd := newDrainCommand(cli)
+	cmd.SetArgs([]string{"nodeID"})
+	cmd.Flags().Set("wait", "true")
+	cmd.Flags().Set("timeout", "10ms")
+	testutil.ErrorContains(t, cmd.Execute(), "timed out after 10ms waiting for the tasks to be moved")
+	assert.Contains(t, cli.OutBuffer().String(), "web.1: stopped, waiting for a replacement")
+}
@@ -0,0 +1,21 @@
+package node
+
+import (
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/spf13/cobra"
+)
+
+// newLabelCommand returns a cobra command for `node label` subcommands
+func newLabelCommand(dockerCli command.Cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "label",
+		Short: "Manage node labels",
+		Args:  cli.NoArgs,
+		RunE:  command.ShowHelp(dockerCli.Err()),
+	}
+	cmd.AddCommand(
+		newLabelApplyCommand(dockerCli),
+	)
+	return cmd
+}
@@ -0,0 +1,219 @@
+package node
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+	yaml "gopkg.in/yaml.v2"
+)
+
+type labelApplyOptions struct {
+	file   string
+	dryRun bool
+}
+
+func newLabelApplyCommand(dockerCli command.Cli) *cobra.Command {
+	options := labelApplyOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "apply [OPTIONS]",
+		Short: "Reconcile node labels with a labels file",
+		Args:  cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLabelApply(dockerCli, options)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&options.file, "file", "f", "", `Path to the labels file ("-" to read from stdin)`)
+	flags.BoolVar(&options.dryRun, "dry-run", false, "Only print the changes, without updating the nodes")
+	cmd.MarkFlagRequired("file")
+	return cmd
+}
+
+// labelChange is a change to a single label of a node.
+type labelChange struct {
+	hostname string
+	key      string
+	// old and new are nil if the label is added or removed respectively.
+	old, new *string
+}
+
+func (c labelChange) String() string {
+	switch {
+	case c.old == nil:
+		return fmt.Sprintf("+ %s: %s=%s", c.hostname, c.key, *c.new)
+	case c.new == nil:
+		return fmt.Sprintf("- %s: %s=%s", c.hostname, c.key, *c.old)
+	default:
+		return fmt.Sprintf("~ %s: %s=%s => %s=%s", c.hostname, c.key, *c.old, c.key, *c.new)
+	}
+}
+
+func runLabelApply(dockerCli command.Cli, options labelApplyOptions) error {
+	desired, err := readLabelsFile(dockerCli.In(), options.file)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	nodes, err := dockerCli.Client().NodeList(ctx, types.NodeListOptions{})
+	if err != nil {
+		return err
+	}
+	labels, err := resolveNodeLabels(nodes, desired)
+	if err != nil {
+		return err
+	}
+
+	sort.Sort(byHostname(nodes))
+	var (
+		changes []labelChange
+		update  []string
+	)
+	for _, node := range nodes {
+		want, ok := labels[node.ID]
+		if !ok {
+			continue
+		}
+		nodeChanges := diffLabels(node.Description.Hostname, node.Spec.Labels, want)
+		if len(nodeChanges) > 0 {
+			changes = append(changes, nodeChanges...)
+			update = append(update, node.ID)
+		}
+	}
+
+	out := dockerCli.Out()
+	if len(changes) == 0 {
+		fmt.Fprintln(out, "Node labels are up to date.")
+		return nil
+	}
+	for _, change := range changes {
+		fmt.Fprintln(out, change)
+	}
+	if options.dryRun {
+		return nil
+	}
+
+	merge := func(node *swarm.Node) error {
+		want := labels[node.ID]
+		if len(diffLabels(node.Description.Hostname, node.Spec.Labels, want)) == 0 {
+			return errNoChange
+		}
+		node.Spec.Labels = make(map[string]string, len(want))
+		for k, v := range want {
+			node.Spec.Labels[k] = v
+		}
+		return nil
+	}
+	var updated int
+	if err := updateNodes(dockerCli, update, merge, func(_ string) { updated++ }); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Updated the labels of %d node(s).\n", updated)
+	return nil
+}
+
+// readLabelsFile reads a labels file, which maps node hostnames or IDs to
+// the complete set of labels of the node:
+//
+//	worker-1:
+//	  zone: a
+//	  disk: ssd
+//	worker-2: {}
+func readLabelsFile(in io.Reader, filename string) (map[string]map[string]string, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if filename == "-" {
+		data, err = ioutil.ReadAll(in)
+	} else {
+		data, err = ioutil.ReadFile(filename)
+	}
+	if err != nil {
+		return nil, err
+	}
+	labels := map[string]map[string]string{}
+	if err := yaml.Unmarshal(data, &labels); err != nil {
+		return nil, errors.Wrap(err, "invalid labels file")
+	}
+	return labels, nil
+}
+
+// resolveNodeLabels maps the node references of a labels file to node IDs. A
+// reference is either the ID or the hostname of a node.
+func resolveNodeLabels(nodes []swarm.Node, desired map[string]map[string]string) (map[string]map[string]string, error) {
+	labels := make(map[string]map[string]string, len(desired))
+	for ref, want := range desired {
+		var matches []string
+		for _, node := range nodes {
+			if node.ID == ref {
+				matches = []string{node.ID}
+				break
+			}
+			if node.Description.Hostname == ref {
+				matches = append(matches, node.ID)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			return nil, errors.Errorf("node %s not found", ref)
+		case 1:
+		default:
+			return nil, errors.Errorf("hostname %s is ambiguous, use a node ID instead", ref)
+		}
+		if _, exists := labels[matches[0]]; exists {
+			return nil, errors.Errorf("node %s is listed more than once", ref)
+		}
+		if want == nil {
+			want = map[string]string{}
+		}
+		labels[matches[0]] = want
+	}
+	return labels, nil
+}
+
+// diffLabels returns the changes needed to turn current into want, sorted by
+// label key.
+func diffLabels(hostname string, current, want map[string]string) []labelChange {
+	keys := make(map[string]struct{}, len(current)+len(want))
+	for k := range current {
+		keys[k] = struct{}{}
+	}
+	for k := range want {
+		keys[k] = struct{}{}
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	var changes []labelChange
+	for _, k := range sorted {
+		oldValue, hadOld := current[k]
+		newValue, hasNew := want[k]
+		if hadOld && hasNew && oldValue == newValue {
+			continue
+		}
+		change := labelChange{hostname: hostname, key: k}
+		if hadOld {
+			change.old = &oldValue
+		}
+		if hasNew {
+			change.new = &newValue
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
@@ -0,0 +1,130 @@
+package node
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/gotestyourself/gotestyourself/fs"
+	"github.com/gotestyourself/gotestyourself/golden"
+	// Import builders to get the builder function as package function
+	. "github.com/docker/cli/internal/test/builders"
+	"github.com/docker/cli/internal/test/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+const labelsFile = `
+worker-1:
+  zone: a
+  disk: ssd
+  gpu: true
+worker-2:
+manager-1:
+  zone: c
+`
+
+func labelApplyTestNodes() []swarm.Node {
+	return []swarm.Node{
+		*Node(NodeID("nodeID3"), Hostname("manager-1"), Manager(), NodeLabels(map[string]string{"zone": "c"})),
+		*Node(NodeID("nodeID2"), Hostname("worker-2"), NodeLabels(map[string]string{"zone": "b"})),
+		*Node(NodeID("nodeID1"), Hostname("worker-1"), NodeLabels(map[string]string{"zone": "b", "disk": "ssd", "rack": "1"})),
+		*Node(NodeID("nodeID4"), Hostname("worker-3"), NodeLabels(map[string]string{"zone": "a"})),
+	}
+}
+
+func TestNodeLabelApplyErrors(t *testing.T) {
+	testCases := []struct {
+		labels        string
+		expectedError string
+	}{
+		{
+			labels:        "worker-1: [zone]",
+			expectedError: "invalid labels file",
+		},
+		{
+			labels:        "worker-9:\n  zone: a",
+			expectedError: "node worker-9 not found",
+		},
+		{
+			labels:        "worker-1:\n  zone: a\nnodeID1:\n  zone: b",
+			expectedError: "is listed more than once",
+		},
+	}
+	for _, tc := range testCases {
+		cli := test.NewFakeCli(&fakeClient{
+			nodeListFunc: func() ([]swarm.Node, error) {
+				return labelApplyTestNodes(), nil
+			},
+		})
+		cli.SetIn(command.NewInStream(ioutil.NopCloser(strings.NewReader(tc.labels))))
+		cmd := newLabelApplyCommand(cli)
+		cmd.Flags().Set("file", "-")
+		cmd.SetOutput(ioutil.Discard)
+		testutil.ErrorContains(t, cmd.Execute(), tc.expectedError)
+	}
+}
+
+func TestNodeLabelApply(t *testing.T) {
+	nodes := labelApplyTestNodes()
+	inspect := []swarm.Node{nodes[2], nodes[1]}
+	updated := map[string]map[string]string{}
+	cli := test.NewFakeCli(&fakeClient{
+		nodeListFunc: func() ([]swarm.Node, error) {
+			return nodes, nil
+		},
+		nodeInspectFunc: func() (swarm.Node, []byte, error) {
+			node := inspect[0]
+			inspect = inspect[1:]
+			return node, []byte{}, nil
+		},
+		nodeUpdateFunc: func(nodeID string, version swarm.Version, node swarm.NodeSpec) error {
+			updated[nodeID] = node.Labels
+			return nil
+		},
+	})
+	dir := fs.NewDir(t, "labels", fs.WithFile("labels.yaml", labelsFile))
+	defer dir.Remove()
+
+	cmd := newLabelApplyCommand(cli)
+	cmd.Flags().Set("file", dir.Join("labels.yaml"))
+	assert.NoError(t, cmd.Execute())
+	golden.Assert(t, cli.OutBuffer().String(), "node-label-apply.golden")
+	assert.Equal(t, map[string]map[string]string{
+		"nodeID1": {"zone": "a", "disk": "ssd", "gpu": "true"},
+		"nodeID2": {},
+	}, updated)
+}
+
+func TestNodeLabelApplyDryRun(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		nodeListFunc: func() ([]swarm.Node, error) {
+			return labelApplyTestNodes(), nil
+		},
+		nodeUpdateFunc: func(nodeID string, version swarm.Version, node swarm.NodeSpec) error {
+			t.Fatalf("unexpected update of node %s", nodeID)
+			return nil
+		},
+	})
+	cli.SetIn(command.NewInStream(ioutil.NopCloser(strings.NewReader(labelsFile))))
+	cmd := newLabelApplyCommand(cli)
+	cmd.Flags().Set("file", "-")
+	cmd.Flags().Set("dry-run", "true")
+	assert.NoError(t, cmd.Execute())
+	golden.Assert(t, cli.OutBuffer().String(), "node-label-apply-dry-run.golden")
+}
+
+func TestNodeLabelApplyUpToDate(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		nodeListFunc: func() ([]swarm.Node, error) {
+			return labelApplyTestNodes(), nil
+		},
+	})
+	cli.SetIn(command.NewInStream(ioutil.NopCloser(strings.NewReader("manager-1:\n  zone: c\n"))))
+	cmd := newLabelApplyCommand(cli)
+	cmd.Flags().Set("file", "-")
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "Node labels are up to date.\n", cli.OutBuffer().String())
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/docker/cli/opts"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/swarm"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"golang.org/x/net/context"
 	"vbom.ml/util/sortorder"
@@ -23,9 +24,10 @@ func (n byHostname) Less(i, j int) bool {
 }
 
 type listOptions struct {
-	quiet  bool
-	format string
-	filter opts.FilterOpt
+	quiet     bool
+	resources bool
+	format    string
+	filter    opts.FilterOpt
 }
 
 func newListCommand(dockerCli command.Cli) *cobra.Command {
@@ -42,6 +44,7 @@ func newListCommand(dockerCli command.Cli) *cobra.Command {
 	}
 	flags := cmd.Flags()
 	flags.BoolVarP(&options.quiet, "quiet", "q", false, "Only display IDs")
+	flags.BoolVar(&options.resources, "resources", false, "Display the resources reserved by tasks on each node")
 	flags.StringVar(&options.format, "format", "", "Pretty-print nodes using a Go template")
 	flags.VarP(&options.filter, "filter", "f", "Filter output based on conditions provided")
 
@@ -49,6 +52,10 @@ func newListCommand(dockerCli command.Cli) *cobra.Command {
 }
 
 func runList(dockerCli command.Cli, options listOptions) error {
+	if options.resources && options.quiet {
+		return errors.New("--resources and --quiet cannot be used together")
+	}
+
 	client := dockerCli.Client()
 	ctx := context.Background()
 
@@ -68,6 +75,12 @@ func runList(dockerCli command.Cli, options listOptions) error {
 		}
 	}
 
+	sort.Sort(byHostname(nodes))
+
+	if options.resources {
+		return listResources(ctx, dockerCli, nodes, info, options.format)
+	}
+
 	format := options.format
 	if len(format) == 0 {
 		format = formatter.TableFormatKey
@@ -80,6 +93,20 @@ func runList(dockerCli command.Cli, options listOptions) error {
 		Output: dockerCli.Out(),
 		Format: formatter.NewNodeFormat(format, options.quiet),
 	}
-	sort.Sort(byHostname(nodes))
 	return formatter.NodeWrite(nodesCtx, nodes, info)
 }
+
+func listResources(ctx context.Context, dockerCli command.Cli, nodes []swarm.Node, info types.Info, format string) error {
+	capacities, err := nodeCapacities(ctx, dockerCli, nodes, nil)
+	if err != nil {
+		return err
+	}
+	if len(format) == 0 {
+		format = formatter.TableFormatKey
+	}
+	nodesCtx := formatter.Context{
+		Output: dockerCli.Out(),
+		Format: formatter.NewNodeResourcesFormat(format),
+	}
+	return formatter.NodeCapacityWrite(nodesCtx, capacities, info)
+}
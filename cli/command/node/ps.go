@@ -1,6 +1,7 @@
 package node
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/docker/cli/cli"
@@ -10,6 +11,7 @@ import (
 	"github.com/docker/cli/opts"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/swarm"
+	"github.com/docker/docker/client"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"golang.org/x/net/context"
@@ -20,6 +22,7 @@ type psOptions struct {
 	noResolve bool
 	noTrunc   bool
 	quiet     bool
+	watch     bool
 	format    string
 	filter    opts.FilterOpt
 }
@@ -47,6 +50,7 @@ func newPsCommand(dockerCli command.Cli) *cobra.Command {
 	flags.VarP(&options.filter, "filter", "f", "Filter output based on conditions provided")
 	flags.StringVar(&options.format, "format", "", "Pretty-print tasks using a Go template")
 	flags.BoolVarP(&options.quiet, "quiet", "q", false, "Only display task IDs")
+	flags.BoolVar(&options.watch, "watch", false, "Redraw the tasks as their state changes")
 
 	return cmd
 }
@@ -56,8 +60,9 @@ func runPs(dockerCli command.Cli, options psOptions) error {
 	ctx := context.Background()
 
 	var (
-		errs  []string
-		tasks []swarm.Task
+		errs    []string
+		tasks   []swarm.Task
+		nodeIDs []string
 	)
 
 	for _, nodeID := range options.nodeIDs {
@@ -72,11 +77,37 @@ func runPs(dockerCli command.Cli, options psOptions) error {
 			errs = append(errs, err.Error())
 			continue
 		}
+		nodeIDs = append(nodeIDs, node.ID)
+	}
 
-		filter := options.filter.Value()
-		filter.Add("node", node.ID)
+	format := options.format
+	if len(format) == 0 {
+		format = task.DefaultFormat(dockerCli.ConfigFile(), options.quiet)
+	}
 
-		nodeTasks, err := client.TaskList(ctx, types.TaskListOptions{Filters: filter})
+	if options.watch {
+		if len(nodeIDs) == 0 {
+			return errors.Errorf("%s", strings.Join(errs, "\n"))
+		}
+		for _, err := range errs {
+			fmt.Fprintln(dockerCli.Err(), err)
+		}
+		list := func(ctx context.Context) ([]swarm.Task, error) {
+			var tasks []swarm.Task
+			for _, nodeID := range nodeIDs {
+				nodeTasks, err := listNodeTasks(ctx, client, nodeID, options)
+				if err != nil {
+					return nil, err
+				}
+				tasks = append(tasks, nodeTasks...)
+			}
+			return tasks, nil
+		}
+		return task.Watch(ctx, dockerCli, list, idresolver.New(client, options.noResolve), !options.noTrunc, options.quiet, format)
+	}
+
+	for _, nodeID := range nodeIDs {
+		nodeTasks, err := listNodeTasks(ctx, client, nodeID, options)
 		if err != nil {
 			errs = append(errs, err.Error())
 			continue
@@ -85,11 +116,6 @@ func runPs(dockerCli command.Cli, options psOptions) error {
 		tasks = append(tasks, nodeTasks...)
 	}
 
-	format := options.format
-	if len(format) == 0 {
-		format = task.DefaultFormat(dockerCli.ConfigFile(), options.quiet)
-	}
-
 	if len(errs) == 0 || len(tasks) != 0 {
 		if err := task.Print(ctx, dockerCli, tasks, idresolver.New(client, options.noResolve), !options.noTrunc, options.quiet, format); err != nil {
 			errs = append(errs, err.Error())
@@ -102,3 +128,10 @@ func runPs(dockerCli command.Cli, options psOptions) error {
 
 	return nil
 }
+
+func listNodeTasks(ctx context.Context, client client.APIClient, nodeID string, options psOptions) ([]swarm.Task, error) {
+	filter := options.filter.Value()
+	filter.Add("node", nodeID)
+
+	return client.TaskList(ctx, types.TaskListOptions{Filters: filter})
+}
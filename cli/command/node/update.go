@@ -2,10 +2,12 @@ package node
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/opts"
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/swarm"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -15,17 +17,29 @@ import (
 
 var (
 	errNoRoleChange = errors.New("role was already set to the requested value")
+	// errNoChange is returned by the merge function of updateNodes when the
+	// node doesn't need to be updated.
+	errNoChange = errors.New("node is already in the requested state")
 )
 
 func newUpdateCommand(dockerCli command.Cli) *cobra.Command {
 	options := newNodeOptions()
+	filter := opts.NewFilterOpt()
 
 	cmd := &cobra.Command{
-		Use:   "update [OPTIONS] NODE",
-		Short: "Update a node",
-		Args:  cli.ExactArgs(1),
+		Use:   "update [OPTIONS] NODE [NODE...]",
+		Short: "Update one or more nodes",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if filter.Value().Len() == 0 {
+				return cli.RequiresMinArgs(1)(cmd, args)
+			}
+			if len(args) > 0 {
+				return errors.New("--filter cannot be used together with a list of nodes")
+			}
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runUpdate(dockerCli, cmd.Flags(), args[0])
+			return runUpdate(dockerCli, cmd.Flags(), args, filter)
 		},
 	}
 
@@ -35,14 +49,39 @@ func newUpdateCommand(dockerCli command.Cli) *cobra.Command {
 	flags.Var(&options.annotations.labels, flagLabelAdd, "Add or update a node label (key=value)")
 	labelKeys := opts.NewListOpts(nil)
 	flags.Var(&labelKeys, flagLabelRemove, "Remove a node label if exists")
+	flags.VarP(&filter, "filter", "f", "Update the nodes matching a filter instead of the nodes given as arguments")
 	return cmd
 }
 
-func runUpdate(dockerCli command.Cli, flags *pflag.FlagSet, nodeID string) error {
-	success := func(_ string) {
+func runUpdate(dockerCli command.Cli, flags *pflag.FlagSet, nodes []string, filter opts.FilterOpt) error {
+	if filter.Value().Len() > 0 {
+		var err error
+		if nodes, err = filterNodes(dockerCli, filter); err != nil {
+			return err
+		}
+	}
+	success := func(nodeID string) {
 		fmt.Fprintln(dockerCli.Out(), nodeID)
 	}
-	return updateNodes(dockerCli, []string{nodeID}, mergeNodeUpdate(flags), success)
+	return updateNodes(dockerCli, nodes, mergeNodeUpdate(flags), success)
+}
+
+// filterNodes returns the IDs of the nodes matching filter, sorted by
+// hostname.
+func filterNodes(dockerCli command.Cli, filter opts.FilterOpt) ([]string, error) {
+	nodes, err := dockerCli.Client().NodeList(context.Background(), types.NodeListOptions{Filters: filter.Value()})
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, errors.New("no nodes match the filter")
+	}
+	sort.Sort(byHostname(nodes))
+	ids := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		ids = append(ids, node.ID)
+	}
+	return ids, nil
 }
 
 func updateNodes(dockerCli command.Cli, nodes []string, mergeNode func(node *swarm.Node) error, success func(nodeID string)) error {
@@ -57,7 +96,7 @@ func updateNodes(dockerCli command.Cli, nodes []string, mergeNode func(node *swa
 
 		err = mergeNode(&node)
 		if err != nil {
-			if err == errNoRoleChange {
+			if err == errNoRoleChange || err == errNoChange {
 				continue
 			}
 			return err
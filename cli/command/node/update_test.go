@@ -22,11 +22,16 @@ func TestNodeUpdateErrors(t *testing.T) {
 		expectedError   string
 	}{
 		{
-			expectedError: "requires exactly 1 argument",
+			expectedError: "requires at least 1 argument",
 		},
 		{
-			args:          []string{"node1", "node2"},
-			expectedError: "requires exactly 1 argument",
+			args:          []string{"node1"},
+			flags:         map[string]string{"filter": "role=worker"},
+			expectedError: "--filter cannot be used together with a list of nodes",
+		},
+		{
+			flags:         map[string]string{"filter": "role=worker"},
+			expectedError: "no nodes match the filter",
 		},
 		{
 			args: []string{"nodeID"},
@@ -168,3 +173,54 @@ func TestNodeUpdate(t *testing.T) {
 		assert.NoError(t, cmd.Execute())
 	}
 }
+
+func TestNodeUpdateMultipleNodes(t *testing.T) {
+	var updated []string
+	cli := test.NewFakeCli(&fakeClient{
+		nodeUpdateFunc: func(nodeID string, version swarm.Version, node swarm.NodeSpec) error {
+			if node.Labels["zone"] != "a" {
+				return errors.Errorf("expected zone label to be a, got %v", node.Labels)
+			}
+			updated = append(updated, nodeID)
+			return nil
+		},
+	})
+	cmd := newUpdateCommand(cli)
+	cmd.SetArgs([]string{"node1", "node2"})
+	cmd.Flags().Set("label-add", "zone=a")
+	assert.NoError(t, cmd.Execute())
+	assert.Len(t, updated, 2)
+	assert.Equal(t, "node1\nnode2\n", cli.OutBuffer().String())
+}
+
+func TestNodeUpdateFilter(t *testing.T) {
+	var updated []string
+	nodes := []swarm.Node{
+		*Node(NodeID("nodeID2"), Hostname("worker-2")),
+		*Node(NodeID("nodeID1"), Hostname("worker-1")),
+	}
+	inspected := 0
+	cli := test.NewFakeCli(&fakeClient{
+		nodeListFunc: func() ([]swarm.Node, error) {
+			return append([]swarm.Node{}, nodes...), nil
+		},
+		nodeInspectFunc: func() (swarm.Node, []byte, error) {
+			node := nodes[1-inspected]
+			inspected++
+			return node, []byte{}, nil
+		},
+		nodeUpdateFunc: func(nodeID string, version swarm.Version, node swarm.NodeSpec) error {
+			updated = append(updated, nodeID)
+			if node.Availability != swarm.NodeAvailabilityPause {
+				return errors.Errorf("expected pause availability, got %s", node.Availability)
+			}
+			return nil
+		},
+	})
+	cmd := newUpdateCommand(cli)
+	cmd.Flags().Set("filter", "node.label=zone=a")
+	cmd.Flags().Set("availability", "pause")
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, []string{"nodeID1", "nodeID2"}, updated)
+	assert.Equal(t, "nodeID1\nnodeID2\n", cli.OutBuffer().String())
+}
@@ -0,0 +1,176 @@
+// Package placement evaluates the placement of service tasks on swarm nodes
+// the way the swarm scheduler does, so that scheduling decisions can be
+// explained from the CLI.
+package placement
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/docker/docker/api/types/swarm"
+)
+
+const (
+	eq = iota
+	noteq
+
+	nodeLabelPrefix   = "node.labels."
+	engineLabelPrefix = "engine.labels."
+)
+
+var (
+	alphaNumeric = regexp.MustCompile(`^(?i)[a-z_][a-z0-9\-_.]+$`)
+	// value can be alphanumeric and some special characters. it shouldn't
+	// contain current or future operators like '>, <, ~', etc.
+	valuePattern = regexp.MustCompile(`^(?i)[a-z0-9:\-_\s\.\*\(\)\?\+\[\]\\\^\$\|\/]+$`)
+
+	// operators defines the list of accepted operators, in the order they
+	// are looked for.
+	operators = []string{"==", "!="}
+)
+
+// Constraint is a placement constraint of a service, such as
+// "node.role==manager".
+type Constraint struct {
+	key      string
+	operator int
+	exp      string
+	raw      string
+}
+
+// ParseConstraints parses the placement constraints of a service, using the
+// same rules as the swarm managers.
+func ParseConstraints(env []string) ([]Constraint, error) {
+	constraints := make([]Constraint, 0, len(env))
+	for _, e := range env {
+		c, err := parseConstraint(e)
+		if err != nil {
+			return nil, err
+		}
+		constraints = append(constraints, c)
+	}
+	return constraints, nil
+}
+
+func parseConstraint(e string) (Constraint, error) {
+	for i, op := range operators {
+		parts := strings.SplitN(e, op, 2)
+		if len(parts) != 2 {
+			continue
+		}
+		key := strings.TrimSpace(parts[0])
+		if !alphaNumeric.MatchString(key) {
+			return Constraint{}, fmt.Errorf("key '%s' is invalid", key)
+		}
+		exp := strings.TrimSpace(parts[1])
+		if !valuePattern.MatchString(exp) {
+			return Constraint{}, fmt.Errorf("value '%s' is invalid", exp)
+		}
+		return Constraint{key: key, operator: i, exp: exp, raw: e}, nil
+	}
+	return Constraint{}, fmt.Errorf("constraint expected one operator from %s", strings.Join(operators, ", "))
+}
+
+// String returns the constraint as it was written in the service spec.
+func (c Constraint) String() string {
+	return c.raw
+}
+
+// match compares the expression of the constraint to the given values,
+// ignoring case as the swarm managers do.
+func (c Constraint) match(whats ...string) bool {
+	var match bool
+	for _, what := range whats {
+		if strings.EqualFold(c.exp, what) {
+			match = true
+			break
+		}
+	}
+	if c.operator == noteq {
+		return !match
+	}
+	return match
+}
+
+// Match returns whether node satisfies the constraint. Constraints on labels
+// that are not set on the node only match with the != operator.
+func (c Constraint) Match(node swarm.Node) bool {
+	switch key := strings.ToLower(c.key); {
+	case key == "node.id":
+		return c.match(node.ID)
+	case key == "node.hostname":
+		return c.match(node.Description.Hostname)
+	case key == "node.role":
+		return c.match(string(node.Spec.Role))
+	case key == "node.platform.os":
+		return c.match(node.Description.Platform.OS)
+	case key == "node.platform.arch":
+		return c.match(node.Description.Platform.Architecture)
+	case strings.HasPrefix(key, nodeLabelPrefix):
+		return c.matchLabel(node.Spec.Labels, c.key[len(nodeLabelPrefix):])
+	case strings.HasPrefix(key, engineLabelPrefix):
+		return c.matchLabel(node.Description.Engine.Labels, c.key[len(engineLabelPrefix):])
+	}
+	// unknown keys never match, as with the swarm managers
+	return false
+}
+
+func (c Constraint) matchLabel(labels map[string]string, label string) bool {
+	value, ok := labels[label]
+	if !ok {
+		return c.operator == noteq
+	}
+	return c.match(value)
+}
+
+// FailingConstraint returns the first of constraints that node does not
+// satisfy, if any.
+func FailingConstraint(constraints []Constraint, node swarm.Node) (Constraint, bool) {
+	for _, c := range constraints {
+		if !c.Match(node) {
+			return c, true
+		}
+	}
+	return Constraint{}, false
+}
+
+// MatchPlatforms returns whether node runs one of platforms. An empty list of
+// platforms matches all nodes.
+func MatchPlatforms(platforms []swarm.Platform, node swarm.Node) bool {
+	if len(platforms) == 0 {
+		return true
+	}
+	for _, p := range platforms {
+		if (p.OS == "" || strings.EqualFold(p.OS, node.Description.Platform.OS)) &&
+			(p.Architecture == "" || normalizeArch(p.Architecture) == normalizeArch(node.Description.Platform.Architecture)) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeArch maps the architecture reported by the nodes to the name used
+// in image platforms.
+func normalizeArch(arch string) string {
+	switch arch = strings.ToLower(arch); arch {
+	case "x86_64":
+		return "amd64"
+	case "aarch64":
+		return "arm64"
+	}
+	return arch
+}
+
+// KnownKey returns whether the key of the constraint is one the swarm
+// managers know about. Constraints with other keys never match.
+func (c Constraint) KnownKey() bool {
+	switch key := strings.ToLower(c.key); {
+	case key == "node.id", key == "node.hostname", key == "node.role",
+		key == "node.platform.os", key == "node.platform.arch":
+		return true
+	case strings.HasPrefix(key, nodeLabelPrefix), strings.HasPrefix(key, engineLabelPrefix):
+		return true
+	}
+	return false
+}
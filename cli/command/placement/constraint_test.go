@@ -0,0 +1,98 @@
+package placement
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConstraints(t *testing.T) {
+	constraints, err := ParseConstraints([]string{"node.role == manager", "node.labels.zone!=east"})
+	require.NoError(t, err)
+	assert.Equal(t, []Constraint{
+		{key: "node.role", operator: eq, exp: "manager", raw: "node.role == manager"},
+		{key: "node.labels.zone", operator: noteq, exp: "east", raw: "node.labels.zone!=east"},
+	}, constraints)
+}
+
+func TestParseConstraintsErrors(t *testing.T) {
+	testCases := []struct {
+		constraint    string
+		expectedError string
+	}{
+		{constraint: "node.role", expectedError: "constraint expected one operator from ==, !="},
+		{constraint: "node role==manager", expectedError: "key 'node role' is invalid"},
+		{constraint: "node.role==<manager>", expectedError: "value '<manager>' is invalid"},
+	}
+	for _, tc := range testCases {
+		_, err := ParseConstraints([]string{tc.constraint})
+		assert.EqualError(t, err, tc.expectedError)
+	}
+}
+
+func TestConstraintMatch(t *testing.T) {
+	node := swarm.Node{
+		ID: "node1",
+		Description: swarm.NodeDescription{
+			Hostname: "worker-1",
+			Platform: swarm.Platform{OS: "linux", Architecture: "x86_64"},
+			Engine: swarm.EngineDescription{
+				Labels: map[string]string{"storage": "ssd"},
+			},
+		},
+		Spec: swarm.NodeSpec{
+			Annotations: swarm.Annotations{Labels: map[string]string{"zone": "east"}},
+			Role:        swarm.NodeRoleWorker,
+		},
+	}
+
+	testCases := []struct {
+		constraint string
+		expected   bool
+	}{
+		{constraint: "node.id==node1", expected: true},
+		{constraint: "node.hostname==WORKER-1", expected: true},
+		{constraint: "node.hostname!=worker-1", expected: false},
+		{constraint: "node.role==manager", expected: false},
+		{constraint: "node.platform.os==linux", expected: true},
+		{constraint: "node.platform.arch==x86_64", expected: true},
+		{constraint: "node.labels.zone==east", expected: true},
+		{constraint: "node.labels.zone==west", expected: false},
+		{constraint: "node.labels.rack==1", expected: false},
+		{constraint: "node.labels.rack!=1", expected: true},
+		{constraint: "engine.labels.storage==ssd", expected: true},
+		{constraint: "unknown.key==value", expected: false},
+	}
+	for _, tc := range testCases {
+		constraints, err := ParseConstraints([]string{tc.constraint})
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, constraints[0].Match(node), tc.constraint)
+	}
+
+	constraints, err := ParseConstraints([]string{"node.role==worker", "node.labels.zone==west", "node.id==node2"})
+	require.NoError(t, err)
+	failing, failed := FailingConstraint(constraints, node)
+	assert.True(t, failed)
+	assert.Equal(t, "node.labels.zone==west", failing.String())
+}
+
+func TestMatchPlatforms(t *testing.T) {
+	node := swarm.Node{
+		Description: swarm.NodeDescription{
+			Platform: swarm.Platform{OS: "linux", Architecture: "x86_64"},
+		},
+	}
+	assert.True(t, MatchPlatforms(nil, node))
+	assert.True(t, MatchPlatforms([]swarm.Platform{{OS: "windows", Architecture: "amd64"}, {OS: "linux", Architecture: "amd64"}}, node))
+	assert.False(t, MatchPlatforms([]swarm.Platform{{OS: "linux", Architecture: "arm64"}}, node))
+}
+
+func TestConstraintKnownKey(t *testing.T) {
+	constraints, err := ParseConstraints([]string{"node.role==manager", "engine.labels.os==linux", "node.zone==east"})
+	require.NoError(t, err)
+	assert.True(t, constraints[0].KnownKey())
+	assert.True(t, constraints[1].KnownKey())
+	assert.False(t, constraints[2].KnownKey())
+}
@@ -0,0 +1,37 @@
+package placement
+
+import (
+	"fmt"
+
+	"github.com/docker/docker/api/types/swarm"
+)
+
+// Check returns an error explaining why the swarm managers would not
+// schedule a task with spec on node, or nil if they could. usage is the
+// current resource usage of the node, and may be nil.
+func Check(node swarm.Node, usage *Usage, spec swarm.TaskSpec) error {
+	if node.Status.State != swarm.NodeStateReady {
+		return fmt.Errorf("node is %s", node.Status.State)
+	}
+	if node.Spec.Availability != swarm.NodeAvailabilityActive {
+		return fmt.Errorf("node availability is %s", node.Spec.Availability)
+	}
+
+	if spec.Placement != nil {
+		constraints, err := ParseConstraints(spec.Placement.Constraints)
+		if err != nil {
+			return err
+		}
+		if c, failed := FailingConstraint(constraints, node); failed {
+			return fmt.Errorf("constraint %s is not satisfied", c)
+		}
+		if !MatchPlatforms(spec.Placement.Platforms, node) {
+			return fmt.Errorf("platform %s/%s is not supported by the image", node.Description.Platform.OS, node.Description.Platform.Architecture)
+		}
+	}
+
+	if spec.Resources != nil {
+		return CheckResources(Available(node, usage), spec.Resources.Reservations)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package placement
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck(t *testing.T) {
+	node := swarm.Node{
+		Description: swarm.NodeDescription{
+			Platform:  swarm.Platform{OS: "linux", Architecture: "x86_64"},
+			Resources: swarm.Resources{NanoCPUs: 1e9},
+		},
+		Spec: swarm.NodeSpec{
+			Role:         swarm.NodeRoleWorker,
+			Availability: swarm.NodeAvailabilityActive,
+		},
+		Status: swarm.NodeStatus{State: swarm.NodeStateReady},
+	}
+	spec := swarm.TaskSpec{
+		Placement: &swarm.Placement{Constraints: []string{"node.role==worker"}},
+		Resources: &swarm.ResourceRequirements{Reservations: &swarm.Resources{NanoCPUs: 5e8}},
+	}
+	assert.NoError(t, Check(node, nil, spec))
+
+	down := node
+	down.Status.State = swarm.NodeStateDown
+	assert.EqualError(t, Check(down, nil, spec), "node is down")
+
+	drained := node
+	drained.Spec.Availability = swarm.NodeAvailabilityDrain
+	assert.EqualError(t, Check(drained, nil, spec), "node availability is drain")
+
+	manager := node
+	manager.Spec.Role = swarm.NodeRoleManager
+	assert.EqualError(t, Check(manager, nil, spec), "constraint node.role==worker is not satisfied")
+
+	arm := spec
+	arm.Placement = &swarm.Placement{Platforms: []swarm.Platform{{OS: "linux", Architecture: "arm64"}}}
+	assert.EqualError(t, Check(node, nil, arm), "platform linux/x86_64 is not supported by the image")
+
+	busy := &Usage{Reservations: Resources{NanoCPUs: 75e7}}
+	assert.EqualError(t, Check(node, busy, spec), "insufficient CPU: 0.5 required, 0.25 available")
+}
@@ -0,0 +1,31 @@
+package placement
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/docker/docker/api/types/swarm"
+)
+
+// SpreadValue returns the value of the label used by a spread preference for
+// node, which is the group of nodes the swarm managers spread tasks over. The
+// second return value is false if the node doesn't have the label; these
+// nodes are grouped together.
+func SpreadValue(descriptor string, node swarm.Node) (string, bool, error) {
+	var (
+		labels map[string]string
+		key    = strings.ToLower(descriptor)
+	)
+	switch {
+	case strings.HasPrefix(key, nodeLabelPrefix):
+		labels = node.Spec.Labels
+		descriptor = descriptor[len(nodeLabelPrefix):]
+	case strings.HasPrefix(key, engineLabelPrefix):
+		labels = node.Description.Engine.Labels
+		descriptor = descriptor[len(engineLabelPrefix):]
+	default:
+		return "", false, fmt.Errorf("invalid spread descriptor %q: only node.labels and engine.labels are supported", descriptor)
+	}
+	value, ok := labels[descriptor]
+	return value, ok, nil
+}
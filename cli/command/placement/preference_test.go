@@ -0,0 +1,37 @@
+package placement
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpreadValue(t *testing.T) {
+	node := swarm.Node{
+		Description: swarm.NodeDescription{
+			Engine: swarm.EngineDescription{Labels: map[string]string{"az": "us-east-1a"}},
+		},
+		Spec: swarm.NodeSpec{
+			Annotations: swarm.Annotations{Labels: map[string]string{"rack": "r1"}},
+		},
+	}
+
+	value, ok, err := SpreadValue("node.labels.rack", node)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "r1", value)
+
+	value, ok, err = SpreadValue("engine.labels.az", node)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "us-east-1a", value)
+
+	_, ok, err = SpreadValue("node.labels.zone", node)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, err = SpreadValue("node.hostname", node)
+	assert.EqualError(t, err, `invalid spread descriptor "node.hostname": only node.labels and engine.labels are supported`)
+}
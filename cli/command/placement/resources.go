@@ -0,0 +1,171 @@
+package placement
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/swarm"
+	units "github.com/docker/go-units"
+)
+
+// Usage is the amount of resources used by the tasks on a node.
+type Usage struct {
+	// Tasks is the number of tasks that are running, or being started, on
+	// the node.
+	Tasks        int
+	Reservations Resources
+	Limits       Resources
+}
+
+// Resources is an amount of CPU, memory and generic resources. Generic
+// resources are counted by kind.
+type Resources struct {
+	NanoCPUs    int64
+	MemoryBytes int64
+	Generic     map[string]int64
+}
+
+// NewResources returns the amount of resources described by r.
+func NewResources(r *swarm.Resources) Resources {
+	res := Resources{Generic: map[string]int64{}}
+	if r == nil {
+		return res
+	}
+	res.NanoCPUs = r.NanoCPUs
+	res.MemoryBytes = r.MemoryBytes
+	for _, g := range r.GenericResources {
+		switch {
+		case g.DiscreteResourceSpec != nil:
+			res.Generic[g.DiscreteResourceSpec.Kind] += g.DiscreteResourceSpec.Value
+		case g.NamedResourceSpec != nil:
+			// each named resource is a single unit of its kind
+			res.Generic[g.NamedResourceSpec.Kind]++
+		}
+	}
+	return res
+}
+
+func (r *Resources) add(o Resources) {
+	r.NanoCPUs += o.NanoCPUs
+	r.MemoryBytes += o.MemoryBytes
+	for kind, value := range o.Generic {
+		r.Generic[kind] += value
+	}
+}
+
+// Sub returns the resources of r that are left after subtracting o.
+func (r Resources) Sub(o Resources) Resources {
+	res := Resources{
+		NanoCPUs:    r.NanoCPUs - o.NanoCPUs,
+		MemoryBytes: r.MemoryBytes - o.MemoryBytes,
+		Generic:     make(map[string]int64, len(r.Generic)),
+	}
+	for kind, value := range r.Generic {
+		res.Generic[kind] = value - o.Generic[kind]
+	}
+	for kind, value := range o.Generic {
+		if _, ok := r.Generic[kind]; !ok {
+			res.Generic[kind] = -value
+		}
+	}
+	return res
+}
+
+// FormatCPUs formats an amount of nano CPUs as a number of CPUs.
+func FormatCPUs(nanoCPUs int64) string {
+	return strconv.FormatFloat(float64(nanoCPUs)/1e9, 'f', -1, 64)
+}
+
+// FormatGeneric formats generic resources as a sorted list of kind=value.
+func FormatGeneric(generic map[string]int64) string {
+	kinds := make([]string, 0, len(generic))
+	for kind := range generic {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	values := make([]string, 0, len(kinds))
+	for _, kind := range kinds {
+		values = append(values, fmt.Sprintf("%s=%d", kind, generic[kind]))
+	}
+	return strings.Join(values, ", ")
+}
+
+// isActive returns whether a task holds resources on its node: the swarm
+// managers reserve resources for a task from the moment it is assigned to a
+// node, until it has stopped.
+func isActive(task swarm.Task) bool {
+	if task.NodeID == "" || task.DesiredState != swarm.TaskStateRunning {
+		return false
+	}
+	switch task.Status.State {
+	case swarm.TaskStateComplete, swarm.TaskStateShutdown, swarm.TaskStateFailed, swarm.TaskStateRejected:
+		return false
+	}
+	return true
+}
+
+// NodeUsage aggregates the resource reservations and limits of tasks by node
+// ID. Only the tasks that hold resources on their node are counted.
+func NodeUsage(tasks []swarm.Task) map[string]*Usage {
+	usage := map[string]*Usage{}
+	for _, task := range tasks {
+		if !isActive(task) {
+			continue
+		}
+		u, ok := usage[task.NodeID]
+		if !ok {
+			u = &Usage{
+				Reservations: Resources{Generic: map[string]int64{}},
+				Limits:       Resources{Generic: map[string]int64{}},
+			}
+			usage[task.NodeID] = u
+		}
+		u.Tasks++
+		if res := task.Spec.Resources; res != nil {
+			u.Reservations.add(NewResources(res.Reservations))
+			u.Limits.add(NewResources(res.Limits))
+		}
+	}
+	return usage
+}
+
+// Available returns the resources of node that are not reserved by tasks.
+func Available(node swarm.Node, usage *Usage) Resources {
+	total := NewResources(&node.Description.Resources)
+	if usage == nil {
+		return total
+	}
+	return total.Sub(usage.Reservations)
+}
+
+// CheckResources returns an error describing why the reservations don't fit
+// in the available resources of a node, if they don't.
+func CheckResources(available Resources, reservations *swarm.Resources) error {
+	required := NewResources(reservations)
+	if required.NanoCPUs > available.NanoCPUs {
+		return fmt.Errorf("insufficient CPU: %s required, %s available", FormatCPUs(required.NanoCPUs), FormatCPUs(maxInt64(available.NanoCPUs, 0)))
+	}
+	if required.MemoryBytes > available.MemoryBytes {
+		return fmt.Errorf("insufficient memory: %s required, %s available", units.BytesSize(float64(required.MemoryBytes)), units.BytesSize(float64(maxInt64(available.MemoryBytes, 0))))
+	}
+	kinds := make([]string, 0, len(required.Generic))
+	for kind := range required.Generic {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		if required.Generic[kind] > available.Generic[kind] {
+			return fmt.Errorf("insufficient %s: %d required, %d available", kind, required.Generic[kind], maxInt64(available.Generic[kind], 0))
+		}
+	}
+	return nil
+}
+
+func maxInt64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
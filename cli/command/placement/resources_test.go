@@ -0,0 +1,72 @@
+package placement
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/stretchr/testify/assert"
+)
+
+func gpus(n int64) []swarm.GenericResource {
+	return []swarm.GenericResource{{DiscreteResourceSpec: &swarm.DiscreteGenericResource{Kind: "gpu", Value: n}}}
+}
+
+func task(nodeID string, state swarm.TaskState, reservations, limits *swarm.Resources) swarm.Task {
+	return swarm.Task{
+		NodeID:       nodeID,
+		DesiredState: swarm.TaskStateRunning,
+		Status:       swarm.TaskStatus{State: state},
+		Spec: swarm.TaskSpec{
+			Resources: &swarm.ResourceRequirements{Reservations: reservations, Limits: limits},
+		},
+	}
+}
+
+func TestNodeUsage(t *testing.T) {
+	tasks := []swarm.Task{
+		task("node1", swarm.TaskStateRunning, &swarm.Resources{NanoCPUs: 5e8, MemoryBytes: 1 << 20, GenericResources: gpus(1)}, &swarm.Resources{NanoCPUs: 1e9}),
+		task("node1", swarm.TaskStatePreparing, &swarm.Resources{NanoCPUs: 25e7}, nil),
+		// stopped tasks and tasks that are not assigned to a node don't
+		// hold resources
+		task("node1", swarm.TaskStateFailed, &swarm.Resources{NanoCPUs: 1e9}, nil),
+		task("", swarm.TaskStatePending, &swarm.Resources{NanoCPUs: 1e9}, nil),
+		task("node2", swarm.TaskStateRunning, nil, nil),
+	}
+
+	usage := NodeUsage(tasks)
+	assert.Len(t, usage, 2)
+	assert.Equal(t, Usage{
+		Tasks:        2,
+		Reservations: Resources{NanoCPUs: 75e7, MemoryBytes: 1 << 20, Generic: map[string]int64{"gpu": 1}},
+		Limits:       Resources{NanoCPUs: 1e9, Generic: map[string]int64{}},
+	}, *usage["node1"])
+	assert.Equal(t, 1, usage["node2"].Tasks)
+}
+
+func TestAvailableAndCheckResources(t *testing.T) {
+	node := swarm.Node{
+		Description: swarm.NodeDescription{
+			Resources: swarm.Resources{
+				NanoCPUs:    2e9,
+				MemoryBytes: 4 << 30,
+				GenericResources: []swarm.GenericResource{
+					{NamedResourceSpec: &swarm.NamedGenericResource{Kind: "gpu", Value: "uuid-1"}},
+					{NamedResourceSpec: &swarm.NamedGenericResource{Kind: "gpu", Value: "uuid-2"}},
+				},
+			},
+		},
+	}
+	usage := &Usage{
+		Reservations: Resources{NanoCPUs: 15e8, MemoryBytes: 1 << 30, Generic: map[string]int64{"gpu": 1}},
+	}
+
+	available := Available(node, usage)
+	assert.Equal(t, Resources{NanoCPUs: 5e8, MemoryBytes: 3 << 30, Generic: map[string]int64{"gpu": 1}}, available)
+	assert.Equal(t, "0.5", FormatCPUs(available.NanoCPUs))
+	assert.Equal(t, "gpu=1", FormatGeneric(available.Generic))
+
+	assert.NoError(t, CheckResources(available, &swarm.Resources{NanoCPUs: 5e8, MemoryBytes: 1 << 30, GenericResources: gpus(1)}))
+	assert.EqualError(t, CheckResources(available, &swarm.Resources{NanoCPUs: 1e9}), "insufficient CPU: 1 required, 0.5 available")
+	assert.EqualError(t, CheckResources(available, &swarm.Resources{MemoryBytes: 4 << 30}), "insufficient memory: 4GiB required, 3GiB available")
+	assert.EqualError(t, CheckResources(available, &swarm.Resources{GenericResources: gpus(2)}), "insufficient gpu: 2 required, 1 available")
+}
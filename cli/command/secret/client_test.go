@@ -1,6 +1,7 @@
 package secret
 
 import (
+	"github.com/docker/cli/cli/command"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/swarm"
 	"github.com/docker/docker/client"
@@ -13,6 +14,8 @@ type fakeClient struct {
 	secretInspectFunc func(string) (swarm.Secret, []byte, error)
 	secretListFunc    func(types.SecretListOptions) ([]swarm.Secret, error)
 	secretRemoveFunc  func(string) error
+	serviceListFunc   func(types.ServiceListOptions) ([]swarm.Service, error)
+	serviceUpdateFunc func(string, swarm.Version, swarm.ServiceSpec) (types.ServiceUpdateResponse, error)
 }
 
 func (c *fakeClient) SecretCreate(ctx context.Context, spec swarm.SecretSpec) (types.SecretCreateResponse, error) {
@@ -42,3 +45,29 @@ func (c *fakeClient) SecretRemove(ctx context.Context, name string) error {
 	}
 	return nil
 }
+
+func (c *fakeClient) ServiceList(ctx context.Context, options types.ServiceListOptions) ([]swarm.Service, error) {
+	if c.serviceListFunc != nil {
+		return c.serviceListFunc(options)
+	}
+	return []swarm.Service{}, nil
+}
+
+func (c *fakeClient) ServiceUpdate(ctx context.Context, serviceID string, version swarm.Version, service swarm.ServiceSpec, options types.ServiceUpdateOptions) (types.ServiceUpdateResponse, error) {
+	if c.serviceUpdateFunc != nil {
+		return c.serviceUpdateFunc(serviceID, version, service)
+	}
+	return types.ServiceUpdateResponse{}, nil
+}
+
+type fakeTemplatingClient struct {
+	command.TemplatingClient
+	secretCreateFunc func(command.SecretSpecWithTemplating) (types.SecretCreateResponse, error)
+}
+
+func (c *fakeTemplatingClient) SecretCreate(ctx context.Context, spec command.SecretSpecWithTemplating) (types.SecretCreateResponse, error) {
+	if c.secretCreateFunc != nil {
+		return c.secretCreateFunc(spec)
+	}
+	return types.SecretCreateResponse{}, nil
+}
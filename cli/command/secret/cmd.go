@@ -20,7 +20,9 @@ func NewSecretCommand(dockerCli command.Cli) *cobra.Command {
 		newSecretListCommand(dockerCli),
 		newSecretCreateCommand(dockerCli),
 		newSecretInspectCommand(dockerCli),
+		newSecretPruneCommand(dockerCli),
 		newSecretRemoveCommand(dockerCli),
+		newSecretRotateCommand(dockerCli),
 	)
 	return cmd
 }
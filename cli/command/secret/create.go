@@ -8,18 +8,61 @@ import (
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/opts"
+	"github.com/docker/cli/secret/source"
+	"github.com/docker/cli/templates"
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/swarm"
 	"github.com/docker/docker/pkg/system"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"golang.org/x/net/context"
 )
 
 type createOptions struct {
-	name   string
-	driver string
-	file   string
-	labels opts.ListOpts
+	name           string
+	driver         string
+	templateDriver string
+	file           string
+	labels         opts.ListOpts
+	source         sourceOptions
+}
+
+// sourceOptions select a source of the value of a secret, which is used
+// instead of a file.
+type sourceOptions struct {
+	fromEnv  string
+	generate string
+	from     string
+}
+
+func addSourceFlags(flags *pflag.FlagSet, options *sourceOptions) {
+	flags.StringVar(&options.fromEnv, "from-env", "", "Read the secret from an environment variable")
+	flags.StringVar(&options.generate, "generate", "", `Generate a random secret ("bytes"|"base64"|"passphrase")[:length]`)
+	flags.StringVar(&options.from, "from", "", `Read the secret from a source ("env:VAR"|"exec:COMMAND"|"generate:KIND[:LENGTH]")`)
+}
+
+// source returns the source selected by the options, or nil if no source is
+// selected.
+func (o sourceOptions) source() (source.Source, error) {
+	var specs []string
+	if o.fromEnv != "" {
+		specs = append(specs, "env:"+o.fromEnv)
+	}
+	if o.generate != "" {
+		specs = append(specs, "generate:"+o.generate)
+	}
+	if o.from != "" {
+		specs = append(specs, o.from)
+	}
+	switch len(specs) {
+	case 0:
+		return nil, nil
+	case 1:
+		return source.Parse(specs[0])
+	default:
+		return nil, errors.New("only one of --from-env, --generate and --from can be used")
+	}
 }
 
 func newSecretCreateCommand(dockerCli command.Cli) *cobra.Command {
@@ -43,6 +86,9 @@ func newSecretCreateCommand(dockerCli command.Cli) *cobra.Command {
 	flags.VarP(&options.labels, "label", "l", "Secret labels")
 	flags.StringVarP(&options.driver, "driver", "d", "", "Secret driver")
 	flags.SetAnnotation("driver", "version", []string{"1.31"})
+	flags.StringVar(&options.templateDriver, "template-driver", "", "Template driver")
+	flags.SetAnnotation("template-driver", "version", []string{"1.37"})
+	addSourceFlags(flags, &options.source)
 
 	return cmd
 }
@@ -51,13 +97,22 @@ func runSecretCreate(dockerCli command.Cli, options createOptions) error {
 	client := dockerCli.Client()
 	ctx := context.Background()
 
-	if options.driver != "" && options.file != "" {
+	src, err := options.source.source()
+	if err != nil {
+		return err
+	}
+	if options.driver != "" && (options.file != "" || src != nil) {
 		return errors.Errorf("When using secret driver secret data must be empty")
 	}
 
-	secretData, err := readSecretData(dockerCli.In(), options.file)
+	secretData, err := readSecretValue(dockerCli.In(), options.file, src)
 	if err != nil {
-		return errors.Errorf("Error reading content from %q: %v", options.file, err)
+		return err
+	}
+	if options.templateDriver == templates.PayloadTemplateDriver {
+		if _, err := templates.ParsePayload(string(secretData), templates.PayloadFunctions{}); err != nil {
+			return errors.Wrap(err, "invalid secret template")
+		}
 	}
 	spec := swarm.SecretSpec{
 		Annotations: swarm.Annotations{
@@ -72,7 +127,15 @@ func runSecretCreate(dockerCli command.Cli, options createOptions) error {
 		}
 	}
 
-	r, err := client.SecretCreate(ctx, spec)
+	var r types.SecretCreateResponse
+	if options.templateDriver != "" {
+		r, err = dockerCli.TemplatingClient().SecretCreate(ctx, command.SecretSpecWithTemplating{
+			SecretSpec: spec,
+			Templating: &swarm.Driver{Name: options.templateDriver},
+		})
+	} else {
+		r, err = client.SecretCreate(ctx, spec)
+	}
 	if err != nil {
 		return err
 	}
@@ -81,6 +144,26 @@ func runSecretCreate(dockerCli command.Cli, options createOptions) error {
 	return nil
 }
 
+// readSecretValue reads the value of a secret from src, or from file if src
+// is nil.
+func readSecretValue(in io.ReadCloser, file string, src source.Source) ([]byte, error) {
+	if src == nil {
+		data, err := readSecretData(in, file)
+		if err != nil {
+			return nil, errors.Errorf("Error reading content from %q: %v", file, err)
+		}
+		return data, nil
+	}
+	if file != "" {
+		return nil, errors.New("a secret source cannot be used together with a file")
+	}
+	data, err := src.Read()
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading secret from source")
+	}
+	return data, nil
+}
+
 func readSecretData(in io.ReadCloser, file string) ([]byte, error) {
 	// Read secret value from external driver
 	if file == "" {
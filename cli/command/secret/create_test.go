@@ -2,11 +2,13 @@ package secret
 
 import (
 	"io/ioutil"
+	"os"
 	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/internal/test"
 	"github.com/docker/cli/internal/test/testutil"
 	"github.com/docker/docker/api/types"
@@ -133,3 +135,120 @@ func TestSecretCreateWithLabels(t *testing.T) {
 	assert.NoError(t, cmd.Execute())
 	assert.Equal(t, "ID-"+name, strings.TrimSpace(cli.OutBuffer().String()))
 }
+
+func TestSecretCreateWithTemplatingDriver(t *testing.T) {
+	var actual command.SecretSpecWithTemplating
+	cli := test.NewFakeCli(&fakeClient{
+		secretCreateFunc: func(spec swarm.SecretSpec) (types.SecretCreateResponse, error) {
+			return types.SecretCreateResponse{}, errors.Errorf("unexpected creation of the secret without its template driver")
+		},
+	})
+	cli.SetTemplatingClient(&fakeTemplatingClient{
+		secretCreateFunc: func(spec command.SecretSpecWithTemplating) (types.SecretCreateResponse, error) {
+			actual = spec
+			return types.SecretCreateResponse{ID: "ID-" + spec.Name}, nil
+		},
+	})
+	cli.SetIn(command.NewInStream(ioutil.NopCloser(strings.NewReader(`{{secret "password"}}@{{.Service.Name}}`))))
+
+	cmd := newSecretCreateCommand(cli)
+	cmd.SetArgs([]string{"foo", "-"})
+	cmd.Flags().Set("template-driver", "golang")
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "ID-foo", strings.TrimSpace(cli.OutBuffer().String()))
+	assert.Equal(t, "foo", actual.Name)
+	assert.Equal(t, &swarm.Driver{Name: "golang"}, actual.Templating)
+}
+
+func TestSecretCreateWithInvalidTemplate(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{})
+	cli.SetIn(command.NewInStream(ioutil.NopCloser(strings.NewReader(`{{.Service.Name`))))
+
+	cmd := newSecretCreateCommand(cli)
+	cmd.SetArgs([]string{"foo", "-"})
+	cmd.Flags().Set("template-driver", "golang")
+	cmd.SetOutput(ioutil.Discard)
+	testutil.ErrorContains(t, cmd.Execute(), "invalid secret template")
+}
+
+func TestSecretCreateFromSource(t *testing.T) {
+	defer os.Unsetenv("SECRET_CREATE_TEST")
+	os.Setenv("SECRET_CREATE_TEST", "from-env")
+
+	testCases := []struct {
+		flags    map[string]string
+		expected func(data []byte) bool
+	}{
+		{
+			flags:    map[string]string{"from-env": "SECRET_CREATE_TEST"},
+			expected: func(data []byte) bool { return string(data) == "from-env" },
+		},
+		{
+			flags:    map[string]string{"from": "env:SECRET_CREATE_TEST"},
+			expected: func(data []byte) bool { return string(data) == "from-env" },
+		},
+		{
+			flags:    map[string]string{"generate": "bytes:24"},
+			expected: func(data []byte) bool { return len(data) == 24 },
+		},
+	}
+	for _, tc := range testCases {
+		var actual []byte
+		cli := test.NewFakeCli(&fakeClient{
+			secretCreateFunc: func(spec swarm.SecretSpec) (types.SecretCreateResponse, error) {
+				actual = spec.Data
+				return types.SecretCreateResponse{ID: "ID-" + spec.Name}, nil
+			},
+		})
+		cmd := newSecretCreateCommand(cli)
+		cmd.SetArgs([]string{"foo"})
+		for key, value := range tc.flags {
+			cmd.Flags().Set(key, value)
+		}
+		assert.NoError(t, cmd.Execute())
+		assert.True(t, tc.expected(actual), "unexpected secret data %q for %v", actual, tc.flags)
+	}
+}
+
+func TestSecretCreateFromSourceErrors(t *testing.T) {
+	testCases := []struct {
+		args          []string
+		flags         map[string]string
+		expectedError string
+	}{
+		{
+			args:          []string{"foo", "-"},
+			flags:         map[string]string{"generate": "base64"},
+			expectedError: "a secret source cannot be used together with a file",
+		},
+		{
+			args:          []string{"foo"},
+			flags:         map[string]string{"generate": "base64", "from-env": "PASSWORD"},
+			expectedError: "only one of --from-env, --generate and --from can be used",
+		},
+		{
+			args:          []string{"foo"},
+			flags:         map[string]string{"generate": "base64", "driver": "vault"},
+			expectedError: "secret data must be empty",
+		},
+		{
+			args:          []string{"foo"},
+			flags:         map[string]string{"from": "env:SECRET_CREATE_TEST_UNSET"},
+			expectedError: "error reading secret from source: environment variable SECRET_CREATE_TEST_UNSET is not set",
+		},
+		{
+			args:          []string{"foo"},
+			flags:         map[string]string{"from": "vault:secret/db"},
+			expectedError: `unknown scheme "vault"`,
+		},
+	}
+	for _, tc := range testCases {
+		cmd := newSecretCreateCommand(test.NewFakeCli(&fakeClient{}))
+		cmd.SetArgs(tc.args)
+		for key, value := range tc.flags {
+			cmd.Flags().Set(key, value)
+		}
+		cmd.SetOutput(ioutil.Discard)
+		testutil.ErrorContains(t, cmd.Execute(), tc.expectedError)
+	}
+}
@@ -0,0 +1,61 @@
+package secret
+
+import (
+	"github.com/docker/cli/cli/command/servicedata"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/docker/docker/client"
+	"golang.org/x/net/context"
+)
+
+// secretKind implements servicedata.Kind for secrets.
+type secretKind struct {
+	client client.APIClient
+}
+
+var _ servicedata.Kind = secretKind{}
+
+func (secretKind) Name() string {
+	return "secret"
+}
+
+func (k secretKind) List(ctx context.Context, filters filters.Args) ([]servicedata.Object, error) {
+	secrets, err := k.client.SecretList(ctx, types.SecretListOptions{Filters: filters})
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]servicedata.Object, 0, len(secrets))
+	for _, secret := range secrets {
+		objects = append(objects, servicedata.Object{ID: secret.ID, Name: secret.Spec.Name})
+	}
+	return objects, nil
+}
+
+func (k secretKind) Remove(ctx context.Context, id string) error {
+	return k.client.SecretRemove(ctx, id)
+}
+
+func (secretKind) ReplaceReferences(spec *swarm.ContainerSpec, oldID, newID, newName string) bool {
+	var replaced bool
+	for _, ref := range spec.Secrets {
+		if ref.SecretID == oldID {
+			ref.SecretID = newID
+			ref.SecretName = newName
+			replaced = true
+		}
+	}
+	return replaced
+}
+
+func (secretKind) References(spec *swarm.ContainerSpec) []servicedata.Reference {
+	refs := make([]servicedata.Reference, 0, len(spec.Secrets))
+	for _, ref := range spec.Secrets {
+		var target string
+		if ref.File != nil {
+			target = ref.File.Name
+		}
+		refs = append(refs, servicedata.Reference{ID: ref.SecretID, Target: target})
+	}
+	return refs
+}
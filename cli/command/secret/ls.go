@@ -6,6 +6,7 @@ import (
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/command/formatter"
+	"github.com/docker/cli/cli/command/servicedata"
 	"github.com/docker/cli/opts"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/swarm"
@@ -53,7 +54,13 @@ func runSecretList(dockerCli command.Cli, options listOptions) error {
 	client := dockerCli.Client()
 	ctx := context.Background()
 
-	secrets, err := client.SecretList(ctx, types.SecretListOptions{Filters: options.filter.Value()})
+	listFilters := options.filter.Value()
+	used, err := servicedata.NewUsedFilter(listFilters)
+	if err != nil {
+		return err
+	}
+
+	secrets, err := client.SecretList(ctx, types.SecretListOptions{Filters: listFilters})
 	if err != nil {
 		return err
 	}
@@ -66,11 +73,25 @@ func runSecretList(dockerCli command.Cli, options listOptions) error {
 		}
 	}
 
+	var usage servicedata.Usage
+	if used.IsSet() || servicedata.FormatUsesServices(format) {
+		if usage, err = servicedata.ServiceUsage(ctx, client, secretKind{client: client}); err != nil {
+			return err
+		}
+	}
+	filtered := secrets[:0]
+	for _, secret := range secrets {
+		if used.Match(usage, secret.ID) {
+			filtered = append(filtered, secret)
+		}
+	}
+	secrets = filtered
+
 	sort.Sort(bySecretName(secrets))
 
 	secretCtx := formatter.Context{
 		Output: dockerCli.Out(),
 		Format: formatter.NewSecretFormat(format, options.quiet),
 	}
-	return formatter.SecretWrite(secretCtx, secrets)
+	return formatter.SecretWrite(secretCtx, secrets, usage)
 }
@@ -158,3 +158,90 @@ func TestSecretListWithFilter(t *testing.T) {
 	assert.NoError(t, cmd.Execute())
 	golden.Assert(t, cli.OutBuffer().String(), "secret-list-with-filter.golden")
 }
+
+func secretUsageServices() []swarm.Service {
+	return []swarm.Service{
+		{
+			Spec: swarm.ServiceSpec{
+				Annotations: swarm.Annotations{Name: "web"},
+				TaskTemplate: swarm.TaskSpec{ContainerSpec: &swarm.ContainerSpec{
+					Secrets: []*swarm.SecretReference{
+						{SecretID: "ID-foo", SecretName: "foo", File: &swarm.SecretReferenceFileTarget{Name: "foo"}},
+					},
+				}},
+			},
+		},
+		{
+			Spec: swarm.ServiceSpec{
+				Annotations: swarm.Annotations{Name: "api"},
+				TaskTemplate: swarm.TaskSpec{ContainerSpec: &swarm.ContainerSpec{
+					Secrets: []*swarm.SecretReference{
+						{SecretID: "ID-foo", SecretName: "foo", File: &swarm.SecretReferenceFileTarget{Name: "db_password"}},
+					},
+				}},
+			},
+		},
+	}
+}
+
+func TestSecretListWithServices(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		secretListFunc: func(options types.SecretListOptions) ([]swarm.Secret, error) {
+			return []swarm.Secret{
+				*Secret(SecretID("ID-foo"), SecretName("foo")),
+				*Secret(SecretID("ID-bar"), SecretName("bar")),
+			}, nil
+		},
+		serviceListFunc: func(options types.ServiceListOptions) ([]swarm.Service, error) {
+			return secretUsageServices(), nil
+		},
+	})
+	cmd := newSecretListCommand(cli)
+	cmd.Flags().Set("format", "{{ .Name }}={{ .Services }}")
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "bar=\nfoo=api:db_password, web:foo\n", cli.OutBuffer().String())
+}
+
+func TestSecretListWithJSONFormat(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		secretListFunc: func(options types.SecretListOptions) ([]swarm.Secret, error) {
+			return []swarm.Secret{*Secret(SecretID("ID-foo"), SecretName("foo"))}, nil
+		},
+		serviceListFunc: func(options types.ServiceListOptions) ([]swarm.Service, error) {
+			return secretUsageServices(), nil
+		},
+	})
+	cmd := newSecretListCommand(cli)
+	cmd.Flags().Set("format", "{{json .}}")
+	assert.NoError(t, cmd.Execute())
+	assert.Contains(t, cli.OutBuffer().String(), `"Services":"api:db_password, web:foo"`)
+}
+
+func TestSecretListWithUsedFilter(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		secretListFunc: func(options types.SecretListOptions) ([]swarm.Secret, error) {
+			assert.False(t, options.Filters.Include("used"))
+			assert.Equal(t, []string{"foo"}, options.Filters.Get("name"))
+			return []swarm.Secret{
+				*Secret(SecretID("ID-foo"), SecretName("foo")),
+				*Secret(SecretID("ID-foo-2"), SecretName("foo-2")),
+			}, nil
+		},
+		serviceListFunc: func(options types.ServiceListOptions) ([]swarm.Service, error) {
+			return secretUsageServices(), nil
+		},
+	})
+	cmd := newSecretListCommand(cli)
+	cmd.Flags().Set("filter", "name=foo")
+	cmd.Flags().Set("filter", "used=false")
+	cmd.Flags().Set("format", "{{ .Name }}")
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "foo-2\n", cli.OutBuffer().String())
+}
+
+func TestSecretListWithInvalidUsedFilter(t *testing.T) {
+	cmd := newSecretListCommand(test.NewFakeCli(&fakeClient{}))
+	cmd.Flags().Set("filter", "used=maybe")
+	cmd.SetOutput(ioutil.Discard)
+	testutil.ErrorContains(t, cmd.Execute(), "invalid filter 'used=maybe'")
+}
@@ -0,0 +1,26 @@
+package secret
+
+import (
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/servicedata"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+func newSecretPruneCommand(dockerCli command.Cli) *cobra.Command {
+	options := servicedata.NewPruneOptions()
+
+	cmd := &cobra.Command{
+		Use:   "prune [OPTIONS]",
+		Short: "Remove all unused secrets",
+		Args:  cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return servicedata.Prune(context.Background(), dockerCli, secretKind{client: dockerCli.Client()}, options)
+		},
+	}
+
+	servicedata.AddPruneFlags(cmd.Flags(), &options)
+
+	return cmd
+}
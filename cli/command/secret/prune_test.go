@@ -0,0 +1,75 @@
+package secret
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/pkg/errors"
+	// Import builders to get the builder function as package function
+	. "github.com/docker/cli/internal/test/builders"
+	"github.com/docker/cli/internal/test/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func pruneTestClient(removed *[]string) *fakeClient {
+	return &fakeClient{
+		secretListFunc: func(options types.SecretListOptions) ([]swarm.Secret, error) {
+			return []swarm.Secret{
+				*Secret(SecretID("ID-foo"), SecretName("foo")),
+				*Secret(SecretID("ID-foo-2"), SecretName("foo-2")),
+				*Secret(SecretID("ID-bar"), SecretName("bar")),
+			}, nil
+		},
+		serviceListFunc: func(options types.ServiceListOptions) ([]swarm.Service, error) {
+			return secretUsageServices(), nil
+		},
+		secretRemoveFunc: func(id string) error {
+			*removed = append(*removed, id)
+			return nil
+		},
+	}
+}
+
+func TestSecretPrune(t *testing.T) {
+	var removed []string
+	cli := test.NewFakeCli(pruneTestClient(&removed))
+	cmd := newSecretPruneCommand(cli)
+	cmd.Flags().Set("force", "true")
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, []string{"ID-bar", "ID-foo-2"}, removed)
+	assert.Equal(t, "Deleted Secrets:\nbar\nfoo-2\n", cli.OutBuffer().String())
+}
+
+func TestSecretPruneNotConfirmed(t *testing.T) {
+	var removed []string
+	cli := test.NewFakeCli(pruneTestClient(&removed))
+	cli.SetIn(command.NewInStream(ioutil.NopCloser(strings.NewReader("n\n"))))
+	cmd := newSecretPruneCommand(cli)
+	assert.NoError(t, cmd.Execute())
+	assert.Empty(t, removed)
+	assert.Contains(t, cli.OutBuffer().String(), "WARNING! This will remove all secrets not used by any service.")
+}
+
+func TestSecretPruneRemoveFailed(t *testing.T) {
+	var removed []string
+	client := pruneTestClient(&removed)
+	client.secretRemoveFunc = func(id string) error {
+		if id == "ID-bar" {
+			return errors.Errorf("error removing secret bar")
+		}
+		removed = append(removed, id)
+		return nil
+	}
+	cli := test.NewFakeCli(client)
+	cmd := newSecretPruneCommand(cli)
+	cmd.Flags().Set("force", "true")
+	cmd.SetOutput(ioutil.Discard)
+	testutil.ErrorContains(t, cmd.Execute(), "error removing secret bar")
+	assert.Equal(t, []string{"ID-foo-2"}, removed)
+	assert.Equal(t, "Deleted Secrets:\nfoo-2\n", cli.OutBuffer().String())
+}
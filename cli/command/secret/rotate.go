@@ -0,0 +1,73 @@
+package secret
+
+import (
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/servicedata"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+type rotateOptions struct {
+	secret string
+	file   string
+	source sourceOptions
+	rotate servicedata.RotateOptions
+}
+
+func newSecretRotateCommand(dockerCli command.Cli) *cobra.Command {
+	options := rotateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "rotate [OPTIONS] SECRET [file|-]",
+		Short: "Replace a secret with a new version, and update the services using it",
+		Args:  cli.RequiresRangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.secret = args[0]
+			if len(args) == 2 {
+				options.file = args[1]
+			}
+			return runSecretRotate(dockerCli, options)
+		},
+	}
+	flags := cmd.Flags()
+	servicedata.AddRotateFlags(flags, secretKind{}, &options.rotate)
+	addSourceFlags(flags, &options.source)
+
+	return cmd
+}
+
+func runSecretRotate(dockerCli command.Cli, options rotateOptions) error {
+	client := dockerCli.Client()
+	ctx := context.Background()
+
+	old, _, err := client.SecretInspectWithRaw(ctx, options.secret)
+	if err != nil {
+		return err
+	}
+	src, err := options.source.source()
+	if err != nil {
+		return err
+	}
+	if old.Spec.Driver != nil && (options.file != "" || src != nil) {
+		return errors.Errorf("When using secret driver secret data must be empty")
+	}
+	if old.Spec.Driver == nil && options.file == "" && src == nil {
+		return errors.Errorf("secret %s is not managed by a secret driver, the new secret data must be provided", old.Spec.Name)
+	}
+
+	secretData, err := readSecretValue(dockerCli.In(), options.file, src)
+	if err != nil {
+		return err
+	}
+
+	create := func(name string) (string, error) {
+		spec := old.Spec
+		spec.Name = name
+		spec.Data = secretData
+		r, err := client.SecretCreate(ctx, spec)
+		return r.ID, err
+	}
+	return servicedata.Rotate(ctx, dockerCli, secretKind{client: client}, servicedata.Object{ID: old.ID, Name: old.Spec.Name}, create, options.rotate)
+}
@@ -0,0 +1,166 @@
+package secret
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/cli/internal/test/testutil"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func rotateTestSecret(name string) (swarm.Secret, []byte, error) {
+	secret := swarm.Secret{ID: "secretID1"}
+	secret.Spec.Name = "db_password"
+	secret.Spec.Labels = map[string]string{"env": "prod"}
+	return secret, nil, nil
+}
+
+func TestSecretRotateErrors(t *testing.T) {
+	testCases := []struct {
+		args              []string
+		secretInspectFunc func(string) (swarm.Secret, []byte, error)
+		serviceUpdateFunc func(string, swarm.Version, swarm.ServiceSpec) (types.ServiceUpdateResponse, error)
+		expectedError     string
+	}{
+		{
+			args:          []string{"too", "many", "arguments"},
+			expectedError: "requires at least 1 and at most 2 arguments",
+		},
+		{
+			args: []string{"db_password", "-"},
+			secretInspectFunc: func(name string) (swarm.Secret, []byte, error) {
+				return swarm.Secret{}, nil, errors.Errorf("error inspecting secret")
+			},
+			expectedError: "error inspecting secret",
+		},
+		{
+			args:              []string{"db_password"},
+			secretInspectFunc: rotateTestSecret,
+			expectedError:     "the new secret data must be provided",
+		},
+		{
+			args:              []string{"db_password", "-"},
+			secretInspectFunc: rotateTestSecret,
+			serviceUpdateFunc: func(string, swarm.Version, swarm.ServiceSpec) (types.ServiceUpdateResponse, error) {
+				return types.ServiceUpdateResponse{}, errors.Errorf("error updating service")
+			},
+			expectedError: "failed to update service web, secret db_password was not removed: error updating service",
+		},
+	}
+	for _, tc := range testCases {
+		cli := test.NewFakeCli(&fakeClient{
+			secretInspectFunc: tc.secretInspectFunc,
+			serviceListFunc: func(types.ServiceListOptions) ([]swarm.Service, error) {
+				return rotateTestServices(), nil
+			},
+			serviceUpdateFunc: tc.serviceUpdateFunc,
+			secretRemoveFunc: func(string) error {
+				return errors.Errorf("unexpected removal of the secret")
+			},
+		})
+		cli.SetIn(command.NewInStream(ioutil.NopCloser(strings.NewReader("new-password"))))
+		cmd := newSecretRotateCommand(cli)
+		cmd.SetArgs(tc.args)
+		cmd.SetOutput(ioutil.Discard)
+		testutil.ErrorContains(t, cmd.Execute(), tc.expectedError)
+	}
+}
+
+func rotateTestServices() []swarm.Service {
+	service := func(id string, refs ...*swarm.SecretReference) swarm.Service {
+		s := swarm.Service{ID: id}
+		s.Spec.Name = id
+		s.Spec.TaskTemplate.ContainerSpec = &swarm.ContainerSpec{Secrets: refs}
+		return s
+	}
+	return []swarm.Service{
+		service("web", &swarm.SecretReference{
+			SecretID:   "secretID1",
+			SecretName: "db_password",
+			File:       &swarm.SecretReferenceFileTarget{Name: "password", UID: "33", GID: "33", Mode: 0400},
+		}),
+		service("cache", &swarm.SecretReference{SecretID: "secretID2", SecretName: "cache_password"}),
+		{ID: "plugin"},
+	}
+}
+
+func TestSecretRotate(t *testing.T) {
+	var (
+		created swarm.SecretSpec
+		updated = map[string]swarm.ServiceSpec{}
+		removed string
+	)
+	cli := test.NewFakeCli(&fakeClient{
+		secretInspectFunc: rotateTestSecret,
+		secretCreateFunc: func(spec swarm.SecretSpec) (types.SecretCreateResponse, error) {
+			created = spec
+			return types.SecretCreateResponse{ID: "secretID3"}, nil
+		},
+		secretRemoveFunc: func(id string) error {
+			removed = id
+			return nil
+		},
+		serviceListFunc: func(types.ServiceListOptions) ([]swarm.Service, error) {
+			return rotateTestServices(), nil
+		},
+		serviceUpdateFunc: func(id string, version swarm.Version, spec swarm.ServiceSpec) (types.ServiceUpdateResponse, error) {
+			updated[id] = spec
+			return types.ServiceUpdateResponse{}, nil
+		},
+	})
+	cli.SetIn(command.NewInStream(ioutil.NopCloser(strings.NewReader("new-password"))))
+	cmd := newSecretRotateCommand(cli)
+	cmd.SetArgs([]string{"db_password", "-"})
+	assert.NoError(t, cmd.Execute())
+
+	assert.Equal(t, "db_password_v2", created.Name)
+	assert.Equal(t, map[string]string{"env": "prod"}, created.Labels)
+	assert.Equal(t, []byte("new-password"), created.Data)
+	assert.Len(t, updated, 1)
+	assert.Equal(t, []*swarm.SecretReference{{
+		SecretID:   "secretID3",
+		SecretName: "db_password_v2",
+		File:       &swarm.SecretReferenceFileTarget{Name: "password", UID: "33", GID: "33", Mode: 0400},
+	}}, updated["web"].TaskTemplate.ContainerSpec.Secrets)
+	assert.Equal(t, "secretID1", removed)
+	assert.Equal(t, "Created secret db_password_v2 (secretID3)\nUpdated service web\nRemoved secret db_password (secretID1)\n", cli.OutBuffer().String())
+}
+
+func TestSecretRotateWithName(t *testing.T) {
+	var created swarm.SecretSpec
+	cli := test.NewFakeCli(&fakeClient{
+		secretInspectFunc: rotateTestSecret,
+		secretCreateFunc: func(spec swarm.SecretSpec) (types.SecretCreateResponse, error) {
+			created = spec
+			return types.SecretCreateResponse{ID: "secretID3"}, nil
+		},
+	})
+	cli.SetIn(command.NewInStream(ioutil.NopCloser(strings.NewReader("new-password"))))
+	cmd := newSecretRotateCommand(cli)
+	cmd.SetArgs([]string{"db_password", "-"})
+	cmd.Flags().Set("name", "db_password_2018")
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "db_password_2018", created.Name)
+}
+
+func TestSecretRotateGenerate(t *testing.T) {
+	var created swarm.SecretSpec
+	cli := test.NewFakeCli(&fakeClient{
+		secretInspectFunc: rotateTestSecret,
+		secretCreateFunc: func(spec swarm.SecretSpec) (types.SecretCreateResponse, error) {
+			created = spec
+			return types.SecretCreateResponse{ID: "secretID3"}, nil
+		},
+	})
+	cmd := newSecretRotateCommand(cli)
+	cmd.SetArgs([]string{"db_password"})
+	cmd.Flags().Set("generate", "bytes:16")
+	assert.NoError(t, cmd.Execute())
+	assert.Len(t, created.Data, 16)
+}
@@ -0,0 +1,252 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/service/progress"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/docker/docker/api/types/versions"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"golang.org/x/net/context"
+)
+
+const (
+	flagCanary            = "canary"
+	flagCanaryConfirm     = "canary-confirm"
+	flagCanaryMaxFailures = "canary-max-failures"
+	flagCanaryReplicas    = "canary-replicas"
+	flagCanaryWindow      = "canary-window"
+
+	// canaryLabel is set on canary services to the ID of the service they
+	// are a canary for.
+	canaryLabel = "com.docker.service.canary-of"
+)
+
+// canaryPollInterval is how often the tasks of a canary service are checked.
+var canaryPollInterval = time.Second
+
+type canaryOptions struct {
+	replicas    uint64
+	window      time.Duration
+	maxFailures uint64
+	confirm     bool
+}
+
+func addCanaryFlags(flags *pflag.FlagSet) {
+	flags.Bool(flagCanary, false, "Deploy the update to a canary service first, and promote it if the canary stays healthy")
+	flags.Uint64(flagCanaryReplicas, 1, "Number of tasks in the canary service")
+	flags.Duration(flagCanaryWindow, time.Minute, "Time to monitor the canary before promoting it (ns|us|ms|s|m|h)")
+	flags.Uint64(flagCanaryMaxFailures, 0, "Number of canary task failures to tolerate")
+	flags.Bool(flagCanaryConfirm, false, "Prompt for confirmation before promoting the canary")
+}
+
+func canaryOptionsFromFlags(flags *pflag.FlagSet) (canaryOptions, error) {
+	var (
+		opts canaryOptions
+		err  error
+	)
+	if opts.replicas, err = flags.GetUint64(flagCanaryReplicas); err != nil {
+		return opts, err
+	}
+	if opts.window, err = flags.GetDuration(flagCanaryWindow); err != nil {
+		return opts, err
+	}
+	if opts.maxFailures, err = flags.GetUint64(flagCanaryMaxFailures); err != nil {
+		return opts, err
+	}
+	if opts.confirm, err = flags.GetBool(flagCanaryConfirm); err != nil {
+		return opts, err
+	}
+	if opts.replicas == 0 {
+		return opts, errors.Errorf("--%s must be greater than 0", flagCanaryReplicas)
+	}
+	return opts, nil
+}
+
+// newCanarySpec returns the spec of the canary service for service. The
+// canary runs the updated spec with its own name and a fixed number of
+// replicas. Published ports are left out because they can't be shared with
+// the service being updated.
+func newCanarySpec(service swarm.Service, spec swarm.ServiceSpec, replicas uint64) (swarm.ServiceSpec, error) {
+	canary, err := copyServiceSpec(spec)
+	if err != nil {
+		return canary, err
+	}
+	canary.Name = service.Spec.Name + "-canary"
+	if canary.Labels == nil {
+		canary.Labels = map[string]string{}
+	}
+	canary.Labels[canaryLabel] = service.ID
+	canary.Mode = swarm.ServiceMode{Replicated: &swarm.ReplicatedService{Replicas: &replicas}}
+	canary.UpdateConfig = nil
+	canary.RollbackConfig = nil
+	if canary.EndpointSpec != nil {
+		canary.EndpointSpec.Ports = nil
+	}
+	return canary, nil
+}
+
+// runCanaryUpdate deploys spec as a canary service, monitors it, and then
+// either promotes spec to service or removes the canary and reports why it
+// was rejected.
+func runCanaryUpdate(ctx context.Context, dockerCli command.Cli, service swarm.Service, spec swarm.ServiceSpec, updateOpts types.ServiceUpdateOptions, canaryOpts canaryOptions, options *serviceOptions) error {
+	apiClient := dockerCli.Client()
+
+	canarySpec, err := newCanarySpec(service, spec, canaryOpts.replicas)
+	if err != nil {
+		return err
+	}
+	createOpts := types.ServiceCreateOptions{
+		EncodedRegistryAuth: updateOpts.EncodedRegistryAuth,
+		QueryRegistry:       updateOpts.QueryRegistry,
+	}
+	response, err := apiClient.ServiceCreate(ctx, canarySpec, createOpts)
+	if err != nil {
+		return errors.Wrap(err, "failed to create canary service")
+	}
+	for _, warning := range response.Warnings {
+		fmt.Fprintln(dockerCli.Err(), warning)
+	}
+	fmt.Fprintf(dockerCli.Out(), "Created canary service %s (%s)\n", canarySpec.Name, response.ID)
+
+	removeCanary := func() error {
+		if err := apiClient.ServiceRemove(ctx, response.ID); err != nil {
+			return errors.Wrapf(err, "failed to remove canary service %s", canarySpec.Name)
+		}
+		fmt.Fprintf(dockerCli.Out(), "Removed canary service %s\n", canarySpec.Name)
+		return nil
+	}
+
+	progressOut := dockerCli.Out()
+	if options.quiet {
+		progressOut = nil
+	}
+	if err := watchCanary(ctx, apiClient, response.ID, canaryOpts, progressOut); err != nil {
+		if removeErr := removeCanary(); removeErr != nil {
+			fmt.Fprintln(dockerCli.Err(), removeErr)
+		}
+		return errors.Wrapf(err, "canary rejected, %s was not updated", service.Spec.Name)
+	}
+
+	if canaryOpts.confirm {
+		message := fmt.Sprintf("Canary %s is healthy. Promote the update to %s?", canarySpec.Name, service.Spec.Name)
+		if !command.PromptForConfirmation(dockerCli.In(), dockerCli.Out(), message) {
+			if err := removeCanary(); err != nil {
+				return err
+			}
+			return errors.Errorf("update of %s cancelled", service.Spec.Name)
+		}
+	}
+
+	updateResponse, err := apiClient.ServiceUpdate(ctx, service.ID, service.Version, spec, updateOpts)
+	if err != nil {
+		if removeErr := removeCanary(); removeErr != nil {
+			fmt.Fprintln(dockerCli.Err(), removeErr)
+		}
+		return err
+	}
+	for _, warning := range updateResponse.Warnings {
+		fmt.Fprintln(dockerCli.Err(), warning)
+	}
+	if err := removeCanary(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(dockerCli.Out(), "%s\n", service.Spec.Name)
+
+	if options.detach || versions.LessThan(apiClient.ClientVersion(), "1.29") {
+		return nil
+	}
+	return progress.WaitOnService(ctx, dockerCli, service.ID, options.quiet)
+}
+
+// watchCanary monitors the tasks of the canary service for canaryOpts.window.
+// It returns an error as soon as more than canaryOpts.maxFailures tasks have
+// failed, or if not all canary tasks are running at the end of the window.
+// A task that has a healthcheck only reaches the running state once it is
+// healthy, so unhealthy tasks are caught by the same checks.
+func watchCanary(ctx context.Context, apiClient client.APIClient, serviceID string, canaryOpts canaryOptions, out io.Writer) error {
+	taskFilter := filters.NewArgs()
+	taskFilter.Add("service", serviceID)
+
+	deadline := time.Now().Add(canaryOpts.window)
+	lastStatus := ""
+	for {
+		tasks, err := apiClient.TaskList(ctx, types.TaskListOptions{Filters: taskFilter})
+		if err != nil {
+			return err
+		}
+
+		running, failed := canaryTaskStatus(tasks)
+		if uint64(len(failed)) > canaryOpts.maxFailures {
+			return canaryFailureError(failed)
+		}
+
+		status := fmt.Sprintf("canary: %d/%d tasks running, %d failed", running, canaryOpts.replicas, len(failed))
+		if out != nil && status != lastStatus {
+			fmt.Fprintln(out, status)
+			lastStatus = status
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			if uint64(running) < canaryOpts.replicas {
+				return errors.Errorf("canary did not converge within %s: %d/%d tasks running", canaryOpts.window, running, canaryOpts.replicas)
+			}
+			return nil
+		}
+		if remaining > canaryPollInterval {
+			remaining = canaryPollInterval
+		}
+
+		select {
+		case <-time.After(remaining):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// canaryTaskStatus returns the number of tasks that are running and should
+// be, and the tasks that failed or were rejected.
+func canaryTaskStatus(tasks []swarm.Task) (int, []swarm.Task) {
+	var (
+		running int
+		failed  []swarm.Task
+	)
+	for _, task := range tasks {
+		switch task.Status.State {
+		case swarm.TaskStateFailed, swarm.TaskStateRejected:
+			failed = append(failed, task)
+		case swarm.TaskStateRunning:
+			if task.DesiredState == swarm.TaskStateRunning {
+				running++
+			}
+		}
+	}
+	return running, failed
+}
+
+func canaryFailureError(failed []swarm.Task) error {
+	reasons := make([]string, 0, len(failed))
+	for _, task := range failed {
+		reason := task.Status.Err
+		if reason == "" {
+			reason = task.Status.Message
+		}
+		if task.Status.ContainerStatus.ExitCode != 0 {
+			reason = fmt.Sprintf("%s (exit code %d)", reason, task.Status.ContainerStatus.ExitCode)
+		}
+		reasons = append(reasons, fmt.Sprintf("task %s on node %s %s: %s", stringid.TruncateID(task.ID), stringid.TruncateID(task.NodeID), task.Status.State, reason))
+	}
+	return errors.Errorf("%d canary task(s) failed:\n%s", len(failed), strings.Join(reasons, "\n"))
+}
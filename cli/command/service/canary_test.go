@@ -0,0 +1,142 @@
+package service
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/docker/cli/internal/test"
+	// Import builders to get the builder function as package function
+	. "github.com/docker/cli/internal/test/builders"
+	"github.com/docker/cli/internal/test/testutil"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func TestNewCanarySpec(t *testing.T) {
+	service := *Service(
+		ServiceID("service-id"),
+		ServiceName("web"),
+		ServiceLabels(map[string]string{"tier": "front"}),
+		ServicePort(swarm.PortConfig{TargetPort: 80, PublishedPort: 8080}),
+		ReplicatedService(5),
+	)
+	spec := service.Spec
+	spec.UpdateConfig = &swarm.UpdateConfig{Parallelism: 2}
+
+	canary, err := newCanarySpec(service, spec, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "web-canary", canary.Name)
+	assert.Equal(t, map[string]string{"tier": "front", canaryLabel: "service-id"}, canary.Labels)
+	assert.Equal(t, uint64(2), *canary.Mode.Replicated.Replicas)
+	assert.Nil(t, canary.UpdateConfig)
+	assert.Len(t, canary.EndpointSpec.Ports, 0)
+
+	// the spec of the service itself must be left untouched
+	assert.Equal(t, map[string]string{"tier": "front"}, spec.Labels)
+	assert.Len(t, spec.EndpointSpec.Ports, 1)
+}
+
+func canaryTask(state swarm.TaskState, err string) swarm.Task {
+	return *Task(
+		TaskID("task-"+string(state)),
+		TaskNodeID("node-id"),
+		TaskDesiredState(swarm.TaskStateRunning),
+		WithStatus(TaskState(state), StatusErr(err)),
+	)
+}
+
+func newCanaryTestClient(tasks []swarm.Task, created *swarm.ServiceSpec, updated *swarm.ServiceSpec, removed *[]string) *fakeClient {
+	return &fakeClient{
+		serviceInspectWithRawFunc: func(ctx context.Context, serviceID string, options types.ServiceInspectOptions) (swarm.Service, []byte, error) {
+			return *Service(ServiceID("service-id"), ServiceName("web"), ServiceImage("nginx:1.12")), nil, nil
+		},
+		serviceCreateFunc: func(ctx context.Context, service swarm.ServiceSpec, options types.ServiceCreateOptions) (types.ServiceCreateResponse, error) {
+			*created = service
+			return types.ServiceCreateResponse{ID: "canary-id"}, nil
+		},
+		serviceUpdateFunc: func(ctx context.Context, serviceID string, version swarm.Version, service swarm.ServiceSpec, options types.ServiceUpdateOptions) (types.ServiceUpdateResponse, error) {
+			*updated = service
+			return types.ServiceUpdateResponse{}, nil
+		},
+		serviceRemoveFunc: func(ctx context.Context, serviceID string) error {
+			*removed = append(*removed, serviceID)
+			return nil
+		},
+		taskListFunc: func(ctx context.Context, options types.TaskListOptions) ([]swarm.Task, error) {
+			return tasks, nil
+		},
+	}
+}
+
+func TestUpdateCanaryPromote(t *testing.T) {
+	var (
+		created, updated swarm.ServiceSpec
+		removed          []string
+	)
+	cli := test.NewFakeCli(newCanaryTestClient(
+		[]swarm.Task{canaryTask(swarm.TaskStateRunning, "")},
+		&created, &updated, &removed,
+	))
+	cmd := newUpdateCommand(cli)
+	cmd.SetArgs([]string{"web"})
+	cmd.Flags().Set(flagCanary, "true")
+	cmd.Flags().Set(flagCanaryWindow, "0s")
+	cmd.Flags().Set("image", "nginx:1.13")
+	cmd.Flags().Set(flagNoResolveImage, "true")
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, "web-canary", created.Name)
+	assert.Equal(t, "nginx:1.13", created.TaskTemplate.ContainerSpec.Image)
+	assert.Equal(t, "web", updated.Name)
+	assert.Equal(t, "nginx:1.13", updated.TaskTemplate.ContainerSpec.Image)
+	assert.Equal(t, []string{"canary-id"}, removed)
+	assert.Contains(t, cli.OutBuffer().String(), "canary: 1/1 tasks running, 0 failed")
+}
+
+func TestUpdateCanaryRejected(t *testing.T) {
+	var (
+		created, updated swarm.ServiceSpec
+		removed          []string
+	)
+	cli := test.NewFakeCli(newCanaryTestClient(
+		[]swarm.Task{canaryTask(swarm.TaskStateFailed, "task: non-zero exit (1)")},
+		&created, &updated, &removed,
+	))
+	cmd := newUpdateCommand(cli)
+	cmd.SetArgs([]string{"web"})
+	cmd.SetOutput(ioutil.Discard)
+	cmd.Flags().Set(flagCanary, "true")
+	cmd.Flags().Set(flagCanaryWindow, "1m")
+	cmd.Flags().Set("image", "nginx:broken")
+	cmd.Flags().Set(flagNoResolveImage, "true")
+
+	testutil.ErrorContains(t, cmd.Execute(), "task: non-zero exit (1)")
+	assert.Equal(t, "", updated.Name)
+	assert.Equal(t, []string{"canary-id"}, removed)
+}
+
+func TestWatchCanaryNotConverged(t *testing.T) {
+	defer func(interval time.Duration) { canaryPollInterval = interval }(canaryPollInterval)
+	canaryPollInterval = time.Millisecond
+
+	client := &fakeClient{
+		taskListFunc: func(ctx context.Context, options types.TaskListOptions) ([]swarm.Task, error) {
+			return []swarm.Task{canaryTask(swarm.TaskStateStarting, "")}, nil
+		},
+	}
+	err := watchCanary(context.Background(), client, "canary-id", canaryOptions{replicas: 1, window: 5 * time.Millisecond}, nil)
+	testutil.ErrorContains(t, err, "0/1 tasks running")
+}
+
+func TestUpdateCanaryWithRollback(t *testing.T) {
+	cmd := newUpdateCommand(test.NewFakeCli(&fakeClient{}))
+	cmd.SetArgs([]string{"web"})
+	cmd.SetOutput(ioutil.Discard)
+	cmd.Flags().Set(flagRollback, "true")
+	cmd.Flags().Set(flagCanary, "true")
+	testutil.ErrorContains(t, cmd.Execute(), "other flags may not be combined with --rollback")
+}
@@ -1,7 +1,10 @@
 package service
 
 import (
+	"io"
+
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/swarm"
 	"github.com/docker/docker/client"
 	"golang.org/x/net/context"
@@ -11,14 +14,23 @@ import (
 
 type fakeClient struct {
 	client.Client
+	nodeListFunc              func(ctx context.Context, options types.NodeListOptions) ([]swarm.Node, error)
 	serviceInspectWithRawFunc func(ctx context.Context, serviceID string, options types.ServiceInspectOptions) (swarm.Service, []byte, error)
 	serviceUpdateFunc         func(ctx context.Context, serviceID string, version swarm.Version, service swarm.ServiceSpec, options types.ServiceUpdateOptions) (types.ServiceUpdateResponse, error)
+	serviceCreateFunc         func(ctx context.Context, service swarm.ServiceSpec, options types.ServiceCreateOptions) (types.ServiceCreateResponse, error)
+	serviceRemoveFunc         func(ctx context.Context, serviceID string) error
 	serviceListFunc           func(context.Context, types.ServiceListOptions) ([]swarm.Service, error)
 	taskListFunc              func(context.Context, types.TaskListOptions) ([]swarm.Task, error)
+	taskInspectWithRawFunc    func(ctx context.Context, taskID string) (swarm.Task, []byte, error)
 	infoFunc                  func(ctx context.Context) (types.Info, error)
+	eventsFunc                func(ctx context.Context, options types.EventsOptions) (<-chan events.Message, <-chan error)
+	serviceLogsFunc           func(ctx context.Context, serviceID string, options types.ContainerLogsOptions) (io.ReadCloser, error)
 }
 
 func (f *fakeClient) NodeList(ctx context.Context, options types.NodeListOptions) ([]swarm.Node, error) {
+	if f.nodeListFunc != nil {
+		return f.nodeListFunc(ctx, options)
+	}
 	return nil, nil
 }
 
@@ -29,6 +41,13 @@ func (f *fakeClient) TaskList(ctx context.Context, options types.TaskListOptions
 	return nil, nil
 }
 
+func (f *fakeClient) TaskInspectWithRaw(ctx context.Context, taskID string) (swarm.Task, []byte, error) {
+	if f.taskInspectWithRawFunc != nil {
+		return f.taskInspectWithRawFunc(ctx, taskID)
+	}
+	return *Task(TaskID(taskID)), nil, nil
+}
+
 func (f *fakeClient) ServiceInspectWithRaw(ctx context.Context, serviceID string, options types.ServiceInspectOptions) (swarm.Service, []byte, error) {
 	if f.serviceInspectWithRawFunc != nil {
 		return f.serviceInspectWithRawFunc(ctx, serviceID, options)
@@ -53,6 +72,31 @@ func (f *fakeClient) ServiceUpdate(ctx context.Context, serviceID string, versio
 	return types.ServiceUpdateResponse{}, nil
 }
 
+func (f *fakeClient) ServiceCreate(ctx context.Context, service swarm.ServiceSpec, options types.ServiceCreateOptions) (types.ServiceCreateResponse, error) {
+	if f.serviceCreateFunc != nil {
+		return f.serviceCreateFunc(ctx, service, options)
+	}
+
+	return types.ServiceCreateResponse{}, nil
+}
+
+func (f *fakeClient) ServiceRemove(ctx context.Context, serviceID string) error {
+	if f.serviceRemoveFunc != nil {
+		return f.serviceRemoveFunc(ctx, serviceID)
+	}
+
+	return nil
+}
+
+func (f *fakeClient) Events(ctx context.Context, options types.EventsOptions) (<-chan events.Message, <-chan error) {
+	if f.eventsFunc != nil {
+		return f.eventsFunc(ctx, options)
+	}
+	errs := make(chan error, 1)
+	errs <- io.EOF
+	return nil, errs
+}
+
 func (f *fakeClient) Info(ctx context.Context) (types.Info, error) {
 	if f.infoFunc == nil {
 		return types.Info{}, nil
@@ -60,6 +104,13 @@ func (f *fakeClient) Info(ctx context.Context) (types.Info, error) {
 	return f.infoFunc(ctx)
 }
 
+func (f *fakeClient) ServiceLogs(ctx context.Context, serviceID string, options types.ContainerLogsOptions) (io.ReadCloser, error) {
+	if f.serviceLogsFunc != nil {
+		return f.serviceLogsFunc(ctx, serviceID, options)
+	}
+	return nil, nil
+}
+
 func newService(id string, name string) swarm.Service {
 	return swarm.Service{
 		ID:   id,
@@ -18,9 +18,11 @@ func NewServiceCommand(dockerCli command.Cli) *cobra.Command {
 	}
 	cmd.AddCommand(
 		newCreateCommand(dockerCli),
+		newHistoryCommand(dockerCli),
 		newInspectCommand(dockerCli),
 		newPsCommand(dockerCli),
 		newListCommand(dockerCli),
+		newPlacementCommand(dockerCli),
 		newRemoveCommand(dockerCli),
 		newScaleCommand(dockerCli),
 		newUpdateCommand(dockerCli),
@@ -0,0 +1,272 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/formatter"
+	"github.com/docker/cli/cli/command/idresolver"
+	"github.com/docker/docker/api/types"
+	eventtypes "github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/swarm"
+	timetypes "github.com/docker/docker/api/types/time"
+	"github.com/docker/docker/client"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+type historyOptions struct {
+	service   string
+	since     string
+	format    string
+	noTrunc   bool
+	noResolve bool
+}
+
+func newHistoryCommand(dockerCli command.Cli) *cobra.Command {
+	options := historyOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "history [OPTIONS] SERVICE",
+		Short: "Show the timeline of changes and task failures of a service",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.service = args[0]
+			return runHistory(dockerCli, options)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.since, "since", "", "Show entries since timestamp (e.g. 2013-01-02T13:23:37) or relative (e.g. 42m for 42 minutes)")
+	flags.StringVar(&options.format, "format", "", "Pretty-print entries using a Go template, or \"json\"")
+	flags.BoolVar(&options.noTrunc, "no-trunc", false, "Do not truncate output")
+	flags.BoolVar(&options.noResolve, "no-resolve", false, "Do not map IDs to Names")
+
+	return cmd
+}
+
+func runHistory(dockerCli command.Cli, options historyOptions) error {
+	apiClient := dockerCli.Client()
+	ctx := context.Background()
+
+	var since time.Time
+	if options.since != "" {
+		ts, err := timetypes.GetTimestamp(options.since, time.Now())
+		if err != nil {
+			return err
+		}
+		sec, nsec, err := timetypes.ParseTimestamps(ts, 0)
+		if err != nil {
+			return err
+		}
+		since = time.Unix(sec, nsec)
+	}
+
+	service, _, err := apiClient.ServiceInspectWithRaw(ctx, options.service, types.ServiceInspectOptions{})
+	if err != nil {
+		return err
+	}
+
+	taskFilter := filters.NewArgs()
+	taskFilter.Add("service", service.ID)
+	tasks, err := apiClient.TaskList(ctx, types.TaskListOptions{Filters: taskFilter})
+	if err != nil {
+		return err
+	}
+
+	entries, err := serviceHistory(service)
+	if err != nil {
+		return err
+	}
+
+	resolver := idresolver.New(apiClient, options.noResolve)
+	taskEntries, err := taskHistory(ctx, resolver, tasks)
+	if err != nil {
+		return err
+	}
+	entries = append(entries, taskEntries...)
+
+	eventsSince := since
+	if eventsSince.IsZero() {
+		eventsSince = service.CreatedAt
+	}
+	eventEntries, err := serviceEventHistory(ctx, apiClient, service.ID, eventsSince)
+	if err != nil {
+		// events are only kept in memory by the daemon, and may not be
+		// available; the timeline is still useful without them.
+		fmt.Fprintf(dockerCli.Err(), "Unable to retrieve service events: %v\n", err)
+	}
+	entries = append(entries, eventEntries...)
+
+	filtered := entries[:0]
+	for _, entry := range entries {
+		if entry.Time.Before(since) {
+			continue
+		}
+		filtered = append(filtered, entry)
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].Time.Before(filtered[j].Time)
+	})
+
+	format := options.format
+	if len(format) == 0 {
+		format = formatter.TableFormatKey
+	}
+	historyCtx := formatter.Context{
+		Output: dockerCli.Out(),
+		Format: formatter.NewServiceHistoryFormat(format),
+		Trunc:  !options.noTrunc,
+	}
+	return formatter.ServiceHistoryWrite(historyCtx, filtered)
+}
+
+// serviceHistory returns the entries for the creation of the service, the
+// last change of its spec, and the state of its last update.
+func serviceHistory(service swarm.Service) ([]formatter.ServiceHistoryEntry, error) {
+	entries := []formatter.ServiceHistoryEntry{{
+		Time:  service.CreatedAt,
+		Type:  "service",
+		State: "created",
+	}}
+
+	if service.PreviousSpec != nil {
+		changes, err := diffServiceSpecs(*service.PreviousSpec, service.Spec)
+		if err != nil {
+			return nil, err
+		}
+		paths := make([]string, 0, len(changes))
+		for _, change := range changes {
+			paths = append(paths, change.Path)
+		}
+		entries = append(entries, formatter.ServiceHistoryEntry{
+			Time:    service.UpdatedAt,
+			Type:    "spec",
+			State:   "updated",
+			Message: strings.Join(paths, ", "),
+		})
+	}
+
+	if status := service.UpdateStatus; status != nil {
+		if status.StartedAt != nil {
+			state := "update started"
+			if strings.HasPrefix(string(status.State), "rollback") {
+				state = "rollback started"
+			}
+			entries = append(entries, formatter.ServiceHistoryEntry{
+				Time:  *status.StartedAt,
+				Type:  "update",
+				State: state,
+			})
+		}
+		if status.CompletedAt != nil || status.State != swarm.UpdateStateUpdating {
+			at := service.UpdatedAt
+			if status.CompletedAt != nil {
+				at = *status.CompletedAt
+			}
+			entries = append(entries, formatter.ServiceHistoryEntry{
+				Time:    at,
+				Type:    "update",
+				State:   string(status.State),
+				Message: status.Message,
+			})
+		}
+	}
+	return entries, nil
+}
+
+// taskHistory returns two entries for each task: one when the task was
+// created, and one for its current state. The daemon does not keep the
+// intermediate states of a task.
+func taskHistory(ctx context.Context, resolver *idresolver.IDResolver, tasks []swarm.Task) ([]formatter.ServiceHistoryEntry, error) {
+	entries := make([]formatter.ServiceHistoryEntry, 0, 2*len(tasks))
+	for _, task := range tasks {
+		node, err := resolver.Resolve(ctx, swarm.Node{}, task.NodeID)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, formatter.ServiceHistoryEntry{
+			Time:   task.CreatedAt,
+			Type:   "task",
+			Slot:   task.Slot,
+			Node:   node,
+			TaskID: task.ID,
+			State:  "created",
+		})
+
+		message := task.Status.Err
+		if message == "" {
+			message = task.Status.Message
+		}
+		var exitCode *int
+		switch task.Status.State {
+		case swarm.TaskStateComplete, swarm.TaskStateFailed:
+			code := task.Status.ContainerStatus.ExitCode
+			exitCode = &code
+		}
+		entries = append(entries, formatter.ServiceHistoryEntry{
+			Time:     task.Status.Timestamp,
+			Type:     "task",
+			Slot:     task.Slot,
+			Node:     node,
+			TaskID:   task.ID,
+			State:    string(task.Status.State),
+			ExitCode: exitCode,
+			Message:  message,
+		})
+	}
+	return entries, nil
+}
+
+// serviceEventHistory returns the daemon events of the service since the
+// given time.
+func serviceEventHistory(ctx context.Context, apiClient client.APIClient, serviceID string, since time.Time) ([]formatter.ServiceHistoryEntry, error) {
+	eventFilter := filters.NewArgs()
+	eventFilter.Add("type", eventtypes.ServiceEventType)
+	eventFilter.Add("service", serviceID)
+
+	until := time.Now()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	messages, errs := apiClient.Events(ctx, types.EventsOptions{
+		Since:   fmt.Sprintf("%d.%09d", since.Unix(), since.Nanosecond()),
+		Until:   fmt.Sprintf("%d.%09d", until.Unix(), until.Nanosecond()),
+		Filters: eventFilter,
+	})
+
+	var entries []formatter.ServiceHistoryEntry
+	for {
+		select {
+		case event := <-messages:
+			entries = append(entries, formatter.ServiceHistoryEntry{
+				Time:    time.Unix(0, event.TimeNano),
+				Type:    "event",
+				State:   event.Action,
+				Message: eventAttributes(event),
+			})
+		case err := <-errs:
+			if err == nil || err == io.EOF {
+				return entries, nil
+			}
+			return entries, err
+		}
+	}
+}
+
+func eventAttributes(event eventtypes.Message) string {
+	attrs := make([]string, 0, len(event.Actor.Attributes))
+	for k, v := range event.Actor.Attributes {
+		if k == "name" {
+			continue
+		}
+		attrs = append(attrs, k+"="+v)
+	}
+	sort.Strings(attrs)
+	return strings.Join(attrs, ", ")
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/cli/internal/test"
+	// Import builders to get the builder function as package function
+	. "github.com/docker/cli/internal/test/builders"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/gotestyourself/gotestyourself/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func newHistoryTestClient() *fakeClient {
+	created := time.Date(2017, time.December, 1, 10, 0, 0, 0, time.UTC)
+	updateStarted := created.Add(10 * time.Minute)
+	updateCompleted := updateStarted.Add(time.Minute)
+	return &fakeClient{
+		serviceInspectWithRawFunc: func(ctx context.Context, serviceID string, options types.ServiceInspectOptions) (swarm.Service, []byte, error) {
+			service := *Service(ServiceID("service-id"), ServiceName("web"), ServiceImage("nginx:1.13"))
+			service.CreatedAt = created
+			service.UpdatedAt = updateStarted
+			previous := *Service(ServiceName("web"), ServiceImage("nginx:1.12"))
+			service.PreviousSpec = &previous.Spec
+			service.UpdateStatus = &swarm.UpdateStatus{
+				State:       swarm.UpdateStateRollbackCompleted,
+				StartedAt:   &updateStarted,
+				CompletedAt: &updateCompleted,
+				Message:     "rollback completed",
+			}
+			return service, nil, nil
+		},
+		taskListFunc: func(ctx context.Context, options types.TaskListOptions) ([]swarm.Task, error) {
+			failed := *Task(TaskID("task-failed"), TaskNodeID("node-1"), TaskSlot(1),
+				WithStatus(TaskState(swarm.TaskStateFailed), StatusErr("task: non-zero exit (1)"), Timestamp(updateStarted.Add(30*time.Second))))
+			failed.CreatedAt = updateStarted.Add(5 * time.Second)
+			failed.Status.ContainerStatus.ExitCode = 1
+			running := *Task(TaskID("task-running"), TaskNodeID("node-2"), TaskSlot(1),
+				WithStatus(TaskState(swarm.TaskStateRunning), Timestamp(updateCompleted)))
+			running.CreatedAt = updateStarted.Add(40 * time.Second)
+			return []swarm.Task{running, failed}, nil
+		},
+		eventsFunc: func(ctx context.Context, options types.EventsOptions) (<-chan events.Message, <-chan error) {
+			messages := make(chan events.Message)
+			errs := make(chan error)
+			go func() {
+				messages <- events.Message{
+					Type:     events.ServiceEventType,
+					Action:   "update",
+					Actor:    events.Actor{ID: "service-id", Attributes: map[string]string{"name": "web", "updatestate.new": "rollback_started"}},
+					TimeNano: updateStarted.Add(35 * time.Second).UnixNano(),
+				}
+				close(errs)
+			}()
+			return messages, errs
+		},
+	}
+}
+
+func TestServiceHistory(t *testing.T) {
+	cli := test.NewFakeCli(newHistoryTestClient())
+	cmd := newHistoryCommand(cli)
+	cmd.SetArgs([]string{"web"})
+	cmd.Flags().Set("no-resolve", "true")
+	require.NoError(t, cmd.Execute())
+	golden.Assert(t, cli.OutBuffer().String(), "service-history.golden")
+}
+
+func TestServiceHistorySince(t *testing.T) {
+	cli := test.NewFakeCli(newHistoryTestClient())
+	cmd := newHistoryCommand(cli)
+	cmd.SetArgs([]string{"web"})
+	cmd.Flags().Set("no-resolve", "true")
+	cmd.Flags().Set("since", "2017-12-01T10:10:36Z")
+	cmd.Flags().Set("format", "json")
+	require.NoError(t, cmd.Execute())
+	golden.Assert(t, cli.OutBuffer().String(), "service-history-since-json.golden")
+}
+
+func TestServiceHistoryEventAttributes(t *testing.T) {
+	event := events.Message{Actor: events.Actor{Attributes: map[string]string{
+		"name":            "web",
+		"replicas.old":    "1",
+		"replicas.new":    "3",
+		"updatestate.new": "updating",
+	}}}
+	assert.Equal(t, "replicas.new=3, replicas.old=1, updatestate.new=updating", eventAttributes(event))
+}
@@ -2,11 +2,14 @@ package service
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"golang.org/x/net/context"
 
@@ -16,6 +19,7 @@ import (
 	"github.com/docker/cli/service/logs"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/swarm"
+	timetypes "github.com/docker/docker/api/types/time"
 	"github.com/docker/docker/client"
 	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/docker/docker/pkg/stringid"
@@ -33,6 +37,10 @@ type logsOptions struct {
 	tail       string
 	details    bool
 	raw        bool
+	until      string
+	format     string
+	grep       string
+	grepV      string
 
 	target string
 }
@@ -61,22 +69,87 @@ func newLogsCommand(dockerCli command.Cli) *cobra.Command {
 	// options identical to container logs
 	flags.BoolVarP(&opts.follow, "follow", "f", false, "Follow log output")
 	flags.StringVar(&opts.since, "since", "", "Show logs since timestamp (e.g. 2013-01-02T13:23:37) or relative (e.g. 42m for 42 minutes)")
+	flags.StringVar(&opts.until, "until", "", "Show logs before a timestamp (e.g. 2013-01-02T13:23:37) or relative (e.g. 42m for 42 minutes)")
 	flags.BoolVarP(&opts.timestamps, "timestamps", "t", false, "Show timestamps")
 	flags.BoolVar(&opts.details, "details", false, "Show extra details provided to logs")
 	flags.SetAnnotation("details", "version", []string{"1.30"})
 	flags.StringVar(&opts.tail, "tail", "all", "Number of lines to show from the end of the logs")
+	flags.StringVar(&opts.format, "format", "", "Format the logs (\"json\")")
+	flags.StringVar(&opts.grep, "grep", "", "Only show log lines matching a regular expression")
+	flags.StringVar(&opts.grepV, "grep-v", "", "Only show log lines not matching a regular expression")
 	return cmd
 }
 
+// withTimestamps returns whether the log lines received from the daemon are
+// prefixed with a timestamp. JSON output always includes timestamps, and
+// --until needs them to filter the lines.
+func (opts *logsOptions) withTimestamps() bool {
+	return opts.timestamps || opts.format == logsFormatJSON || opts.until != ""
+}
+
+// parseUntil returns the time of --until, or the zero time if it isn't set.
+// The daemon doesn't support until for service and task logs, so the lines
+// logged after it are dropped by the client.
+func parseUntil(until string, now time.Time) (time.Time, error) {
+	if until == "" {
+		return time.Time{}, nil
+	}
+	ts, err := timetypes.GetTimestamp(until, now)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "invalid value for --until")
+	}
+	sec, nsec, err := timetypes.ParseTimestamps(ts, 0)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "invalid value for --until")
+	}
+	return time.Unix(sec, nsec), nil
+}
+
+const logsFormatJSON = "json"
+
+// nolint: gocyclo
 func runLogs(dockerCli command.Cli, opts *logsOptions) error {
 	ctx := context.Background()
 
+	switch opts.format {
+	case "":
+	case logsFormatJSON:
+		if opts.raw {
+			return errors.New("--format json can't be combined with --raw")
+		}
+	default:
+		return errors.Errorf("unsupported format %q: only \"json\" is supported", opts.format)
+	}
+
+	filter, err := newLogFilter(opts.grep, opts.grepV)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	until, err := parseUntil(opts.until, now)
+	if err != nil {
+		return err
+	}
+
+	follow := opts.follow
+	if !until.IsZero() {
+		if until.After(now) {
+			// stop following when --until is reached
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithDeadline(ctx, until)
+			defer cancel()
+		} else {
+			// no new log line can be logged before --until
+			follow = false
+		}
+	}
+
 	options := types.ContainerLogsOptions{
 		ShowStdout: true,
 		ShowStderr: true,
 		Since:      opts.since,
-		Timestamps: opts.timestamps,
-		Follow:     opts.follow,
+		Timestamps: opts.withTimestamps(),
+		Follow:     follow,
 		Tail:       opts.tail,
 		// get the details if we request it OR if we're not doing raw mode
 		// (we need them for the context to pretty print)
@@ -143,23 +216,49 @@ func runLogs(dockerCli command.Cli, opts *logsOptions) error {
 
 	// tty logs get straight copied. they're not muxed with stdcopy
 	if tty {
-		_, err = io.Copy(dockerCli.Out(), responseBody)
-		return err
+		out := newFilterWriter(dockerCli.Out(), filter)
+		out.until, out.timestamps = until, opts.timestamps
+		if _, err = io.Copy(out, responseBody); err != nil {
+			return untilReached(ctx, err)
+		}
+		return out.Flush()
 	}
 
 	// otherwise, logs are multiplexed. if we're doing pretty printing, also
 	// create a task formatter.
-	var stdout, stderr io.Writer
-	stdout = dockerCli.Out()
-	stderr = dockerCli.Err()
-	if !opts.raw {
-		taskFormatter := newTaskFormatter(cli, opts, maxLength)
+	if opts.raw {
+		stdout := newFilterWriter(dockerCli.Out(), filter)
+		stdout.until, stdout.timestamps = until, opts.timestamps
+		stderr := newFilterWriter(dockerCli.Err(), filter)
+		stderr.until, stderr.timestamps = until, opts.timestamps
+		if _, err = stdcopy.StdCopy(stdout, stderr, responseBody); err != nil {
+			return untilReached(ctx, err)
+		}
+		if err := stdout.Flush(); err != nil {
+			return err
+		}
+		return stderr.Flush()
+	}
 
-		stdout = &logWriter{ctx: ctx, opts: opts, f: taskFormatter, w: stdout}
-		stderr = &logWriter{ctx: ctx, opts: opts, f: taskFormatter, w: stderr}
+	taskFormatter := newTaskFormatter(cli, opts, maxLength)
+	stdout := &logWriter{ctx: ctx, opts: opts, f: taskFormatter, filter: filter, until: until, stream: "stdout", w: dockerCli.Out()}
+	stderr := &logWriter{ctx: ctx, opts: opts, f: taskFormatter, filter: filter, until: until, stream: "stderr", w: dockerCli.Err()}
+	if opts.format == logsFormatJSON {
+		// the stream is part of each JSON entry, so keep all entries on
+		// the same output
+		stderr.w = dockerCli.Out()
 	}
 
 	_, err = stdcopy.StdCopy(stdout, stderr, responseBody)
+	return untilReached(ctx, err)
+}
+
+// untilReached returns nil if err is caused by following the logs being
+// stopped because --until was reached.
+func untilReached(ctx context.Context, err error) error {
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		return nil
+	}
 	return err
 }
 
@@ -177,6 +276,16 @@ type taskFormatter struct {
 	// cache saves a pre-cooked logContext formatted string based on a
 	// logcontext object, so we don't have to resolve names every time
 	cache map[logContext]string
+	// sources saves the resolved names of a logContext, for the same reason
+	sources map[logContext]logSource
+}
+
+// logSource holds the resolved names of the task a log line comes from.
+type logSource struct {
+	service string
+	node    string
+	taskID  string
+	slot    int
 }
 
 func newTaskFormatter(client client.APIClient, opts *logsOptions, padding int) *taskFormatter {
@@ -186,53 +295,79 @@ func newTaskFormatter(client client.APIClient, opts *logsOptions, padding int) *
 		padding: padding,
 		r:       idresolver.New(client, opts.noResolve),
 		cache:   make(map[logContext]string),
+		sources: make(map[logContext]logSource),
 	}
 }
 
-func (f *taskFormatter) format(ctx context.Context, logCtx logContext) (string, error) {
-	if cached, ok := f.cache[logCtx]; ok {
+func (f *taskFormatter) source(ctx context.Context, logCtx logContext) (logSource, error) {
+	if cached, ok := f.sources[logCtx]; ok {
 		return cached, nil
 	}
 
 	nodeName, err := f.r.Resolve(ctx, swarm.Node{}, logCtx.nodeID)
 	if err != nil {
-		return "", err
+		return logSource{}, err
 	}
 
 	serviceName, err := f.r.Resolve(ctx, swarm.Service{}, logCtx.serviceID)
 	if err != nil {
-		return "", err
+		return logSource{}, err
 	}
 
 	task, _, err := f.client.TaskInspectWithRaw(ctx, logCtx.taskID)
+	if err != nil {
+		return logSource{}, err
+	}
+
+	taskID := task.ID
+	if !f.opts.noTrunc {
+		taskID = stringid.TruncateID(task.ID)
+	}
+
+	source := logSource{
+		service: serviceName,
+		node:    nodeName,
+		taskID:  taskID,
+		slot:    task.Slot,
+	}
+	f.sources[logCtx] = source
+	return source, nil
+}
+
+func (f *taskFormatter) format(ctx context.Context, logCtx logContext) (string, error) {
+	if cached, ok := f.cache[logCtx]; ok {
+		return cached, nil
+	}
+
+	source, err := f.source(ctx, logCtx)
 	if err != nil {
 		return "", err
 	}
 
-	taskName := fmt.Sprintf("%s.%d", serviceName, task.Slot)
+	taskName := fmt.Sprintf("%s.%d", source.service, source.slot)
 	if !f.opts.noTaskIDs {
-		if f.opts.noTrunc {
-			taskName += fmt.Sprintf(".%s", task.ID)
-		} else {
-			taskName += fmt.Sprintf(".%s", stringid.TruncateID(task.ID))
-		}
+		taskName += fmt.Sprintf(".%s", source.taskID)
 	}
 
-	paddingCount := f.padding - getMaxLength(task.Slot)
+	paddingCount := f.padding - getMaxLength(source.slot)
 	padding := ""
 	if paddingCount > 0 {
 		padding = strings.Repeat(" ", paddingCount)
 	}
-	formatted := taskName + "@" + nodeName + padding
+	formatted := taskName + "@" + source.node + padding
 	f.cache[logCtx] = formatted
 	return formatted, nil
 }
 
 type logWriter struct {
-	ctx  context.Context
-	opts *logsOptions
-	f    *taskFormatter
-	w    io.Writer
+	ctx    context.Context
+	opts   *logsOptions
+	f      *taskFormatter
+	filter *logFilter
+	// until, if set, drops the log lines logged after it
+	until  time.Time
+	stream string
+	w      io.Writer
 }
 
 func (lw *logWriter) Write(buf []byte) (int, error) {
@@ -247,7 +382,7 @@ func (lw *logWriter) Write(buf []byte) (int, error) {
 	// spaces. if there is a timestamp, details will be 2nd (`index 1)
 	detailsIndex := 0
 	numParts := 2
-	if lw.opts.timestamps {
+	if lw.opts.withTimestamps() {
 		detailsIndex++
 		numParts++
 	}
@@ -270,6 +405,28 @@ func (lw *logWriter) Write(buf []byte) (int, error) {
 		return 0, err
 	}
 
+	if !lw.until.IsZero() {
+		ts, err := time.Parse(time.RFC3339Nano, string(parts[0]))
+		if err != nil {
+			return 0, errors.Wrapf(err, "invalid timestamp in log message")
+		}
+		if ts.After(lw.until) {
+			return len(buf), nil
+		}
+	}
+
+	message := parts[detailsIndex+1]
+	if !lw.filter.accept(message) {
+		return len(buf), nil
+	}
+
+	if lw.opts.format == logsFormatJSON {
+		if err := lw.writeJSON(string(parts[0]), logCtx, details, message); err != nil {
+			return 0, err
+		}
+		return len(buf), nil
+	}
+
 	output := []byte{}
 	// if we included timestamps, add them to the front
 	if lw.opts.timestamps {
@@ -306,7 +463,7 @@ func (lw *logWriter) Write(buf []byte) (int, error) {
 	}
 
 	// add the log message itself, finally
-	output = append(output, parts[detailsIndex+1]...)
+	output = append(output, message...)
 
 	_, err = lw.w.Write(output)
 	if err != nil {
@@ -316,6 +473,42 @@ func (lw *logWriter) Write(buf []byte) (int, error) {
 	return len(buf), nil
 }
 
+// logEntry is a log line, as written with --format json.
+type logEntry struct {
+	Timestamp time.Time
+	Service   string
+	Task      string
+	Slot      int
+	Node      string
+	Stream    string
+	Message   string
+	Details   map[string]string `json:",omitempty"`
+}
+
+func (lw *logWriter) writeJSON(timestamp string, logCtx logContext, details map[string]string, message []byte) error {
+	ts, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return errors.Wrapf(err, "invalid timestamp in log message")
+	}
+	source, err := lw.f.source(lw.ctx, logCtx)
+	if err != nil {
+		return err
+	}
+	entry := logEntry{
+		Timestamp: ts,
+		Service:   source.service,
+		Task:      source.taskID,
+		Slot:      source.slot,
+		Node:      source.node,
+		Stream:    lw.stream,
+		Message:   strings.TrimSuffix(string(message), "\n"),
+	}
+	if lw.opts.details && len(details) > 0 {
+		entry.Details = details
+	}
+	return json.NewEncoder(lw.w).Encode(entry)
+}
+
 // parseContext returns a log context and REMOVES the context from the details map
 func (lw *logWriter) parseContext(details map[string]string) (logContext, error) {
 	nodeID, ok := details["com.docker.swarm.node.id"]
@@ -348,3 +541,108 @@ type logContext struct {
 	serviceID string
 	taskID    string
 }
+
+// logFilter selects log messages using the --grep and --grep-v regular
+// expressions. A nil logFilter accepts all messages.
+type logFilter struct {
+	match   *regexp.Regexp
+	exclude *regexp.Regexp
+}
+
+func newLogFilter(grep, grepV string) (*logFilter, error) {
+	if grep == "" && grepV == "" {
+		return nil, nil
+	}
+	f := &logFilter{}
+	var err error
+	if grep != "" {
+		if f.match, err = regexp.Compile(grep); err != nil {
+			return nil, errors.Wrap(err, "invalid --grep expression")
+		}
+	}
+	if grepV != "" {
+		if f.exclude, err = regexp.Compile(grepV); err != nil {
+			return nil, errors.Wrap(err, "invalid --grep-v expression")
+		}
+	}
+	return f, nil
+}
+
+func (f *logFilter) accept(message []byte) bool {
+	if f == nil {
+		return true
+	}
+	if f.match != nil && !f.match.Match(message) {
+		return false
+	}
+	if f.exclude != nil && f.exclude.Match(message) {
+		return false
+	}
+	return true
+}
+
+// filterWriter applies a logFilter to each line written to it. It is used
+// for raw and tty logs, which can't be parsed, so the whole line is matched.
+type filterWriter struct {
+	w      io.Writer
+	filter *logFilter
+	// until, if set, drops the lines logged after it. The lines must then
+	// be prefixed with their timestamp, which is removed unless timestamps
+	// is set.
+	until      time.Time
+	timestamps bool
+	buf        []byte
+}
+
+func newFilterWriter(w io.Writer, filter *logFilter) *filterWriter {
+	return &filterWriter{w: w, filter: filter}
+}
+
+func (fw *filterWriter) Write(p []byte) (int, error) {
+	if fw.filter == nil && fw.until.IsZero() {
+		return fw.w.Write(p)
+	}
+	fw.buf = append(fw.buf, p...)
+	for {
+		i := bytes.IndexByte(fw.buf, '\n')
+		if i < 0 {
+			break
+		}
+		if err := fw.writeLine(fw.buf[:i+1]); err != nil {
+			return 0, err
+		}
+		fw.buf = fw.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+func (fw *filterWriter) writeLine(line []byte) error {
+	if !fw.until.IsZero() {
+		parts := bytes.SplitN(line, []byte(" "), 2)
+		ts, err := time.Parse(time.RFC3339Nano, string(parts[0]))
+		if err != nil {
+			return errors.Wrapf(err, "invalid timestamp in log message")
+		}
+		if ts.After(fw.until) {
+			return nil
+		}
+		if !fw.timestamps && len(parts) == 2 {
+			line = parts[1]
+		}
+	}
+	if !fw.filter.accept(line) {
+		return nil
+	}
+	_, err := fw.w.Write(line)
+	return err
+}
+
+// Flush writes the last line if it isn't terminated by a newline.
+func (fw *filterWriter) Flush() error {
+	if len(fw.buf) == 0 {
+		return nil
+	}
+	err := fw.writeLine(fw.buf)
+	fw.buf = nil
+	return err
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/cli/internal/test/testutil"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+const testLogDetails = "com.docker.swarm.node.id=node-id,com.docker.swarm.service.id=service-id,com.docker.swarm.task.id=task-id-1234567890ab"
+
+func newTestLogWriter(opts *logsOptions, stream string, out *bytes.Buffer) *logWriter {
+	client := &fakeClient{
+		taskInspectWithRawFunc: func(ctx context.Context, taskID string) (swarm.Task, []byte, error) {
+			return swarm.Task{ID: taskID, Slot: 2}, nil, nil
+		},
+	}
+	filter, _ := newLogFilter(opts.grep, opts.grepV)
+	return &logWriter{
+		ctx:    context.Background(),
+		opts:   opts,
+		f:      newTaskFormatter(client, opts, 1),
+		filter: filter,
+		stream: stream,
+		w:      out,
+	}
+}
+
+func TestLogWriterFormatJSON(t *testing.T) {
+	out := new(bytes.Buffer)
+	opts := &logsOptions{noResolve: true, format: logsFormatJSON}
+	lw := newTestLogWriter(opts, "stderr", out)
+
+	_, err := lw.Write([]byte("2017-12-01T10:04:05.123456789Z " + testLogDetails + " something went wrong\n"))
+	require.NoError(t, err)
+	assert.Equal(t, `{"Timestamp":"2017-12-01T10:04:05.123456789Z","Service":"service-id","Task":"task-id-1234","Slot":2,"Node":"node-id","Stream":"stderr","Message":"something went wrong"}`+"\n", out.String())
+}
+
+func TestLogWriterGrep(t *testing.T) {
+	out := new(bytes.Buffer)
+	opts := &logsOptions{noResolve: true, noTaskIDs: true, grep: "GET|POST", grepV: "/health"}
+	lw := newTestLogWriter(opts, "stdout", out)
+
+	for _, line := range []string{
+		"GET /index.html\n",
+		"GET /health\n",
+		"starting worker\n",
+		"POST /login\n",
+	} {
+		n, err := lw.Write([]byte(testLogDetails + " " + line))
+		require.NoError(t, err)
+		assert.Equal(t, len(testLogDetails)+1+len(line), n)
+	}
+	assert.Equal(t, "service-id.2@node-id    | GET /index.html\nservice-id.2@node-id    | POST /login\n", out.String())
+}
+
+func TestFilterWriter(t *testing.T) {
+	out := new(bytes.Buffer)
+	filter, err := newLogFilter("", "^debug")
+	require.NoError(t, err)
+	fw := newFilterWriter(out, filter)
+
+	fw.Write([]byte("info: one\ndebug: two\ninfo: th"))
+	fw.Write([]byte("ree\ndebug: four"))
+	assert.Equal(t, "info: one\ninfo: three\n", out.String())
+	require.NoError(t, fw.Flush())
+	assert.Equal(t, "info: one\ninfo: three\n", out.String())
+}
+
+func TestNewLogFilterInvalid(t *testing.T) {
+	_, err := newLogFilter("(", "")
+	testutil.ErrorContains(t, err, "invalid --grep expression")
+}
+
+func TestRunLogsUntil(t *testing.T) {
+	var requested types.ContainerLogsOptions
+	client := &fakeClient{
+		serviceInspectWithRawFunc: func(ctx context.Context, serviceID string, options types.ServiceInspectOptions) (swarm.Service, []byte, error) {
+			return swarm.Service{Spec: swarm.ServiceSpec{TaskTemplate: swarm.TaskSpec{ContainerSpec: &swarm.ContainerSpec{}}}}, nil, nil
+		},
+		taskInspectWithRawFunc: func(ctx context.Context, taskID string) (swarm.Task, []byte, error) {
+			return swarm.Task{ID: taskID, Slot: 1}, nil, nil
+		},
+		serviceLogsFunc: func(ctx context.Context, serviceID string, options types.ContainerLogsOptions) (io.ReadCloser, error) {
+			requested = options
+			output := new(bytes.Buffer)
+			stdout := stdcopy.NewStdWriter(output, stdcopy.Stdout)
+			details := ""
+			if options.Details {
+				details = testLogDetails + " "
+			}
+			for _, line := range []string{
+				"2017-12-01T10:00:00.000000000Z " + details + "starting\n",
+				"2017-12-01T10:05:00.000000000Z " + details + "ready\n",
+				"2017-12-01T10:10:00.000000000Z " + details + "stopping\n",
+			} {
+				stdout.Write([]byte(line))
+			}
+			return ioutil.NopCloser(output), nil
+		},
+	}
+
+	testcases := []struct {
+		opts     logsOptions
+		expected string
+	}{
+		{
+			opts:     logsOptions{noResolve: true, noTaskIDs: true, follow: true, until: "2017-12-01T10:05:00Z"},
+			expected: "service-id.1@node-id    | starting\nservice-id.1@node-id    | ready\n",
+		},
+		{
+			opts:     logsOptions{raw: true, until: "2017-12-01T10:04:00Z"},
+			expected: "starting\n",
+		},
+		{
+			opts:     logsOptions{raw: true, timestamps: true, until: "2017-12-01T10:04:00Z"},
+			expected: "2017-12-01T10:00:00.000000000Z starting\n",
+		},
+	}
+	for _, tc := range testcases {
+		cli := test.NewFakeCli(client)
+		tc.opts.target = "web"
+		require.NoError(t, runLogs(cli, &tc.opts))
+		assert.Equal(t, tc.expected, cli.OutBuffer().String())
+		assert.True(t, requested.Timestamps)
+		// --until is in the past, so there is nothing to follow
+		assert.False(t, requested.Follow)
+	}
+}
@@ -824,12 +824,14 @@ const (
 	flagDNSSearch               = "dns-search"
 	flagDNSSearchRemove         = "dns-search-rm"
 	flagDNSSearchAdd            = "dns-search-add"
+	flagDryRun                  = "dry-run"
 	flagEndpointMode            = "endpoint-mode"
 	flagEntrypoint              = "entrypoint"
 	flagEnv                     = "env"
 	flagEnvFile                 = "env-file"
 	flagEnvRemove               = "env-rm"
 	flagEnvAdd                  = "env-add"
+	flagFormat                  = "format"
 	flagGenericResourcesRemove  = "generic-resource-rm"
 	flagGenericResourcesAdd     = "generic-resource-add"
 	flagGroup                   = "group"
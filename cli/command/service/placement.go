@@ -0,0 +1,170 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/formatter"
+	"github.com/docker/cli/cli/command/placement"
+	"github.com/docker/cli/opts"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+	"vbom.ml/util/sortorder"
+)
+
+// unsetSpreadValue is shown for nodes that don't have the label of a spread
+// preference.
+const unsetSpreadValue = "<unset>"
+
+type placementOptions struct {
+	service     string
+	constraints opts.ListOpts
+	format      string
+}
+
+func newPlacementCommand(dockerCli command.Cli) *cobra.Command {
+	options := placementOptions{constraints: opts.NewListOpts(nil)}
+
+	cmd := &cobra.Command{
+		Use:   "placement [OPTIONS] SERVICE",
+		Short: "Evaluate the placement constraints and preferences of a service against the nodes",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.service = args[0]
+			return runPlacement(dockerCli, options)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.Var(&options.constraints, "constraint", "Evaluate an additional placement constraint")
+	flags.StringVar(&options.format, "format", "", "Pretty-print results using a Go template, or \"json\"")
+	return cmd
+}
+
+func runPlacement(dockerCli command.Cli, options placementOptions) error {
+	client := dockerCli.Client()
+	ctx := context.Background()
+
+	service, _, err := client.ServiceInspectWithRaw(ctx, options.service, types.ServiceInspectOptions{})
+	if err != nil {
+		return err
+	}
+	spec := swarm.Placement{}
+	if service.Spec.TaskTemplate.Placement != nil {
+		spec = *service.Spec.TaskTemplate.Placement
+	}
+
+	expressions := append([]string{}, spec.Constraints...)
+	constraints, err := placement.ParseConstraints(append(expressions, options.constraints.GetAll()...))
+	if err != nil {
+		return err
+	}
+	for _, c := range constraints {
+		if !c.KnownKey() {
+			fmt.Fprintf(dockerCli.Err(), "Warning: constraint %s uses an unknown key, and will never be satisfied\n", c)
+		}
+	}
+
+	nodes, err := client.NodeList(ctx, types.NodeListOptions{})
+	if err != nil {
+		return err
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return sortorder.NaturalLess(nodes[i].Description.Hostname, nodes[j].Description.Hostname)
+	})
+
+	task := swarm.TaskSpec{Placement: &swarm.Placement{
+		Constraints: append(expressions, options.constraints.GetAll()...),
+		Platforms:   spec.Platforms,
+	}}
+	placements, err := evaluatePlacement(nodes, task, spec.Preferences)
+	if err != nil {
+		return err
+	}
+
+	format := options.format
+	if len(format) == 0 {
+		format = formatter.TableFormatKey
+	}
+	placementCtx := formatter.Context{
+		Output: dockerCli.Out(),
+		Format: formatter.NewServicePlacementFormat(format),
+	}
+	if err := formatter.ServicePlacementWrite(placementCtx, placements); err != nil {
+		return err
+	}
+	if format == formatter.TableFormatKey {
+		printPlacementSummary(dockerCli.Out(), placements, spec.Preferences)
+	}
+	return nil
+}
+
+// evaluatePlacement checks the placement of task on each node with
+// placement.Check, and groups the nodes by the spread preferences.
+func evaluatePlacement(nodes []swarm.Node, task swarm.TaskSpec, preferences []swarm.PlacementPreference) ([]formatter.ServicePlacement, error) {
+	placements := make([]formatter.ServicePlacement, 0, len(nodes))
+	for _, node := range nodes {
+		p := formatter.ServicePlacement{Node: node}
+		if err := placement.Check(node, nil, task); err != nil {
+			p.Failing = err.Error()
+		}
+		for _, pref := range preferences {
+			if pref.Spread == nil {
+				continue
+			}
+			value, ok, err := placement.SpreadValue(pref.Spread.SpreadDescriptor, node)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				value = unsetSpreadValue
+			}
+			p.Spread = append(p.Spread, pref.Spread.SpreadDescriptor+"="+value)
+		}
+		placements = append(placements, p)
+	}
+	return placements, nil
+}
+
+// printPlacementSummary prints the number of nodes that pass the constraints,
+// and how they are grouped by each spread preference.
+func printPlacementSummary(out io.Writer, placements []formatter.ServicePlacement, preferences []swarm.PlacementPreference) {
+	var passing []formatter.ServicePlacement
+	for _, p := range placements {
+		if p.Failing == "" {
+			passing = append(passing, p)
+		}
+	}
+	fmt.Fprintf(out, "\n%d of %d nodes pass the placement constraints\n", len(passing), len(placements))
+
+	spreads := 0
+	for _, pref := range preferences {
+		if pref.Spread == nil {
+			continue
+		}
+		i := spreads
+		spreads++
+		var (
+			groups []string
+			counts = map[string]int{}
+		)
+		for _, p := range passing {
+			value := strings.TrimPrefix(p.Spread[i], pref.Spread.SpreadDescriptor+"=")
+			if counts[value] == 0 {
+				groups = append(groups, value)
+			}
+			counts[value]++
+		}
+		sort.Strings(groups)
+		for j, group := range groups {
+			groups[j] = fmt.Sprintf("%s (%d)", group, counts[group])
+		}
+		fmt.Fprintf(out, "Tasks are spread evenly over %s: %s\n", pref.Spread.SpreadDescriptor, strings.Join(groups, ", "))
+	}
+}
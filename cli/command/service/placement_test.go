@@ -0,0 +1,76 @@
+package service
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
+	// Import builders to get the builder function as package function
+	. "github.com/docker/cli/internal/test/builders"
+	"github.com/docker/cli/internal/test/testutil"
+	"github.com/gotestyourself/gotestyourself/golden"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func placementTestClient(placement *swarm.Placement) *fakeClient {
+	return &fakeClient{
+		serviceInspectWithRawFunc: func(ctx context.Context, serviceID string, options types.ServiceInspectOptions) (swarm.Service, []byte, error) {
+			service := Service(ServiceID("serviceID"), ServiceName(serviceID))
+			service.Spec.TaskTemplate.Placement = placement
+			return *service, nil, nil
+		},
+		nodeListFunc: func(ctx context.Context, options types.NodeListOptions) ([]swarm.Node, error) {
+			manager := Node(NodeID("nodeID1"), Hostname("node-1"), Manager(), NodeLabels(map[string]string{"az": "east"}))
+			manager.Spec.Role = swarm.NodeRoleManager
+			arm := Node(NodeID("nodeID3"), Hostname("node-3"), NodeLabels(map[string]string{"az": "west"}))
+			arm.Description.Platform.Architecture = "aarch64"
+			drained := Node(NodeID("nodeID5"), Hostname("node-4"), NodeLabels(map[string]string{"az": "west"}))
+			drained.Spec.Availability = swarm.NodeAvailabilityDrain
+			down := Node(NodeID("nodeID6"), Hostname("node-5"), NodeLabels(map[string]string{"az": "west"}))
+			down.Status.State = swarm.NodeStateDown
+			return []swarm.Node{
+				*Node(NodeID("nodeID4"), Hostname("node-10")),
+				*down,
+				*drained,
+				*arm,
+				*Node(NodeID("nodeID2"), Hostname("node-2"), NodeLabels(map[string]string{"az": "east"})),
+				*manager,
+			}, nil
+		},
+	}
+}
+
+func TestServicePlacement(t *testing.T) {
+	cli := test.NewFakeCli(placementTestClient(&swarm.Placement{
+		Constraints: []string{"node.role==worker"},
+		Preferences: []swarm.PlacementPreference{{Spread: &swarm.SpreadOver{SpreadDescriptor: "node.labels.az"}}},
+		Platforms:   []swarm.Platform{{OS: "linux", Architecture: "amd64"}},
+	}))
+	cmd := newPlacementCommand(cli)
+	cmd.SetArgs([]string{"web"})
+	assert.NoError(t, cmd.Execute())
+	golden.Assert(t, cli.OutBuffer().String(), "service-placement.golden")
+}
+
+func TestServicePlacementHypotheticalConstraint(t *testing.T) {
+	cli := test.NewFakeCli(placementTestClient(nil))
+	cmd := newPlacementCommand(cli)
+	cmd.SetArgs([]string{"web"})
+	cmd.Flags().Set("constraint", "node.labels.az!=west")
+	cmd.Flags().Set("constraint", "node.zone==east")
+	cmd.Flags().Set("format", "{{.Hostname}} {{.Result}} {{.Failing}}")
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "node-1 fail constraint node.zone==east is not satisfied\nnode-2 fail constraint node.zone==east is not satisfied\nnode-3 fail constraint node.labels.az!=west is not satisfied\nnode-4 fail node availability is drain\nnode-5 fail node is down\nnode-10 fail constraint node.zone==east is not satisfied\n", cli.OutBuffer().String())
+	assert.Equal(t, "Warning: constraint node.zone==east uses an unknown key, and will never be satisfied\n", cli.ErrBuffer().String())
+}
+
+func TestServicePlacementInvalidConstraint(t *testing.T) {
+	cmd := newPlacementCommand(test.NewFakeCli(placementTestClient(nil)))
+	cmd.SetArgs([]string{"web"})
+	cmd.Flags().Set("constraint", "node.role")
+	cmd.SetOutput(ioutil.Discard)
+	testutil.ErrorContains(t, cmd.Execute(), "constraint expected one operator from ==, !=")
+}
@@ -0,0 +1,195 @@
+package progress
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/progress"
+	"github.com/docker/docker/pkg/streamformatter"
+	"github.com/docker/docker/pkg/stringid"
+	"golang.org/x/net/context"
+)
+
+// drainPollInterval is the time between two checks of the drained tasks.
+var drainPollInterval = 500 * time.Millisecond
+
+// DrainProgress outputs progress information while tasks are moved away from
+// drained nodes. It returns once each of the given tasks has reached a
+// terminal state, and a replacement task for the same slot is running on
+// another node. Tasks of global services are not replaced, so they only need
+// to reach a terminal state, and neither are tasks of services that have been
+// removed, or scaled down below the slot of the task.
+// nolint: gocyclo
+func DrainProgress(ctx context.Context, client client.APIClient, nodeIDs []string, tasks []swarm.Task, progressWriter io.WriteCloser) error {
+	defer progressWriter.Close()
+
+	progressOut := streamformatter.NewJSONProgressOutput(progressWriter, false)
+
+	sigint := make(chan os.Signal, 1)
+	signal.Notify(sigint, os.Interrupt)
+	defer signal.Stop(sigint)
+
+	drained := make(map[string]struct{}, len(nodeIDs))
+	nodeFilter := filters.NewArgs()
+	for _, nodeID := range nodeIDs {
+		drained[nodeID] = struct{}{}
+		nodeFilter.Add("node", nodeID)
+	}
+
+	serviceFilter := filters.NewArgs()
+	serviceFilter.Add("desired-state", string(swarm.TaskStateRunning))
+	serviceIDs := map[string]struct{}{}
+	for _, task := range tasks {
+		if _, ok := serviceIDs[task.ServiceID]; !ok {
+			serviceIDs[task.ServiceID] = struct{}{}
+			serviceFilter.Add("service", task.ServiceID)
+		}
+	}
+
+	services, err := drainServices(ctx, client, serviceIDs)
+	if err != nil {
+		return err
+	}
+	names := drainTaskNames(services, tasks)
+
+	if len(tasks) == 0 {
+		progressOut.WriteProgress(progress.Progress{ID: "overall progress", Action: "no tasks to move"})
+		return nil
+	}
+
+	for {
+		nodeTasks, err := client.TaskList(ctx, types.TaskListOptions{Filters: nodeFilter})
+		if err != nil {
+			return err
+		}
+		current := make(map[string]swarm.Task, len(nodeTasks))
+		for _, task := range nodeTasks {
+			current[task.ID] = task
+		}
+
+		var serviceTasks []swarm.Task
+		if len(serviceIDs) > 0 {
+			serviceTasks, err = client.TaskList(ctx, types.TaskListOptions{Filters: serviceFilter})
+			if err != nil {
+				return err
+			}
+			if services, err = drainServices(ctx, client, serviceIDs); err != nil {
+				return err
+			}
+		}
+
+		moved := 0
+		for _, task := range tasks {
+			done, action := drainTaskStatus(task, current, services, serviceTasks, drained)
+			if done {
+				moved++
+			}
+			progressOut.WriteProgress(progress.Progress{ID: names[task.ID], Action: action})
+		}
+		progressOut.WriteProgress(progress.Progress{
+			ID:     "overall progress",
+			Action: fmt.Sprintf("%d out of %d tasks moved", moved, len(tasks)),
+		})
+		if moved == len(tasks) {
+			return nil
+		}
+
+		select {
+		case <-time.After(drainPollInterval):
+		case <-sigint:
+			progress.Message(progressOut, "", "Operation continuing in background.")
+			progress.Message(progressOut, "", "Use `docker node ps` to check progress.")
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// drainTaskStatus returns whether task has been moved off the drained nodes,
+// and a description of its progress.
+func drainTaskStatus(task swarm.Task, current map[string]swarm.Task, services map[string]swarm.Service, serviceTasks []swarm.Task, drained map[string]struct{}) (bool, string) {
+	// a task that is no longer known by the manager has been removed
+	if cur, ok := current[task.ID]; ok && !terminalState(cur.Status.State) {
+		return false, fmt.Sprintf("stopping (%s)", cur.Status.State)
+	}
+	if task.Slot == 0 {
+		return true, "stopped"
+	}
+	service, ok := services[task.ServiceID]
+	if !ok {
+		return true, "stopped, service removed"
+	}
+	if mode := service.Spec.Mode.Replicated; mode != nil && mode.Replicas != nil && uint64(task.Slot) > *mode.Replicas {
+		return true, "stopped, service scaled down"
+	}
+
+	var replacement *swarm.Task
+	for i, t := range serviceTasks {
+		if t.ServiceID != task.ServiceID || t.Slot != task.Slot || t.ID == task.ID {
+			continue
+		}
+		if _, onDrained := drained[t.NodeID]; onDrained {
+			continue
+		}
+		replacement = &serviceTasks[i]
+		if t.Status.State == swarm.TaskStateRunning {
+			break
+		}
+	}
+	switch {
+	case replacement == nil:
+		return false, "stopped, waiting for a replacement"
+	case replacement.Status.State == swarm.TaskStateRunning:
+		return true, fmt.Sprintf("moved to %s", stringid.TruncateID(replacement.NodeID))
+	case replacement.Status.Err != "":
+		return false, fmt.Sprintf("replacement %s: %s", replacement.Status.State, truncError(replacement.Status.Err))
+	default:
+		return false, fmt.Sprintf("replacement %s", replacement.Status.State)
+	}
+}
+
+// drainServices returns the services with the given IDs that still exist,
+// indexed by ID.
+func drainServices(ctx context.Context, client client.APIClient, serviceIDs map[string]struct{}) (map[string]swarm.Service, error) {
+	services := make(map[string]swarm.Service, len(serviceIDs))
+	if len(serviceIDs) == 0 {
+		return services, nil
+	}
+	filter := filters.NewArgs()
+	for id := range serviceIDs {
+		filter.Add("id", id)
+	}
+	list, err := client.ServiceList(ctx, types.ServiceListOptions{Filters: filter})
+	if err != nil {
+		return nil, err
+	}
+	for _, service := range list {
+		services[service.ID] = service
+	}
+	return services, nil
+}
+
+// drainTaskNames returns the names of tasks, as shown by `docker node ps`.
+func drainTaskNames(services map[string]swarm.Service, tasks []swarm.Task) map[string]string {
+	names := make(map[string]string, len(tasks))
+	for _, task := range tasks {
+		serviceName := stringid.TruncateID(task.ServiceID)
+		if service, ok := services[task.ServiceID]; ok {
+			serviceName = service.Spec.Name
+		}
+		if task.Slot != 0 {
+			names[task.ID] = fmt.Sprintf("%s.%d", serviceName, task.Slot)
+		} else {
+			names[task.ID] = fmt.Sprintf("%s.%s", serviceName, stringid.TruncateID(task.NodeID))
+		}
+	}
+	return names
+}
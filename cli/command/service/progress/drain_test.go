@@ -0,0 +1,110 @@
+package progress
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrainTaskStatus(t *testing.T) {
+	drained := map[string]struct{}{"node1": {}}
+	task := swarm.Task{ID: "task1", ServiceID: "service1", Slot: 1, NodeID: "node1"}
+	globalTask := swarm.Task{ID: "task2", ServiceID: "service2", NodeID: "node1"}
+	replicas := func(replicas uint64) map[string]swarm.Service {
+		return map[string]swarm.Service{"service1": {
+			ID: "service1",
+			Spec: swarm.ServiceSpec{
+				Mode: swarm.ServiceMode{Replicated: &swarm.ReplicatedService{Replicas: &replicas}},
+			},
+		}}
+	}
+
+	withState := func(task swarm.Task, state swarm.TaskState) swarm.Task {
+		task.Status.State = state
+		return task
+	}
+	replacement := func(nodeID string, state swarm.TaskState) swarm.Task {
+		return swarm.Task{
+			ID:        "task3",
+			ServiceID: "service1",
+			Slot:      1,
+			NodeID:    nodeID,
+			Status:    swarm.TaskStatus{State: state},
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		task           swarm.Task
+		current        map[string]swarm.Task
+		services       map[string]swarm.Service
+		serviceTasks   []swarm.Task
+		expectedDone   bool
+		expectedAction string
+	}{
+		{
+			name:           "still running",
+			task:           task,
+			services:       replicas(2),
+			current:        map[string]swarm.Task{"task1": withState(task, swarm.TaskStateRunning)},
+			expectedAction: "stopping (running)",
+		},
+		{
+			name:           "stopped without replacement",
+			task:           task,
+			services:       replicas(2),
+			current:        map[string]swarm.Task{"task1": withState(task, swarm.TaskStateShutdown)},
+			expectedAction: "stopped, waiting for a replacement",
+		},
+		{
+			name:           "replacement on drained node is ignored",
+			task:           task,
+			services:       replicas(2),
+			serviceTasks:   []swarm.Task{replacement("node1", swarm.TaskStateRunning)},
+			expectedAction: "stopped, waiting for a replacement",
+		},
+		{
+			name:           "replacement starting",
+			task:           task,
+			services:       replicas(2),
+			serviceTasks:   []swarm.Task{replacement("node2", swarm.TaskStatePreparing)},
+			expectedAction: "replacement preparing",
+		},
+		{
+			name:           "replacement running",
+			task:           task,
+			services:       replicas(2),
+			serviceTasks:   []swarm.Task{replacement("node2", swarm.TaskStateRunning)},
+			expectedDone:   true,
+			expectedAction: "moved to node2",
+		},
+		{
+			name:           "service removed",
+			task:           task,
+			current:        map[string]swarm.Task{"task1": withState(task, swarm.TaskStateShutdown)},
+			expectedDone:   true,
+			expectedAction: "stopped, service removed",
+		},
+		{
+			name:           "service scaled down",
+			task:           task,
+			services:       replicas(0),
+			current:        map[string]swarm.Task{"task1": withState(task, swarm.TaskStateShutdown)},
+			expectedDone:   true,
+			expectedAction: "stopped, service scaled down",
+		},
+		{
+			name:           "global task stopped",
+			task:           globalTask,
+			current:        map[string]swarm.Task{"task2": withState(globalTask, swarm.TaskStateShutdown)},
+			expectedDone:   true,
+			expectedAction: "stopped",
+		},
+	}
+	for _, tc := range testCases {
+		done, action := drainTaskStatus(tc.task, tc.current, tc.services, tc.serviceTasks, drained)
+		assert.Equal(t, tc.expectedDone, done, tc.name)
+		assert.Equal(t, tc.expectedAction, action, tc.name)
+	}
+}
@@ -1,23 +1,22 @@
-package service
+package progress
 
 import (
 	"io"
 	"io/ioutil"
 
 	"github.com/docker/cli/cli/command"
-	"github.com/docker/cli/cli/command/service/progress"
 	"github.com/docker/docker/pkg/jsonmessage"
 	"golang.org/x/net/context"
 )
 
-// waitOnService waits for the service to converge. It outputs a progress bar,
+// WaitOnService waits for the service to converge. It outputs a progress bar,
 // if appropriate based on the CLI flags.
-func waitOnService(ctx context.Context, dockerCli command.Cli, serviceID string, quiet bool) error {
+func WaitOnService(ctx context.Context, dockerCli command.Cli, serviceID string, quiet bool) error {
 	errChan := make(chan error, 1)
 	pipeReader, pipeWriter := io.Pipe()
 
 	go func() {
-		errChan <- progress.ServiceProgress(ctx, dockerCli.Client(), serviceID, pipeWriter)
+		errChan <- ServiceProgress(ctx, dockerCli.Client(), serviceID, pipeWriter)
 	}()
 
 	if quiet {
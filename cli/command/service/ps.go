@@ -1,6 +1,7 @@
 package service
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/docker/cli/cli"
@@ -11,6 +12,7 @@ import (
 	"github.com/docker/cli/opts"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/swarm"
 	"github.com/docker/docker/client"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -22,6 +24,7 @@ type psOptions struct {
 	quiet     bool
 	noResolve bool
 	noTrunc   bool
+	watch     bool
 	format    string
 	filter    opts.FilterOpt
 }
@@ -42,6 +45,7 @@ func newPsCommand(dockerCli command.Cli) *cobra.Command {
 	flags.BoolVarP(&options.quiet, "quiet", "q", false, "Only display task IDs")
 	flags.BoolVar(&options.noTrunc, "no-trunc", false, "Do not truncate output")
 	flags.BoolVar(&options.noResolve, "no-resolve", false, "Do not map IDs to Names")
+	flags.BoolVar(&options.watch, "watch", false, "Redraw the tasks as their state changes")
 	flags.StringVar(&options.format, "format", "", "Pretty-print tasks using a Go template")
 	flags.VarP(&options.filter, "filter", "f", "Filter output based on conditions provided")
 
@@ -60,11 +64,6 @@ func runPS(dockerCli command.Cli, options psOptions) error {
 		return err
 	}
 
-	tasks, err := client.TaskList(ctx, types.TaskListOptions{Filters: filter})
-	if err != nil {
-		return err
-	}
-
 	format := options.format
 	if len(format) == 0 {
 		format = task.DefaultFormat(dockerCli.ConfigFile(), options.quiet)
@@ -72,6 +71,22 @@ func runPS(dockerCli command.Cli, options psOptions) error {
 	if options.quiet {
 		options.noTrunc = true
 	}
+
+	if options.watch {
+		if len(notfound) != 0 {
+			fmt.Fprintln(dockerCli.Err(), strings.Join(notfound, "\n"))
+		}
+		list := func(ctx context.Context) ([]swarm.Task, error) {
+			return client.TaskList(ctx, types.TaskListOptions{Filters: filter})
+		}
+		return task.Watch(ctx, dockerCli, list, idresolver.New(client, options.noResolve), !options.noTrunc, options.quiet, format)
+	}
+
+	tasks, err := client.TaskList(ctx, types.TaskListOptions{Filters: filter})
+	if err != nil {
+		return err
+	}
+
 	if err := task.Print(ctx, dockerCli, tasks, idresolver.New(client, options.noResolve), !options.noTrunc, options.quiet, format); err != nil {
 		return err
 	}
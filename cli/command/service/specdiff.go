@@ -0,0 +1,173 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types/swarm"
+)
+
+const (
+	specChangeAdded   = "added"
+	specChangeRemoved = "removed"
+	specChangeChanged = "changed"
+)
+
+// specChange describes a single field-level difference between two service
+// specs. Path uses Go field names, with map keys in brackets when they
+// contain a dot, and slice elements addressed by index.
+type specChange struct {
+	Path string
+	Kind string
+	Old  interface{} `json:",omitempty"`
+	New  interface{} `json:",omitempty"`
+}
+
+// specDiff is the result of comparing the current spec of a service with the
+// spec that would be sent to the daemon.
+type specDiff struct {
+	ID      string
+	Name    string
+	Changes []specChange
+}
+
+// copyServiceSpec returns a deep copy of spec. updateService mutates maps and
+// slices in place, so a shallow copy is not enough to keep the original.
+func copyServiceSpec(spec swarm.ServiceSpec) (swarm.ServiceSpec, error) {
+	var copied swarm.ServiceSpec
+	raw, err := json.Marshal(spec)
+	if err != nil {
+		return copied, err
+	}
+	err = json.Unmarshal(raw, &copied)
+	return copied, err
+}
+
+// diffServiceSpecs returns the field-level changes needed to go from oldSpec
+// to newSpec. Both specs are compared in their API (JSON) form, so fields that
+// are omitted on the wire are treated as unset.
+func diffServiceSpecs(oldSpec, newSpec swarm.ServiceSpec) ([]specChange, error) {
+	oldValue, err := toGenericValue(oldSpec)
+	if err != nil {
+		return nil, err
+	}
+	newValue, err := toGenericValue(newSpec)
+	if err != nil {
+		return nil, err
+	}
+	changes := []specChange{}
+	diffValues("", oldValue, newValue, &changes)
+	return changes, nil
+}
+
+func toGenericValue(v interface{}) (interface{}, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	err = json.Unmarshal(raw, &generic)
+	return generic, err
+}
+
+func diffValues(path string, oldValue, newValue interface{}, changes *[]specChange) {
+	switch {
+	case oldValue == nil && newValue == nil:
+		return
+	case oldValue == nil:
+		*changes = append(*changes, specChange{Path: path, Kind: specChangeAdded, New: newValue})
+		return
+	case newValue == nil:
+		*changes = append(*changes, specChange{Path: path, Kind: specChangeRemoved, Old: oldValue})
+		return
+	}
+
+	oldMap, oldIsMap := oldValue.(map[string]interface{})
+	newMap, newIsMap := newValue.(map[string]interface{})
+	if oldIsMap && newIsMap {
+		keys := map[string]struct{}{}
+		for k := range oldMap {
+			keys[k] = struct{}{}
+		}
+		for k := range newMap {
+			keys[k] = struct{}{}
+		}
+		sorted := make([]string, 0, len(keys))
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+		for _, k := range sorted {
+			diffValues(joinSpecPath(path, k), oldMap[k], newMap[k], changes)
+		}
+		return
+	}
+
+	oldSlice, oldIsSlice := oldValue.([]interface{})
+	newSlice, newIsSlice := newValue.([]interface{})
+	if oldIsSlice && newIsSlice {
+		for i := 0; i < len(oldSlice) || i < len(newSlice); i++ {
+			var o, n interface{}
+			if i < len(oldSlice) {
+				o = oldSlice[i]
+			}
+			if i < len(newSlice) {
+				n = newSlice[i]
+			}
+			diffValues(fmt.Sprintf("%s[%d]", path, i), o, n, changes)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(oldValue, newValue) {
+		*changes = append(*changes, specChange{Path: path, Kind: specChangeChanged, Old: oldValue, New: newValue})
+	}
+}
+
+func joinSpecPath(path, key string) string {
+	if strings.ContainsAny(key, ".[]") {
+		return fmt.Sprintf("%s[%q]", path, key)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// printSpecDiff writes diff to out, either as a JSON document or as one line
+// per change, prefixed with "+", "-" or "~".
+func printSpecDiff(out io.Writer, diff specDiff, format string) error {
+	if format == "json" {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "    ")
+		return enc.Encode(diff)
+	}
+
+	if len(diff.Changes) == 0 {
+		fmt.Fprintf(out, "No changes to service %s\n", diff.Name)
+		return nil
+	}
+	for _, change := range diff.Changes {
+		switch change.Kind {
+		case specChangeAdded:
+			fmt.Fprintf(out, "+ %s: %s\n", change.Path, formatSpecValue(change.New))
+		case specChangeRemoved:
+			fmt.Fprintf(out, "- %s: %s\n", change.Path, formatSpecValue(change.Old))
+		default:
+			fmt.Fprintf(out, "~ %s: %s => %s\n", change.Path, formatSpecValue(change.Old), formatSpecValue(change.New))
+		}
+	}
+	return nil
+}
+
+func formatSpecValue(v interface{}) string {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(raw)
+}
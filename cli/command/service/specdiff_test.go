@@ -0,0 +1,55 @@
+package service
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffServiceSpecs(t *testing.T) {
+	replicas := uint64(2)
+	oldSpec := swarm.ServiceSpec{
+		Annotations: swarm.Annotations{
+			Name:   "web",
+			Labels: map[string]string{"tier": "front", "com.example.owner": "ops"},
+		},
+		TaskTemplate: swarm.TaskSpec{
+			ContainerSpec: &swarm.ContainerSpec{
+				Image: "nginx:1.12",
+				Env:   []string{"A=1", "B=2"},
+			},
+		},
+	}
+	newSpec, err := copyServiceSpec(oldSpec)
+	require.NoError(t, err)
+	newSpec.Labels["tier"] = "back"
+	delete(newSpec.Labels, "com.example.owner")
+	newSpec.TaskTemplate.ContainerSpec.Env = []string{"A=1"}
+	newSpec.Mode.Replicated = &swarm.ReplicatedService{Replicas: &replicas}
+
+	// copyServiceSpec must not share maps with the original
+	assert.Equal(t, "front", oldSpec.Labels["tier"])
+
+	changes, err := diffServiceSpecs(oldSpec, newSpec)
+	require.NoError(t, err)
+	assert.Equal(t, []specChange{
+		{Path: `Labels["com.example.owner"]`, Kind: specChangeRemoved, Old: "ops"},
+		{Path: "Labels.tier", Kind: specChangeChanged, Old: "front", New: "back"},
+		{Path: "Mode.Replicated", Kind: specChangeAdded, New: map[string]interface{}{"Replicas": float64(2)}},
+		{Path: "TaskTemplate.ContainerSpec.Env[1]", Kind: specChangeRemoved, Old: "B=2"},
+	}, changes)
+}
+
+func TestDiffServiceSpecsNoChanges(t *testing.T) {
+	spec := swarm.ServiceSpec{Annotations: swarm.Annotations{Name: "web"}}
+	changes, err := diffServiceSpecs(spec, spec)
+	require.NoError(t, err)
+	assert.Len(t, changes, 0)
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, printSpecDiff(buf, specDiff{Name: "web", Changes: changes}, ""))
+	assert.Equal(t, "No changes to service web\n", buf.String())
+}
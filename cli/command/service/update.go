@@ -8,6 +8,7 @@ import (
 
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/service/progress"
 	"github.com/docker/cli/opts"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
@@ -41,6 +42,9 @@ func newUpdateCommand(dockerCli command.Cli) *cobra.Command {
 	flags.SetAnnotation(flagRollback, "version", []string{"1.25"})
 	flags.Bool("force", false, "Force update even if no changes require it")
 	flags.SetAnnotation("force", "version", []string{"1.25"})
+	flags.Bool(flagDryRun, false, "Show the changes to the service specification without applying them")
+	flags.String(flagFormat, "", "Format the output of --dry-run (\"json\")")
+	addCanaryFlags(flags)
 	addServiceFlags(flags, options, nil)
 
 	flags.Var(newListOptsVar(), flagEnvRemove, "Remove an environment variable")
@@ -127,6 +131,41 @@ func runUpdate(dockerCli command.Cli, flags *pflag.FlagSet, options *serviceOpti
 		return err
 	}
 
+	dryRun, err := flags.GetBool(flagDryRun)
+	if err != nil {
+		return err
+	}
+	format, err := flags.GetString(flagFormat)
+	if err != nil {
+		return err
+	}
+	if format != "" {
+		if !dryRun {
+			return errors.Errorf("--%s can only be used with --%s", flagFormat, flagDryRun)
+		}
+		if format != "json" {
+			return errors.Errorf("unsupported format %q for --%s", format, flagDryRun)
+		}
+	}
+
+	canary, err := flags.GetBool(flagCanary)
+	if err != nil {
+		return err
+	}
+	var canaryOpts canaryOptions
+	if canary {
+		if canaryOpts, err = canaryOptionsFromFlags(flags); err != nil {
+			return err
+		}
+	}
+
+	// updateService changes maps and slices of the spec in place, so keep a
+	// deep copy of the current spec to compare against.
+	currentSpec, err := copyServiceSpec(service.Spec)
+	if err != nil {
+		return err
+	}
+
 	// There are two ways to do user-requested rollback. The old way is
 	// client-side, but with a sufficiently recent daemon we prefer
 	// server-side, because it will honor the rollback parameters.
@@ -140,7 +179,8 @@ func runUpdate(dockerCli command.Cli, flags *pflag.FlagSet, options *serviceOpti
 		// Rollback can't be combined with other flags.
 		otherFlagsPassed := false
 		flags.VisitAll(func(f *pflag.Flag) {
-			if f.Name == flagRollback || f.Name == flagDetach || f.Name == flagQuiet {
+			switch f.Name {
+			case flagRollback, flagDetach, flagQuiet, flagDryRun, flagFormat:
 				return
 			}
 			if flags.Changed(f.Name) {
@@ -195,6 +235,16 @@ func runUpdate(dockerCli command.Cli, flags *pflag.FlagSet, options *serviceOpti
 
 	spec.TaskTemplate.ContainerSpec.Configs = updatedConfigs
 
+	if dryRun {
+		if serverSideRollback {
+			if service.PreviousSpec == nil {
+				return errors.Errorf("service does not have a previous specification to roll back to")
+			}
+			spec = service.PreviousSpec
+		}
+		return printUpdateDryRun(dockerCli, service, currentSpec, *spec, format)
+	}
+
 	// only send auth if flag was set
 	sendAuth, err := flags.GetBool(flagRegistryAuth)
 	if err != nil {
@@ -215,6 +265,10 @@ func runUpdate(dockerCli command.Cli, flags *pflag.FlagSet, options *serviceOpti
 		updateOpts.RegistryAuthFrom = types.RegistryAuthFromSpec
 	}
 
+	if canary {
+		return runCanaryUpdate(ctx, dockerCli, service, *spec, updateOpts, canaryOpts, options)
+	}
+
 	response, err := apiClient.ServiceUpdate(ctx, service.ID, service.Version, *spec, updateOpts)
 	if err != nil {
 		return err
@@ -230,7 +284,22 @@ func runUpdate(dockerCli command.Cli, flags *pflag.FlagSet, options *serviceOpti
 		return nil
 	}
 
-	return waitOnService(ctx, dockerCli, serviceID, options.quiet)
+	return progress.WaitOnService(ctx, dockerCli, serviceID, options.quiet)
+}
+
+// printUpdateDryRun prints the difference between the current spec of service
+// and the spec that would have been sent to the daemon.
+func printUpdateDryRun(dockerCli command.Cli, service swarm.Service, currentSpec, newSpec swarm.ServiceSpec, format string) error {
+	changes, err := diffServiceSpecs(currentSpec, newSpec)
+	if err != nil {
+		return err
+	}
+	diff := specDiff{
+		ID:      service.ID,
+		Name:    currentSpec.Name,
+		Changes: changes,
+	}
+	return printSpecDiff(dockerCli.Out(), diff, format)
 }
 
 // nolint: gocyclo
@@ -1,16 +1,23 @@
 package service
 
 import (
+	"encoding/json"
+	"io/ioutil"
 	"reflect"
 	"sort"
 	"testing"
 	"time"
 
+	"github.com/docker/cli/internal/test"
+	// Import builders to get the builder function as package function
+	. "github.com/docker/cli/internal/test/builders"
 	"github.com/docker/cli/internal/test/testutil"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	mounttypes "github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/swarm"
+	"github.com/gotestyourself/gotestyourself/golden"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/net/context"
@@ -586,3 +593,58 @@ func TestRemoveGenericResources(t *testing.T) {
 	assert.NoError(t, removeGenericResources(flags, task))
 	assert.Len(t, task.Resources.Reservations.GenericResources, 1)
 }
+
+func TestUpdateDryRun(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		serviceInspectWithRawFunc: func(ctx context.Context, serviceID string, options types.ServiceInspectOptions) (swarm.Service, []byte, error) {
+			return *Service(
+				ServiceID("service-id"),
+				ServiceName("web"),
+				ServiceImage("nginx:1.12"),
+				ServiceLabels(map[string]string{"com.example.tier": "front"}),
+			), nil, nil
+		},
+		serviceUpdateFunc: func(ctx context.Context, serviceID string, version swarm.Version, service swarm.ServiceSpec, options types.ServiceUpdateOptions) (types.ServiceUpdateResponse, error) {
+			return types.ServiceUpdateResponse{}, errors.New("ServiceUpdate must not be called with --dry-run")
+		},
+	})
+	cmd := newUpdateCommand(cli)
+	cmd.SetArgs([]string{"web"})
+	cmd.Flags().Set(flagDryRun, "true")
+	cmd.Flags().Set("image", "nginx:1.13")
+	cmd.Flags().Set(flagLabelRemove, "com.example.tier")
+	cmd.Flags().Set(flagEnvAdd, "FOO=bar")
+	cmd.Flags().Set(flagNoResolveImage, "true")
+	require.NoError(t, cmd.Execute())
+	golden.Assert(t, cli.OutBuffer().String(), "service-update-dry-run.golden")
+}
+
+func TestUpdateDryRunJSON(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		serviceInspectWithRawFunc: func(ctx context.Context, serviceID string, options types.ServiceInspectOptions) (swarm.Service, []byte, error) {
+			return *Service(ServiceID("service-id"), ServiceName("web"), ServiceImage("nginx:1.12")), nil, nil
+		},
+	})
+	cmd := newUpdateCommand(cli)
+	cmd.SetArgs([]string{"web"})
+	cmd.Flags().Set(flagDryRun, "true")
+	cmd.Flags().Set(flagFormat, "json")
+	cmd.Flags().Set(flagHostname, "web-host")
+	require.NoError(t, cmd.Execute())
+
+	var diff specDiff
+	require.NoError(t, json.Unmarshal(cli.OutBuffer().Bytes(), &diff))
+	assert.Equal(t, "service-id", diff.ID)
+	assert.Equal(t, "web", diff.Name)
+	assert.Equal(t, []specChange{
+		{Path: "TaskTemplate.ContainerSpec.Hostname", Kind: specChangeAdded, New: "web-host"},
+	}, diff.Changes)
+}
+
+func TestUpdateFormatRequiresDryRun(t *testing.T) {
+	cmd := newUpdateCommand(test.NewFakeCli(&fakeClient{}))
+	cmd.SetArgs([]string{"web"})
+	cmd.SetOutput(ioutil.Discard)
+	cmd.Flags().Set(flagFormat, "json")
+	testutil.ErrorContains(t, cmd.Execute(), "--format can only be used with --dry-run")
+}
@@ -0,0 +1,40 @@
+// Package servicedata implements the logic shared by the secret and config
+// commands. Secrets and configs are objects holding data that services mount
+// as files in their containers.
+package servicedata
+
+import (
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/swarm"
+	"golang.org/x/net/context"
+)
+
+// Object is a secret or a config.
+type Object struct {
+	ID   string
+	Name string
+}
+
+// Reference is a reference of a container spec to an object.
+type Reference struct {
+	ID string
+	// Target is the name of the file the object is mounted as, if any.
+	Target string
+}
+
+// Kind is the kind of objects, secrets or configs, that the helpers of this
+// package operate on.
+type Kind interface {
+	// Name returns the name of the objects, "secret" or "config".
+	Name() string
+	// List returns the objects matching the filters.
+	List(ctx context.Context, filters filters.Args) ([]Object, error)
+	// Remove removes the object id.
+	Remove(ctx context.Context, id string) error
+	// References returns the references of spec to objects of this kind.
+	References(spec *swarm.ContainerSpec) []Reference
+	// ReplaceReferences re-points the references of spec to the object
+	// oldID to the object newID, keeping the target file and its
+	// permissions. It returns whether spec references the object oldID.
+	ReplaceReferences(spec *swarm.ContainerSpec, oldID, newID, newName string) bool
+}
@@ -0,0 +1,79 @@
+package servicedata
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/opts"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"golang.org/x/net/context"
+	"vbom.ml/util/sortorder"
+)
+
+// PruneOptions are the options of the prune commands.
+type PruneOptions struct {
+	Force  bool
+	Filter opts.FilterOpt
+}
+
+// NewPruneOptions returns the default options of the prune commands.
+func NewPruneOptions() PruneOptions {
+	return PruneOptions{Filter: opts.NewFilterOpt()}
+}
+
+// AddPruneFlags adds the flags of the prune commands to flags.
+func AddPruneFlags(flags *pflag.FlagSet, options *PruneOptions) {
+	flags.BoolVarP(&options.Force, "force", "f", false, "Do not prompt for confirmation")
+	flags.Var(&options.Filter, "filter", "Provide filter values (e.g. 'label=<label>')")
+}
+
+// Prune removes the objects of kind matching the filters that are not used
+// by any service.
+func Prune(ctx context.Context, dockerCli command.Cli, kind Kind, options PruneOptions) error {
+	warning := fmt.Sprintf("WARNING! This will remove all %ss not used by any service.\nAre you sure you want to continue?", kind.Name())
+	if !options.Force && !command.PromptForConfirmation(dockerCli.In(), dockerCli.Out(), warning) {
+		return nil
+	}
+
+	objects, err := kind.List(ctx, options.Filter.Value())
+	if err != nil {
+		return err
+	}
+	usage, err := ServiceUsage(ctx, dockerCli.Client(), kind)
+	if err != nil {
+		return err
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		return sortorder.NaturalLess(objects[i].Name, objects[j].Name)
+	})
+
+	var (
+		deleted []string
+		errs    []string
+	)
+	for _, object := range objects {
+		if len(usage[object.ID]) > 0 {
+			continue
+		}
+		if err := kind.Remove(ctx, object.ID); err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		deleted = append(deleted, object.Name)
+	}
+
+	if len(deleted) > 0 {
+		fmt.Fprintf(dockerCli.Out(), "Deleted %ss:\n", strings.Title(kind.Name()))
+		for _, name := range deleted {
+			fmt.Fprintln(dockerCli.Out(), name)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.Errorf("%s", strings.Join(errs, "\n"))
+	}
+	return nil
+}
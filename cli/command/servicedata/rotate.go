@@ -0,0 +1,98 @@
+package servicedata
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/service/progress"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/versions"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"golang.org/x/net/context"
+)
+
+// RotateOptions are the options of the rotate commands.
+type RotateOptions struct {
+	NewName string
+	Detach  bool
+	Quiet   bool
+}
+
+// AddRotateFlags adds the flags of the rotate command of kind to flags.
+func AddRotateFlags(flags *pflag.FlagSet, kind Kind, options *RotateOptions) {
+	flags.StringVar(&options.NewName, "name", "", fmt.Sprintf("Name of the new version of the %s (default: the name with an incremented version suffix)", kind.Name()))
+	flags.BoolVarP(&options.Detach, "detach", "d", false, "Exit immediately instead of waiting for the services to converge")
+	flags.SetAnnotation("detach", "version", []string{"1.29"})
+	flags.BoolVarP(&options.Quiet, "quiet", "q", false, "Suppress progress output")
+}
+
+// Rotate replaces the object old with a new version, created by calling
+// create with its name. The services using old are updated to use the new
+// version, and old is removed once they have converged.
+func Rotate(ctx context.Context, dockerCli command.Cli, kind Kind, old Object, create func(name string) (string, error), options RotateOptions) error {
+	client := dockerCli.Client()
+
+	services, err := client.ServiceList(ctx, types.ServiceListOptions{})
+	if err != nil {
+		return err
+	}
+
+	name := options.NewName
+	if name == "" {
+		name = nextVersionName(old.Name)
+	}
+	id, err := create(name)
+	if err != nil {
+		return err
+	}
+	out := dockerCli.Out()
+	fmt.Fprintf(out, "Created %s %s (%s)\n", kind.Name(), name, id)
+
+	var updated []string
+	for _, s := range services {
+		containerSpec := s.Spec.TaskTemplate.ContainerSpec
+		if containerSpec == nil || !kind.ReplaceReferences(containerSpec, old.ID, id, name) {
+			continue
+		}
+		response, err := client.ServiceUpdate(ctx, s.ID, s.Version, s.Spec, types.ServiceUpdateOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "failed to update service %s, %s %s was not removed", s.Spec.Name, kind.Name(), old.Name)
+		}
+		for _, warning := range response.Warnings {
+			fmt.Fprintln(dockerCli.Err(), warning)
+		}
+		fmt.Fprintf(out, "Updated service %s\n", s.Spec.Name)
+		updated = append(updated, s.ID)
+	}
+
+	if !options.Detach && !versions.LessThan(client.ClientVersion(), "1.29") {
+		for _, serviceID := range updated {
+			if err := progress.WaitOnService(ctx, dockerCli, serviceID, options.Quiet); err != nil {
+				return errors.Wrapf(err, "%s %s was not removed", kind.Name(), old.Name)
+			}
+		}
+	}
+
+	if err := kind.Remove(ctx, old.ID); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Removed %s %s (%s)\n", kind.Name(), old.Name, old.ID)
+	return nil
+}
+
+var versionSuffix = regexp.MustCompile(`^(.*[._-]v)([0-9]+)$`)
+
+// nextVersionName returns the name of the next version of an object, by
+// incrementing the version suffix of name ("db_password_v2" becomes
+// "db_password_v3"), or adding one ("db_password" becomes "db_password_v2").
+func nextVersionName(name string) string {
+	if m := versionSuffix.FindStringSubmatch(name); m != nil {
+		if version, err := strconv.Atoi(m[2]); err == nil {
+			return m[1] + strconv.Itoa(version+1)
+		}
+	}
+	return name + "_v2"
+}
@@ -0,0 +1,22 @@
+package servicedata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextVersionName(t *testing.T) {
+	testCases := map[string]string{
+		"db_password":     "db_password_v2",
+		"db_password_v2":  "db_password_v3",
+		"db_password.v9":  "db_password.v10",
+		"db_password-v1":  "db_password-v2",
+		"db_passwordv2":   "db_passwordv2_v2",
+		"db_password_v":   "db_password_v_v2",
+		"nginx.conf_v1.2": "nginx.conf_v1.2_v2",
+	}
+	for name, expected := range testCases {
+		assert.Equal(t, expected, nextVersionName(name), name)
+	}
+}
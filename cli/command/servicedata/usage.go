@@ -0,0 +1,92 @@
+package servicedata
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/docker/cli/cli/command/formatter"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"vbom.ml/util/sortorder"
+)
+
+// Usage holds the services referencing each object, keyed by object ID.
+type Usage map[string][]formatter.ServiceReference
+
+// ServiceUsage returns the services referencing the objects of kind, sorted
+// by name.
+func ServiceUsage(ctx context.Context, client client.APIClient, kind Kind) (Usage, error) {
+	services, err := client.ServiceList(ctx, types.ServiceListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(services, func(i, j int) bool {
+		return sortorder.NaturalLess(services[i].Spec.Name, services[j].Spec.Name)
+	})
+
+	usage := Usage{}
+	for _, service := range services {
+		containerSpec := service.Spec.TaskTemplate.ContainerSpec
+		if containerSpec == nil {
+			continue
+		}
+		for _, ref := range kind.References(containerSpec) {
+			usage[ref.ID] = append(usage[ref.ID], formatter.ServiceReference{
+				Service: service.Spec.Name,
+				Target:  ref.Target,
+			})
+		}
+	}
+	return usage, nil
+}
+
+// wholeObjectPattern matches the template actions printing a whole object.
+var wholeObjectPattern = regexp.MustCompile(`{{-?\s*\.\s*-?}}`)
+
+// FormatUsesServices returns whether the ls format shows the services using
+// the objects, in a column or as part of the whole object, for example in
+// its JSON representation.
+func FormatUsesServices(format string) bool {
+	return strings.Contains(format, ".Services") ||
+		strings.Contains(format, "json") ||
+		wholeObjectPattern.MatchString(format)
+}
+
+// UsedFilter is the "used" filter of the ls commands. It is applied by the
+// client, as it is not supported by the daemon.
+type UsedFilter struct {
+	used *bool
+}
+
+// NewUsedFilter removes the "used" filter from listFilters, and returns it.
+func NewUsedFilter(listFilters filters.Args) (UsedFilter, error) {
+	values := listFilters.Get("used")
+	if len(values) == 0 {
+		return UsedFilter{}, nil
+	}
+	if len(values) > 1 {
+		return UsedFilter{}, errors.New("the \"used\" filter can only be specified once")
+	}
+	used, err := strconv.ParseBool(values[0])
+	if err != nil {
+		return UsedFilter{}, errors.Errorf("invalid filter 'used=%s'", values[0])
+	}
+	listFilters.Del("used", values[0])
+	return UsedFilter{used: &used}, nil
+}
+
+// IsSet returns whether the filter is set, in which case Match needs the
+// usage of the objects.
+func (f UsedFilter) IsSet() bool {
+	return f.used != nil
+}
+
+// Match returns whether the object id matches the filter.
+func (f UsedFilter) Match(usage Usage, id string) bool {
+	return f.used == nil || (len(usage[id]) > 0) == *f.used
+}
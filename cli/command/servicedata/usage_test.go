@@ -0,0 +1,21 @@
+package servicedata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatUsesServices(t *testing.T) {
+	testCases := map[string]bool{
+		"table":                     false,
+		"{{.Name}}":                 false,
+		"{{.Name}}={{.Services}}":   true,
+		"{{json .}}":                true,
+		"{{ . }}":                   true,
+		"{{.ID}}\t{{json .Labels}}": true,
+	}
+	for format, expected := range testCases {
+		assert.Equal(t, expected, FormatUsesServices(format), format)
+	}
+}
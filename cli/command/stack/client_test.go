@@ -3,6 +3,7 @@ package stack
 import (
 	"strings"
 
+	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/compose/convert"
 	"github.com/docker/docker/api"
 	"github.com/docker/docker/api/types"
@@ -37,6 +38,13 @@ type fakeClient struct {
 
 	serviceUpdateFunc func(serviceID string, version swarm.Version, service swarm.ServiceSpec, options types.ServiceUpdateOptions) (types.ServiceUpdateResponse, error)
 
+	secretInspectFunc func(id string) (swarm.Secret, []byte, error)
+	secretCreateFunc  func(spec swarm.SecretSpec) (types.SecretCreateResponse, error)
+	secretUpdateFunc  func(id string, version swarm.Version, spec swarm.SecretSpec) error
+	configInspectFunc func(id string) (swarm.Config, []byte, error)
+	configCreateFunc  func(spec swarm.ConfigSpec) (types.ConfigCreateResponse, error)
+	configUpdateFunc  func(id string, version swarm.Version, spec swarm.ConfigSpec) error
+
 	serviceRemoveFunc func(serviceID string) error
 	networkRemoveFunc func(networkID string) error
 	secretRemoveFunc  func(secretID string) error
@@ -179,6 +187,48 @@ func (cli *fakeClient) ConfigRemove(ctx context.Context, configID string) error
 	return nil
 }
 
+func (cli *fakeClient) SecretInspectWithRaw(ctx context.Context, id string) (swarm.Secret, []byte, error) {
+	if cli.secretInspectFunc != nil {
+		return cli.secretInspectFunc(id)
+	}
+	return swarm.Secret{}, nil, nil
+}
+
+func (cli *fakeClient) SecretCreate(ctx context.Context, spec swarm.SecretSpec) (types.SecretCreateResponse, error) {
+	if cli.secretCreateFunc != nil {
+		return cli.secretCreateFunc(spec)
+	}
+	return types.SecretCreateResponse{}, nil
+}
+
+func (cli *fakeClient) SecretUpdate(ctx context.Context, id string, version swarm.Version, spec swarm.SecretSpec) error {
+	if cli.secretUpdateFunc != nil {
+		return cli.secretUpdateFunc(id, version, spec)
+	}
+	return nil
+}
+
+func (cli *fakeClient) ConfigInspectWithRaw(ctx context.Context, id string) (swarm.Config, []byte, error) {
+	if cli.configInspectFunc != nil {
+		return cli.configInspectFunc(id)
+	}
+	return swarm.Config{}, nil, nil
+}
+
+func (cli *fakeClient) ConfigCreate(ctx context.Context, spec swarm.ConfigSpec) (types.ConfigCreateResponse, error) {
+	if cli.configCreateFunc != nil {
+		return cli.configCreateFunc(spec)
+	}
+	return types.ConfigCreateResponse{}, nil
+}
+
+func (cli *fakeClient) ConfigUpdate(ctx context.Context, id string, version swarm.Version, spec swarm.ConfigSpec) error {
+	if cli.configUpdateFunc != nil {
+		return cli.configUpdateFunc(id, version, spec)
+	}
+	return nil
+}
+
 func serviceFromName(name string) swarm.Service {
 	return swarm.Service{
 		ID: "ID-" + name,
@@ -237,3 +287,15 @@ func buildObjectIDs(objectNames []string) []string {
 	}
 	return IDs
 }
+
+type fakeTemplatingClient struct {
+	command.TemplatingClient
+	configCreateFunc func(command.ConfigSpecWithTemplating) (types.ConfigCreateResponse, error)
+}
+
+func (c *fakeTemplatingClient) ConfigCreate(ctx context.Context, spec command.ConfigSpecWithTemplating) (types.ConfigCreateResponse, error) {
+	if c.configCreateFunc != nil {
+		return c.configCreateFunc(spec)
+	}
+	return types.ConfigCreateResponse{}, nil
+}
@@ -27,6 +27,7 @@ type deployOptions struct {
 	resolveImage     string
 	sendRegistryAuth bool
 	prune            bool
+	allowExecSources bool
 }
 
 func newDeployCommand(dockerCli command.Cli) *cobra.Command {
@@ -52,6 +53,7 @@ func newDeployCommand(dockerCli command.Cli) *cobra.Command {
 	flags.StringVar(&opts.resolveImage, "resolve-image", resolveImageAlways,
 		`Query the registry to resolve image digest and supported platforms ("`+resolveImageAlways+`"|"`+resolveImageChanged+`"|"`+resolveImageNever+`")`)
 	flags.SetAnnotation("resolve-image", "version", []string{"1.30"})
+	flags.BoolVar(&opts.allowExecSources, "allow-exec-sources", false, "Allow secrets and configs of the Compose file to run a command on the client to read their data")
 	return cmd
 }
 
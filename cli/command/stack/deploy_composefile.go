@@ -13,6 +13,8 @@ import (
 	"github.com/docker/cli/cli/compose/convert"
 	"github.com/docker/cli/cli/compose/loader"
 	composetypes "github.com/docker/cli/cli/compose/types"
+	"github.com/docker/cli/secret/source"
+	"github.com/docker/cli/templates"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/swarm"
@@ -44,6 +46,12 @@ func deployCompose(ctx context.Context, dockerCli command.Cli, opts deployOption
 			strings.Join(unsupportedProperties, ", "))
 	}
 
+	if !opts.allowExecSources {
+		if err := checkExecSources(config); err != nil {
+			return err
+		}
+	}
+
 	deprecatedProperties := loader.GetDeprecatedProperties(configDetails)
 	if len(deprecatedProperties) > 0 {
 		fmt.Fprintf(dockerCli.Err(), "Ignoring deprecated options:\n\n%s\n\n",
@@ -77,7 +85,9 @@ func deployCompose(ctx context.Context, dockerCli command.Cli, opts deployOption
 	if err != nil {
 		return err
 	}
-	if err := createSecrets(ctx, dockerCli, secrets); err != nil {
+	secretSources := convert.SecretSources(namespace, config.Secrets)
+	secretTemplateDrivers := convert.SecretTemplateDrivers(namespace, config.Secrets)
+	if err := createSecrets(ctx, dockerCli, secrets, secretSources, secretTemplateDrivers); err != nil {
 		return err
 	}
 
@@ -85,7 +95,9 @@ func deployCompose(ctx context.Context, dockerCli command.Cli, opts deployOption
 	if err != nil {
 		return err
 	}
-	if err := createConfigs(ctx, dockerCli, configs); err != nil {
+	configSources := convert.ConfigSources(namespace, config.Configs)
+	configTemplateDrivers := convert.ConfigTemplateDrivers(namespace, config.Configs)
+	if err := createConfigs(ctx, dockerCli, configs, configSources, configTemplateDrivers); err != nil {
 		return err
 	}
 
@@ -211,6 +223,8 @@ func createSecrets(
 	ctx context.Context,
 	dockerCli command.Cli,
 	secrets []swarm.SecretSpec,
+	sources map[string]string,
+	templateDrivers map[string]string,
 ) error {
 	client := dockerCli.Client()
 
@@ -218,14 +232,20 @@ func createSecrets(
 		secret, _, err := client.SecretInspectWithRaw(ctx, secretSpec.Name)
 		switch {
 		case err == nil:
-			// secret already exists, then we update that
+			// secret already exists, then we update that. The source of
+			// a secret is not read again, so that its value is kept.
 			if err := client.SecretUpdate(ctx, secret.ID, secret.Meta.Version, secretSpec); err != nil {
 				return errors.Wrapf(err, "failed to update secret %s", secretSpec.Name)
 			}
 		case apiclient.IsErrNotFound(err):
 			// secret does not exist, then we create a new one.
 			fmt.Fprintf(dockerCli.Out(), "Creating secret %s\n", secretSpec.Name)
-			if _, err := client.SecretCreate(ctx, secretSpec); err != nil {
+			if src, ok := sources[secretSpec.Name]; ok {
+				if secretSpec.Data, err = readSource(src); err != nil {
+					return errors.Wrapf(err, "failed to create secret %s", secretSpec.Name)
+				}
+			}
+			if err := createSecret(ctx, dockerCli, secretSpec, templateDrivers[secretSpec.Name]); err != nil {
 				return errors.Wrapf(err, "failed to create secret %s", secretSpec.Name)
 			}
 		default:
@@ -235,10 +255,87 @@ func createSecrets(
 	return nil
 }
 
+// checkExecSources returns an error if a secret or config of the Compose file
+// uses an exec source, as deploying the stack would run its command on the
+// client.
+func checkExecSources(config *composetypes.Config) error {
+	for name, secret := range config.Secrets {
+		if isExecSource(composetypes.FileObjectConfig(secret)) {
+			return errors.Errorf("secret %s: exec sources run a command on the client, use --allow-exec-sources to deploy them", name)
+		}
+	}
+	for name, config := range config.Configs {
+		if isExecSource(composetypes.FileObjectConfig(config)) {
+			return errors.Errorf("config %s: exec sources run a command on the client, use --allow-exec-sources to deploy them", name)
+		}
+	}
+	return nil
+}
+
+func isExecSource(obj composetypes.FileObjectConfig) bool {
+	return !obj.External.External && strings.HasPrefix(obj.Source, "exec:")
+}
+
+// createSecret creates a secret, with the template driver templateDriver if it
+// is not empty.
+func createSecret(ctx context.Context, dockerCli command.Cli, spec swarm.SecretSpec, templateDriver string) error {
+	if templateDriver == "" {
+		_, err := dockerCli.Client().SecretCreate(ctx, spec)
+		return err
+	}
+	if err := validateTemplate(spec.Data, templateDriver); err != nil {
+		return err
+	}
+	_, err := dockerCli.TemplatingClient().SecretCreate(ctx, command.SecretSpecWithTemplating{
+		SecretSpec: spec,
+		Templating: &swarm.Driver{Name: templateDriver},
+	})
+	return err
+}
+
+// createConfig creates a config, with the template driver templateDriver if it
+// is not empty.
+func createConfig(ctx context.Context, dockerCli command.Cli, spec swarm.ConfigSpec, templateDriver string) error {
+	if templateDriver == "" {
+		_, err := dockerCli.Client().ConfigCreate(ctx, spec)
+		return err
+	}
+	if err := validateTemplate(spec.Data, templateDriver); err != nil {
+		return err
+	}
+	_, err := dockerCli.TemplatingClient().ConfigCreate(ctx, command.ConfigSpecWithTemplating{
+		ConfigSpec: spec,
+		Templating: &swarm.Driver{Name: templateDriver},
+	})
+	return err
+}
+
+// validateTemplate returns an error if data is not a valid template for the
+// template driver of the daemon.
+func validateTemplate(data []byte, templateDriver string) error {
+	if templateDriver != templates.PayloadTemplateDriver {
+		return nil
+	}
+	_, err := templates.ParsePayload(string(data), templates.PayloadFunctions{})
+	return errors.Wrap(err, "invalid template")
+}
+
+// readSource reads the data of a secret or config from its x-source.
+func readSource(spec string) ([]byte, error) {
+	src, err := source.Parse(spec)
+	if err != nil {
+		return nil, err
+	}
+	data, err := src.Read()
+	return data, errors.Wrapf(err, "error reading from x-source %q", spec)
+}
+
 func createConfigs(
 	ctx context.Context,
 	dockerCli command.Cli,
 	configs []swarm.ConfigSpec,
+	sources map[string]string,
+	templateDrivers map[string]string,
 ) error {
 	client := dockerCli.Client()
 
@@ -246,15 +343,21 @@ func createConfigs(
 		config, _, err := client.ConfigInspectWithRaw(ctx, configSpec.Name)
 		switch {
 		case err == nil:
-			// config already exists, then we update that
+			// config already exists, then we update that. The source of
+			// a config is not read again, so that its value is kept.
 			if err := client.ConfigUpdate(ctx, config.ID, config.Meta.Version, configSpec); err != nil {
 				errors.Wrapf(err, "failed to update config %s", configSpec.Name)
 			}
 		case apiclient.IsErrNotFound(err):
 			// config does not exist, then we create a new one.
 			fmt.Fprintf(dockerCli.Out(), "Creating config %s\n", configSpec.Name)
-			if _, err := client.ConfigCreate(ctx, configSpec); err != nil {
-				errors.Wrapf(err, "failed to create config %s", configSpec.Name)
+			if src, ok := sources[configSpec.Name]; ok {
+				if configSpec.Data, err = readSource(src); err != nil {
+					return errors.Wrapf(err, "failed to create config %s", configSpec.Name)
+				}
+			}
+			if err := createConfig(ctx, dockerCli, configSpec, templateDrivers[configSpec.Name]); err != nil {
+				return errors.Wrapf(err, "failed to create config %s", configSpec.Name)
 			}
 		default:
 			return err
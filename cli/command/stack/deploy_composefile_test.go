@@ -6,9 +6,13 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/docker/cli/cli/command"
+	composetypes "github.com/docker/cli/cli/compose/types"
+	"github.com/docker/cli/internal/test"
 	"github.com/docker/cli/internal/test/network"
 	"github.com/docker/cli/internal/test/testutil"
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
 	"github.com/gotestyourself/gotestyourself/fs"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -103,3 +107,110 @@ func TestValidateExternalNetworks(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckExecSources(t *testing.T) {
+	config := &composetypes.Config{
+		Secrets: map[string]composetypes.SecretConfig{
+			"password": {Source: "env:PASSWORD"},
+		},
+		Configs: map[string]composetypes.ConfigObjConfig{
+			"key": {Source: "generate:base64:16"},
+		},
+	}
+	assert.NoError(t, checkExecSources(config))
+
+	config.Secrets["token"] = composetypes.SecretConfig{Source: "exec:pass show token"}
+	testutil.ErrorContains(t, checkExecSources(config), "secret token: exec sources run a command on the client, use --allow-exec-sources to deploy them")
+
+	delete(config.Secrets, "token")
+	config.Configs["cert"] = composetypes.ConfigObjConfig{Source: "exec:cat cert.pem"}
+	testutil.ErrorContains(t, checkExecSources(config), "config cert: exec sources run a command on the client")
+}
+
+func TestCreateSecretsFromSource(t *testing.T) {
+	var (
+		created = map[string][]byte{}
+		updated = map[string][]byte{}
+	)
+	fakeClient := &fakeClient{
+		secretInspectFunc: func(id string) (swarm.Secret, []byte, error) {
+			if id == "stack_existing" {
+				return swarm.Secret{ID: "ID-" + id}, nil, nil
+			}
+			return swarm.Secret{}, nil, notFound{}
+		},
+		secretCreateFunc: func(spec swarm.SecretSpec) (types.SecretCreateResponse, error) {
+			created[spec.Name] = spec.Data
+			return types.SecretCreateResponse{}, nil
+		},
+		secretUpdateFunc: func(id string, version swarm.Version, spec swarm.SecretSpec) error {
+			updated[spec.Name] = spec.Data
+			return nil
+		},
+	}
+	dockerCli := test.NewFakeCli(fakeClient)
+	secrets := []swarm.SecretSpec{
+		{Annotations: swarm.Annotations{Name: "stack_existing"}},
+		{Annotations: swarm.Annotations{Name: "stack_new"}},
+	}
+	sources := map[string]string{
+		// the source of an existing secret is not read
+		"stack_existing": "env:STACK_TEST_UNSET",
+		"stack_new":      "generate:bytes:16",
+	}
+	require.NoError(t, createSecrets(context.Background(), dockerCli, secrets, sources, nil))
+	assert.Equal(t, map[string][]byte{"stack_existing": nil}, updated)
+	require.Contains(t, created, "stack_new")
+	assert.Len(t, created["stack_new"], 16)
+
+	sources["stack_new"] = "env:STACK_TEST_UNSET"
+	err := createSecrets(context.Background(), dockerCli, secrets, sources, nil)
+	testutil.ErrorContains(t, err, "failed to create secret stack_new: error reading from x-source \"env:STACK_TEST_UNSET\"")
+}
+
+func TestCreateConfigsFromSource(t *testing.T) {
+	var created swarm.ConfigSpec
+	fakeClient := &fakeClient{
+		configInspectFunc: func(id string) (swarm.Config, []byte, error) {
+			return swarm.Config{}, nil, notFound{}
+		},
+		configCreateFunc: func(spec swarm.ConfigSpec) (types.ConfigCreateResponse, error) {
+			created = spec
+			return types.ConfigCreateResponse{}, nil
+		},
+	}
+	configs := []swarm.ConfigSpec{{Annotations: swarm.Annotations{Name: "stack_key"}}}
+	sources := map[string]string{"stack_key": "generate:base64:16"}
+	require.NoError(t, createConfigs(context.Background(), test.NewFakeCli(fakeClient), configs, sources, nil))
+	assert.Len(t, created.Data, 24)
+}
+
+func TestCreateConfigsWithTemplateDriver(t *testing.T) {
+	var created command.ConfigSpecWithTemplating
+	dockerCli := test.NewFakeCli(&fakeClient{
+		configInspectFunc: func(id string) (swarm.Config, []byte, error) {
+			return swarm.Config{}, nil, notFound{}
+		},
+		configCreateFunc: func(spec swarm.ConfigSpec) (types.ConfigCreateResponse, error) {
+			return types.ConfigCreateResponse{}, errors.New("unexpected creation of the config without its template driver")
+		},
+	})
+	dockerCli.SetTemplatingClient(&fakeTemplatingClient{
+		configCreateFunc: func(spec command.ConfigSpecWithTemplating) (types.ConfigCreateResponse, error) {
+			created = spec
+			return types.ConfigCreateResponse{}, nil
+		},
+	})
+	configs := []swarm.ConfigSpec{{
+		Annotations: swarm.Annotations{Name: "stack_nginx"},
+		Data:        []byte(`listen {{env "PORT"}}`),
+	}}
+	templateDrivers := map[string]string{"stack_nginx": "golang"}
+	require.NoError(t, createConfigs(context.Background(), dockerCli, configs, nil, templateDrivers))
+	assert.Equal(t, "stack_nginx", created.Name)
+	assert.Equal(t, &swarm.Driver{Name: "golang"}, created.Templating)
+
+	configs[0].Data = []byte(`{{hostname}}`)
+	err := createConfigs(context.Background(), dockerCli, configs, nil, templateDrivers)
+	testutil.ErrorContains(t, err, `failed to create config stack_nginx: invalid template: template: expansion:1: function "hostname" not defined`)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/docker/cli/cli/command/task"
 	"github.com/docker/cli/opts"
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
 	"github.com/spf13/cobra"
 	"golang.org/x/net/context"
 )
@@ -19,6 +20,7 @@ type psOptions struct {
 	namespace string
 	noResolve bool
 	quiet     bool
+	watch     bool
 	format    string
 }
 
@@ -39,6 +41,7 @@ func newPsCommand(dockerCli command.Cli) *cobra.Command {
 	flags.BoolVar(&options.noResolve, "no-resolve", false, "Do not map IDs to Names")
 	flags.VarP(&options.filter, "filter", "f", "Filter output based on conditions provided")
 	flags.BoolVarP(&options.quiet, "quiet", "q", false, "Only display task IDs")
+	flags.BoolVar(&options.watch, "watch", false, "Redraw the tasks as their state changes")
 	flags.StringVar(&options.format, "format", "", "Pretty-print tasks using a Go template")
 
 	return cmd
@@ -65,5 +68,12 @@ func runPS(dockerCli command.Cli, options psOptions) error {
 		format = task.DefaultFormat(dockerCli.ConfigFile(), options.quiet)
 	}
 
-	return task.Print(ctx, dockerCli, tasks, idresolver.New(client, options.noResolve), !options.noTrunc, options.quiet, format)
+	resolver := idresolver.New(client, options.noResolve)
+	if options.watch {
+		list := func(ctx context.Context) ([]swarm.Task, error) {
+			return client.TaskList(ctx, types.TaskListOptions{Filters: filter})
+		}
+		return task.Watch(ctx, dockerCli, list, resolver, !options.noTrunc, options.quiet, format)
+	}
+	return task.Print(ctx, dockerCli, tasks, resolver, !options.noTrunc, options.quiet, format)
 }
@@ -0,0 +1,139 @@
+package swarm
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/pkg/errors"
+)
+
+// archiveVersion is the version of the archive format written by
+// `docker swarm export`. It must be incremented on incompatible changes.
+const archiveVersion = 1
+
+const archiveManifestFile = "manifest.json"
+
+// archiveManifest describes the content of an archive
+type archiveManifest struct {
+	Version   int
+	CreatedAt time.Time
+}
+
+// exportedNetwork is a swarm scoped network, with the options it was
+// created with.
+type exportedNetwork struct {
+	Name string
+	types.NetworkCreate
+}
+
+// exportedNode holds the labels of a node, which is identified by its
+// hostname, as node IDs are not preserved when a swarm is rebuilt.
+type exportedNode struct {
+	Hostname string
+	Labels   map[string]string
+}
+
+// swarmArchive holds the objects of a swarm. Services reference networks,
+// secrets and configs by name, and secrets don't hold their value.
+type swarmArchive struct {
+	Swarm    swarm.Spec
+	Nodes    []exportedNode
+	Networks []exportedNetwork
+	Configs  []swarm.ConfigSpec
+	Secrets  []swarm.SecretSpec
+	Services []swarm.ServiceSpec
+}
+
+// archiveFiles returns the files of the archive, in the order they are
+// written, with the value each one is decoded into.
+func (a *swarmArchive) archiveFiles() []archiveFile {
+	return []archiveFile{
+		{name: "swarm.json", value: &a.Swarm},
+		{name: "nodes.json", value: &a.Nodes},
+		{name: "networks.json", value: &a.Networks},
+		{name: "configs.json", value: &a.Configs},
+		{name: "secrets.json", value: &a.Secrets},
+		{name: "services.json", value: &a.Services},
+	}
+}
+
+type archiveFile struct {
+	name  string
+	value interface{}
+}
+
+func writeArchive(w io.Writer, a *swarmArchive) error {
+	tw := tar.NewWriter(w)
+	files := append([]archiveFile{
+		{name: archiveManifestFile, value: archiveManifest{Version: archiveVersion, CreatedAt: now().UTC()}},
+	}, a.archiveFiles()...)
+	for _, file := range files {
+		content, err := json.MarshalIndent(file.value, "", "    ")
+		if err != nil {
+			return err
+		}
+		header := &tar.Header{
+			Name:    file.name,
+			Mode:    0644,
+			Size:    int64(len(content)),
+			ModTime: now(),
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := tw.Write(content); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+func readArchive(r io.Reader) (*swarmArchive, error) {
+	a := &swarmArchive{}
+	values := map[string]interface{}{}
+	for _, file := range a.archiveFiles() {
+		values[file.name] = file.value
+	}
+
+	tr := tar.NewReader(r)
+	var manifest *archiveManifest
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid swarm archive")
+		}
+		if header.Name == archiveManifestFile {
+			manifest = &archiveManifest{}
+			if err := json.NewDecoder(tr).Decode(manifest); err != nil {
+				return nil, errors.Wrapf(err, "invalid %s", header.Name)
+			}
+			if manifest.Version != archiveVersion {
+				return nil, errors.Errorf("unsupported swarm archive version %d, expected version %d", manifest.Version, archiveVersion)
+			}
+			continue
+		}
+		value, ok := values[header.Name]
+		if !ok {
+			// Ignore unknown files, which may be added by later versions
+			// without breaking compatibility.
+			continue
+		}
+		if manifest == nil {
+			return nil, errors.Errorf("invalid swarm archive: %s must be the first file", archiveManifestFile)
+		}
+		if err := json.NewDecoder(tr).Decode(value); err != nil {
+			return nil, errors.Wrapf(err, "invalid %s", header.Name)
+		}
+	}
+	if manifest == nil {
+		return nil, errors.Errorf("invalid swarm archive: %s not found", archiveManifestFile)
+	}
+	return a, nil
+}
@@ -9,15 +9,31 @@ import (
 
 type fakeClient struct {
 	client.Client
+	version               string
 	infoFunc              func() (types.Info, error)
 	swarmInitFunc         func() (string, error)
 	swarmInspectFunc      func() (swarm.Swarm, error)
 	nodeInspectFunc       func() (swarm.Node, []byte, error)
+	nodeListFunc          func() ([]swarm.Node, error)
+	serviceListFunc       func() ([]swarm.Service, error)
+	taskListFunc          func() ([]swarm.Task, error)
 	swarmGetUnlockKeyFunc func() (types.SwarmUnlockKeyResponse, error)
 	swarmJoinFunc         func() error
 	swarmLeaveFunc        func() error
 	swarmUpdateFunc       func(swarm swarm.Spec, flags swarm.UpdateFlags) error
 	swarmUnlockFunc       func(req swarm.UnlockRequest) error
+	nodeUpdateFunc        func(nodeID string, node swarm.NodeSpec) error
+	networkListFunc       func() ([]types.NetworkResource, error)
+	networkCreateFunc     func(name string, options types.NetworkCreate) (types.NetworkCreateResponse, error)
+	configListFunc        func() ([]swarm.Config, error)
+	configCreateFunc      func(spec swarm.ConfigSpec) (types.ConfigCreateResponse, error)
+	secretListFunc        func() ([]swarm.Secret, error)
+	secretCreateFunc      func(spec swarm.SecretSpec) (types.SecretCreateResponse, error)
+	serviceCreateFunc     func(spec swarm.ServiceSpec) (types.ServiceCreateResponse, error)
+}
+
+func (cli *fakeClient) ClientVersion() string {
+	return cli.version
 }
 
 func (cli *fakeClient) Info(ctx context.Context) (types.Info, error) {
@@ -34,6 +50,27 @@ func (cli *fakeClient) NodeInspectWithRaw(ctx context.Context, ref string) (swar
 	return swarm.Node{}, []byte{}, nil
 }
 
+func (cli *fakeClient) NodeList(ctx context.Context, options types.NodeListOptions) ([]swarm.Node, error) {
+	if cli.nodeListFunc != nil {
+		return cli.nodeListFunc()
+	}
+	return []swarm.Node{}, nil
+}
+
+func (cli *fakeClient) ServiceList(ctx context.Context, options types.ServiceListOptions) ([]swarm.Service, error) {
+	if cli.serviceListFunc != nil {
+		return cli.serviceListFunc()
+	}
+	return []swarm.Service{}, nil
+}
+
+func (cli *fakeClient) TaskList(ctx context.Context, options types.TaskListOptions) ([]swarm.Task, error) {
+	if cli.taskListFunc != nil {
+		return cli.taskListFunc()
+	}
+	return []swarm.Task{}, nil
+}
+
 func (cli *fakeClient) SwarmInit(ctx context.Context, req swarm.InitRequest) (string, error) {
 	if cli.swarmInitFunc != nil {
 		return cli.swarmInitFunc()
@@ -82,3 +119,59 @@ func (cli *fakeClient) SwarmUnlock(ctx context.Context, req swarm.UnlockRequest)
 	}
 	return nil
 }
+
+func (cli *fakeClient) NodeUpdate(ctx context.Context, nodeID string, version swarm.Version, node swarm.NodeSpec) error {
+	if cli.nodeUpdateFunc != nil {
+		return cli.nodeUpdateFunc(nodeID, node)
+	}
+	return nil
+}
+
+func (cli *fakeClient) NetworkList(ctx context.Context, options types.NetworkListOptions) ([]types.NetworkResource, error) {
+	if cli.networkListFunc != nil {
+		return cli.networkListFunc()
+	}
+	return []types.NetworkResource{}, nil
+}
+
+func (cli *fakeClient) NetworkCreate(ctx context.Context, name string, options types.NetworkCreate) (types.NetworkCreateResponse, error) {
+	if cli.networkCreateFunc != nil {
+		return cli.networkCreateFunc(name, options)
+	}
+	return types.NetworkCreateResponse{}, nil
+}
+
+func (cli *fakeClient) ConfigList(ctx context.Context, options types.ConfigListOptions) ([]swarm.Config, error) {
+	if cli.configListFunc != nil {
+		return cli.configListFunc()
+	}
+	return []swarm.Config{}, nil
+}
+
+func (cli *fakeClient) ConfigCreate(ctx context.Context, spec swarm.ConfigSpec) (types.ConfigCreateResponse, error) {
+	if cli.configCreateFunc != nil {
+		return cli.configCreateFunc(spec)
+	}
+	return types.ConfigCreateResponse{}, nil
+}
+
+func (cli *fakeClient) SecretList(ctx context.Context, options types.SecretListOptions) ([]swarm.Secret, error) {
+	if cli.secretListFunc != nil {
+		return cli.secretListFunc()
+	}
+	return []swarm.Secret{}, nil
+}
+
+func (cli *fakeClient) SecretCreate(ctx context.Context, spec swarm.SecretSpec) (types.SecretCreateResponse, error) {
+	if cli.secretCreateFunc != nil {
+		return cli.secretCreateFunc(spec)
+	}
+	return types.SecretCreateResponse{}, nil
+}
+
+func (cli *fakeClient) ServiceCreate(ctx context.Context, spec swarm.ServiceSpec, options types.ServiceCreateOptions) (types.ServiceCreateResponse, error) {
+	if cli.serviceCreateFunc != nil {
+		return cli.serviceCreateFunc(spec)
+	}
+	return types.ServiceCreateResponse{}, nil
+}
@@ -17,6 +17,9 @@ func NewSwarmCommand(dockerCli command.Cli) *cobra.Command {
 		Annotations: map[string]string{"version": "1.24"},
 	}
 	cmd.AddCommand(
+		newDoctorCommand(dockerCli),
+		newExportCommand(dockerCli),
+		newImportCommand(dockerCli),
 		newInitCommand(dockerCli),
 		newJoinCommand(dockerCli),
 		newJoinTokenCommand(dockerCli),
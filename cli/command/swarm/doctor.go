@@ -0,0 +1,370 @@
+package swarm
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/formatter"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/opencontainers/go-digest"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+// now returns the current time; it is replaced in tests.
+var now = time.Now
+
+type doctorOptions struct {
+	days       int
+	stuckAfter time.Duration
+	format     string
+}
+
+func newDoctorCommand(dockerCli command.Cli) *cobra.Command {
+	opts := doctorOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "doctor [OPTIONS]",
+		Short: "Check the health of the swarm",
+		Args:  cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDoctor(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.IntVar(&opts.days, "days", 30, "Warn about certificates expiring within this number of days")
+	flags.DurationVar(&opts.stuckAfter, "stuck-after", time.Hour, "Warn about service updates in progress for longer than this duration")
+	flags.StringVar(&opts.format, "format", "", "Pretty-print the report using a Go template, or \"json\"")
+	return cmd
+}
+
+func runDoctor(dockerCli command.Cli, opts doctorOptions) error {
+	client := dockerCli.Client()
+	ctx := context.Background()
+
+	swarmInspect, err := client.SwarmInspect(ctx)
+	if err != nil {
+		return err
+	}
+	nodes, err := client.NodeList(ctx, types.NodeListOptions{})
+	if err != nil {
+		return err
+	}
+	taskFilter := filters.NewArgs()
+	taskFilter.Add("desired-state", string(swarm.TaskStateRunning))
+	tasks, err := client.TaskList(ctx, types.TaskListOptions{Filters: taskFilter})
+	if err != nil {
+		return err
+	}
+	services, err := client.ServiceList(ctx, types.ServiceListOptions{})
+	if err != nil {
+		return err
+	}
+
+	expiryWindow := time.Duration(opts.days) * 24 * time.Hour
+	checks := []formatter.SwarmCheck{
+		checkQuorum(nodes),
+		checkManagerReachability(nodes),
+		checkRootCA(swarmInspect, expiryWindow),
+		checkNodeCertificates(swarmInspect, nodes, expiryWindow),
+		checkJoinTokens(swarmInspect),
+	}
+	unlockCheck, err := checkUnlockKey(ctx, dockerCli, swarmInspect)
+	if err != nil {
+		return err
+	}
+	checks = append(checks,
+		unlockCheck,
+		checkUnavailableNodes(nodes, tasks),
+		checkServiceUpdates(services, opts.stuckAfter),
+	)
+
+	format := opts.format
+	if len(format) == 0 {
+		format = formatter.TableFormatKey
+	}
+	checkCtx := formatter.Context{
+		Output: dockerCli.Out(),
+		Format: formatter.NewSwarmCheckFormat(format),
+	}
+	if err := formatter.SwarmCheckWrite(checkCtx, checks); err != nil {
+		return err
+	}
+	for _, check := range checks {
+		if check.Status == formatter.SwarmCheckFail {
+			return cli.StatusError{StatusCode: 1}
+		}
+	}
+	return nil
+}
+
+func newCheck(name, status, format string, args ...interface{}) formatter.SwarmCheck {
+	return formatter.SwarmCheck{Check: name, Status: status, Message: fmt.Sprintf(format, args...)}
+}
+
+func managers(nodes []swarm.Node) []swarm.Node {
+	var m []swarm.Node
+	for _, node := range nodes {
+		if node.ManagerStatus != nil {
+			m = append(m, node)
+		}
+	}
+	return m
+}
+
+// checkQuorum checks that a majority of the managers is reachable, and that
+// the swarm can tolerate the loss of a manager.
+func checkQuorum(nodes []swarm.Node) formatter.SwarmCheck {
+	const name = "manager quorum"
+
+	m := managers(nodes)
+	reachable := 0
+	leader := false
+	for _, node := range m {
+		if node.ManagerStatus.Reachability == swarm.ReachabilityReachable {
+			reachable++
+		}
+		leader = leader || node.ManagerStatus.Leader
+	}
+	quorum := len(m)/2 + 1
+	tolerance := (len(m) - 1) / 2
+
+	switch {
+	case reachable < quorum:
+		return newCheck(name, formatter.SwarmCheckFail, "%d of %d managers reachable, %d needed for quorum", reachable, len(m), quorum)
+	case !leader:
+		return newCheck(name, formatter.SwarmCheckFail, "no leader elected among %d managers", len(m))
+	case tolerance == 0:
+		return newCheck(name, formatter.SwarmCheckWarn, "%d manager(s): the loss of a manager makes the swarm unavailable, use 3 or 5 managers", len(m))
+	case len(m)%2 == 0:
+		return newCheck(name, formatter.SwarmCheckWarn, "%d managers tolerate the loss of %d, as %d would; use an odd number of managers", len(m), tolerance, len(m)-1)
+	case reachable-quorum < tolerance:
+		return newCheck(name, formatter.SwarmCheckWarn, "%d of %d managers reachable, the swarm tolerates the loss of %d more", reachable, len(m), reachable-quorum)
+	}
+	return newCheck(name, formatter.SwarmCheckPass, "%d of %d managers reachable, the swarm tolerates the loss of %d", reachable, len(m), tolerance)
+}
+
+func checkManagerReachability(nodes []swarm.Node) formatter.SwarmCheck {
+	const name = "manager reachability"
+
+	var unreachable []string
+	for _, node := range managers(nodes) {
+		if node.ManagerStatus.Reachability != swarm.ReachabilityReachable {
+			unreachable = append(unreachable, fmt.Sprintf("%s (%s)", node.Description.Hostname, node.ManagerStatus.Reachability))
+		}
+	}
+	if len(unreachable) > 0 {
+		sort.Strings(unreachable)
+		return newCheck(name, formatter.SwarmCheckWarn, "managers not reachable: %s", strings.Join(unreachable, ", "))
+	}
+	return newCheck(name, formatter.SwarmCheckPass, "all managers reachable")
+}
+
+// checkRootCA checks that the root CA certificate of the swarm doesn't expire
+// within window.
+func checkRootCA(sw swarm.Swarm, window time.Duration) formatter.SwarmCheck {
+	const name = "root CA"
+
+	cert, err := parseTrustRoot(sw.TLSInfo.TrustRoot)
+	if err != nil {
+		return newCheck(name, formatter.SwarmCheckFail, "%v", err)
+	}
+	expiry := cert.NotAfter.UTC().Format(time.RFC3339)
+	switch {
+	case now().After(cert.NotAfter):
+		return newCheck(name, formatter.SwarmCheckFail, "root CA certificate expired on %s, rotate it with docker swarm ca --rotate", expiry)
+	case now().Add(window).After(cert.NotAfter):
+		return newCheck(name, formatter.SwarmCheckWarn, "root CA certificate expires on %s, rotate it with docker swarm ca --rotate", expiry)
+	case sw.RootRotationInProgress:
+		return newCheck(name, formatter.SwarmCheckWarn, "root CA rotation in progress")
+	}
+	return newCheck(name, formatter.SwarmCheckPass, "root CA certificate expires on %s", expiry)
+}
+
+func parseTrustRoot(trustRoot string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(trustRoot))
+	if block == nil {
+		return nil, fmt.Errorf("no root CA certificate available")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// checkNodeCertificates checks that the certificates of the nodes are issued
+// by the current root CA, and that the certificates of down nodes don't
+// expire within window. The expiry of node certificates is not exposed by the
+// API: the certificates of nodes that are up are renewed automatically, and
+// the certificate of a node that is down expires at the latest one
+// certificate validity period after the node went down.
+func checkNodeCertificates(sw swarm.Swarm, nodes []swarm.Node, window time.Duration) formatter.SwarmCheck {
+	const name = "node certificates"
+
+	var (
+		status     = formatter.SwarmCheckPass
+		messages   []string
+		oldIssuer  []string
+		certExpiry = sw.Spec.CAConfig.NodeCertExpiry
+	)
+	if certExpiry == 0 {
+		// default validity of node certificates
+		certExpiry = 90 * 24 * time.Hour
+	}
+	for _, node := range nodes {
+		if node.Status.State == swarm.NodeStateReady {
+			if !reflect.DeepEqual(node.Description.TLSInfo, swarm.TLSInfo{}) && !reflect.DeepEqual(node.Description.TLSInfo, sw.TLSInfo) {
+				oldIssuer = append(oldIssuer, node.Description.Hostname)
+			}
+			continue
+		}
+		latest := node.UpdatedAt.Add(certExpiry)
+		switch {
+		case now().After(latest):
+			status = formatter.SwarmCheckFail
+			messages = append(messages, fmt.Sprintf("certificate of %s node %s has expired, it must rejoin the swarm", node.Status.State, node.Description.Hostname))
+		case now().Add(window).After(latest):
+			if status == formatter.SwarmCheckPass {
+				status = formatter.SwarmCheckWarn
+			}
+			messages = append(messages, fmt.Sprintf("certificate of %s node %s expires by %s", node.Status.State, node.Description.Hostname, latest.UTC().Format(time.RFC3339)))
+		}
+	}
+	if len(oldIssuer) > 0 {
+		if status == formatter.SwarmCheckPass {
+			status = formatter.SwarmCheckWarn
+		}
+		sort.Strings(oldIssuer)
+		messages = append(messages, fmt.Sprintf("certificates not issued by the current root CA: %s", strings.Join(oldIssuer, ", ")))
+	}
+	if len(messages) == 0 {
+		return newCheck(name, status, "certificates of %d nodes are renewed automatically, and valid for %d days", len(nodes), int(certExpiry.Hours()/24))
+	}
+	return newCheck(name, status, "%s", strings.Join(messages, "; "))
+}
+
+// checkJoinTokens checks that the join tokens are bound to the current root
+// CA. Join tokens embed the digest of the root CA they were issued for, so
+// that joining nodes can verify the managers.
+func checkJoinTokens(sw swarm.Swarm) formatter.SwarmCheck {
+	const name = "join tokens"
+
+	if sw.JoinTokens.Worker == "" || sw.JoinTokens.Manager == "" {
+		return newCheck(name, formatter.SwarmCheckWarn, "join tokens not available")
+	}
+	if sw.TLSInfo.TrustRoot == "" {
+		return newCheck(name, formatter.SwarmCheckWarn, "no root CA certificate available to verify the join tokens")
+	}
+	expected := rootCADigest(sw.TLSInfo.TrustRoot)
+	var stale []string
+	for _, role := range []string{"worker", "manager"} {
+		token := sw.JoinTokens.Worker
+		if role == "manager" {
+			token = sw.JoinTokens.Manager
+		}
+		parts := strings.Split(token, "-")
+		if len(parts) < 4 || parts[2] != expected {
+			stale = append(stale, role)
+		}
+	}
+	if len(stale) > 0 {
+		return newCheck(name, formatter.SwarmCheckWarn, "%s join token(s) not issued for the current root CA, rotate them with docker swarm join-token --rotate", strings.Join(stale, " and "))
+	}
+	return newCheck(name, formatter.SwarmCheckPass, "join tokens issued for the current root CA")
+}
+
+// rootCADigest returns the digest of the root CA, as embedded in join tokens.
+func rootCADigest(trustRoot string) string {
+	var dgst big.Int
+	dgst.SetString(digest.FromBytes([]byte(trustRoot)).Hex(), 16)
+	return fmt.Sprintf("%050s", dgst.Text(36))
+}
+
+func checkUnlockKey(ctx context.Context, dockerCli command.Cli, sw swarm.Swarm) (formatter.SwarmCheck, error) {
+	const name = "unlock key"
+
+	if !sw.Spec.EncryptionConfig.AutoLockManagers {
+		return newCheck(name, formatter.SwarmCheckWarn, "autolock is disabled, the raft encryption key is stored unencrypted on the managers; enable it with docker swarm update --autolock"), nil
+	}
+	unlockKey, err := dockerCli.Client().SwarmGetUnlockKey(ctx)
+	if err != nil {
+		return formatter.SwarmCheck{}, err
+	}
+	if unlockKey.UnlockKey == "" {
+		return newCheck(name, formatter.SwarmCheckFail, "autolock is enabled, but no unlock key is set"), nil
+	}
+	return newCheck(name, formatter.SwarmCheckPass, "autolock is enabled; keep the unlock key safe, and rotate it with docker swarm unlock-key --rotate when a manager is removed"), nil
+}
+
+// checkUnavailableNodes checks that nodes that are down or drained don't hold
+// tasks that should be running.
+func checkUnavailableNodes(nodes []swarm.Node, tasks []swarm.Task) formatter.SwarmCheck {
+	const name = "unavailable nodes"
+
+	counts := map[string]int{}
+	for _, task := range tasks {
+		if task.Status.State != swarm.TaskStateRunning && task.Status.State != swarm.TaskStateStarting {
+			continue
+		}
+		counts[task.NodeID]++
+	}
+
+	status := formatter.SwarmCheckPass
+	var messages []string
+	for _, node := range nodes {
+		n := counts[node.ID]
+		if n == 0 {
+			continue
+		}
+		switch {
+		case node.Status.State != swarm.NodeStateReady:
+			status = formatter.SwarmCheckFail
+			messages = append(messages, fmt.Sprintf("%s node %s holds %d task(s)", node.Status.State, node.Description.Hostname, n))
+		case node.Spec.Availability == swarm.NodeAvailabilityDrain:
+			if status == formatter.SwarmCheckPass {
+				status = formatter.SwarmCheckWarn
+			}
+			messages = append(messages, fmt.Sprintf("drained node %s still runs %d task(s)", node.Description.Hostname, n))
+		}
+	}
+	if len(messages) == 0 {
+		return newCheck(name, status, "no tasks on down or drained nodes")
+	}
+	sort.Strings(messages)
+	return newCheck(name, status, "%s", strings.Join(messages, "; "))
+}
+
+// checkServiceUpdates checks for service updates that are paused, or have
+// been in progress for longer than stuckAfter.
+func checkServiceUpdates(services []swarm.Service, stuckAfter time.Duration) formatter.SwarmCheck {
+	const name = "service updates"
+
+	var stuck []string
+	for _, service := range services {
+		status := service.UpdateStatus
+		if status == nil {
+			continue
+		}
+		switch status.State {
+		case swarm.UpdateStatePaused, swarm.UpdateStateRollbackPaused:
+			stuck = append(stuck, fmt.Sprintf("%s is %s: %s", service.Spec.Name, status.State, status.Message))
+		case swarm.UpdateStateUpdating, swarm.UpdateStateRollbackStarted:
+			if status.StartedAt != nil && now().Sub(*status.StartedAt) > stuckAfter {
+				stuck = append(stuck, fmt.Sprintf("%s has been in state %s since %s", service.Spec.Name, status.State, status.StartedAt.UTC().Format(time.RFC3339)))
+			}
+		}
+	}
+	if len(stuck) > 0 {
+		sort.Strings(stuck)
+		return newCheck(name, formatter.SwarmCheckWarn, "%s", strings.Join(stuck, "; "))
+	}
+	return newCheck(name, formatter.SwarmCheckPass, "no paused or stuck updates among %d services", len(services))
+}
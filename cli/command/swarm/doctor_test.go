@@ -0,0 +1,241 @@
+package swarm
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/gotestyourself/gotestyourself/golden"
+	"github.com/pkg/errors"
+	// Import builders to get the builder function as package function
+	. "github.com/docker/cli/internal/test/builders"
+	"github.com/docker/cli/internal/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var doctorTime = time.Date(2017, time.December, 1, 12, 0, 0, 0, time.UTC)
+
+func setDoctorTime() func() {
+	now = func() time.Time { return doctorTime }
+	return func() { now = time.Now }
+}
+
+func rootCACert(t *testing.T, notAfter time.Time) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "swarm-ca"},
+		NotBefore:             notAfter.Add(-20 * 365 * 24 * time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func healthySwarm(t *testing.T) swarm.Swarm {
+	trustRoot := rootCACert(t, doctorTime.Add(10*365*24*time.Hour))
+	digest := rootCADigest(trustRoot)
+	sw := swarm.Swarm{
+		ClusterInfo: swarm.ClusterInfo{
+			TLSInfo: swarm.TLSInfo{TrustRoot: trustRoot},
+		},
+		JoinTokens: swarm.JoinTokens{
+			Worker:  "SWMTKN-1-" + digest + "-worker",
+			Manager: "SWMTKN-1-" + digest + "-manager",
+		},
+	}
+	sw.Spec.EncryptionConfig.AutoLockManagers = true
+	return sw
+}
+
+func healthyNodes() []swarm.Node {
+	return []swarm.Node{
+		*Node(NodeID("manager1"), Hostname("manager1"), Manager(Leader())),
+		*Node(NodeID("manager2"), Hostname("manager2"), Manager()),
+		*Node(NodeID("manager3"), Hostname("manager3"), Manager()),
+		*Node(NodeID("worker1"), Hostname("worker1")),
+	}
+}
+
+func TestSwarmDoctorErrors(t *testing.T) {
+	testCases := []struct {
+		name             string
+		swarmInspectFunc func() (swarm.Swarm, error)
+		nodeListFunc     func() ([]swarm.Node, error)
+		expectedError    string
+	}{
+		{
+			name: "swarm-inspect-failed",
+			swarmInspectFunc: func() (swarm.Swarm, error) {
+				return swarm.Swarm{}, errors.Errorf("error inspecting the swarm")
+			},
+			expectedError: "error inspecting the swarm",
+		},
+		{
+			name: "node-list-failed",
+			nodeListFunc: func() ([]swarm.Node, error) {
+				return nil, errors.Errorf("error listing nodes")
+			},
+			expectedError: "error listing nodes",
+		},
+	}
+	for _, tc := range testCases {
+		cmd := newDoctorCommand(
+			test.NewFakeCli(&fakeClient{
+				swarmInspectFunc: tc.swarmInspectFunc,
+				nodeListFunc:     tc.nodeListFunc,
+			}))
+		cmd.SetOutput(ioutil.Discard)
+		testutil.ErrorContains(t, cmd.Execute(), tc.expectedError)
+	}
+}
+
+func TestSwarmDoctorHealthy(t *testing.T) {
+	defer setDoctorTime()()
+	sw := healthySwarm(t)
+	dockerCli := test.NewFakeCli(&fakeClient{
+		swarmInspectFunc: func() (swarm.Swarm, error) {
+			return sw, nil
+		},
+		swarmGetUnlockKeyFunc: func() (types.SwarmUnlockKeyResponse, error) {
+			return types.SwarmUnlockKeyResponse{UnlockKey: "unlock-key"}, nil
+		},
+		nodeListFunc: func() ([]swarm.Node, error) {
+			return healthyNodes(), nil
+		},
+	})
+	cmd := newDoctorCommand(dockerCli)
+	assert.NoError(t, cmd.Execute())
+	golden.Assert(t, dockerCli.OutBuffer().String(), "doctor-healthy.golden")
+}
+
+func TestSwarmDoctorUnhealthy(t *testing.T) {
+	defer setDoctorTime()()
+	sw := healthySwarm(t)
+	sw.TLSInfo.TrustRoot = rootCACert(t, doctorTime.Add(10*24*time.Hour))
+	sw.Spec.EncryptionConfig.AutoLockManagers = false
+	startedAt := doctorTime.Add(-2 * time.Hour)
+
+	dockerCli := test.NewFakeCli(&fakeClient{
+		swarmInspectFunc: func() (swarm.Swarm, error) {
+			return sw, nil
+		},
+		nodeListFunc: func() ([]swarm.Node, error) {
+			nodes := healthyNodes()
+			nodes[1].ManagerStatus.Reachability = swarm.ReachabilityUnreachable
+			nodes[3].Status.State = swarm.NodeStateDown
+			nodes[3].UpdatedAt = doctorTime.Add(-100 * 24 * time.Hour)
+			drained := Node(NodeID("worker2"), Hostname("worker2"))
+			drained.Spec.Availability = swarm.NodeAvailabilityDrain
+			return append(nodes, *drained), nil
+		},
+		taskListFunc: func() ([]swarm.Task, error) {
+			return []swarm.Task{
+				*Task(TaskNodeID("worker1"), WithStatus(TaskState(swarm.TaskStateRunning))),
+				*Task(TaskNodeID("worker2"), WithStatus(TaskState(swarm.TaskStateRunning))),
+				*Task(TaskNodeID("worker2"), WithStatus(TaskState(swarm.TaskStateShutdown))),
+			}, nil
+		},
+		serviceListFunc: func() ([]swarm.Service, error) {
+			return []swarm.Service{
+				{
+					Spec: swarm.ServiceSpec{Annotations: swarm.Annotations{Name: "web"}},
+					UpdateStatus: &swarm.UpdateStatus{
+						State:     swarm.UpdateStateUpdating,
+						StartedAt: &startedAt,
+					},
+				},
+				{
+					Spec: swarm.ServiceSpec{Annotations: swarm.Annotations{Name: "db"}},
+					UpdateStatus: &swarm.UpdateStatus{
+						State:   swarm.UpdateStatePaused,
+						Message: "update paused due to failure or early termination of task",
+					},
+				},
+			}, nil
+		},
+	})
+	cmd := newDoctorCommand(dockerCli)
+	err := cmd.Execute()
+	assert.Equal(t, cli.StatusError{StatusCode: 1}, err)
+	golden.Assert(t, dockerCli.OutBuffer().String(), "doctor-unhealthy.golden")
+}
+
+func TestSwarmDoctorJSON(t *testing.T) {
+	defer setDoctorTime()()
+	sw := healthySwarm(t)
+	sw.JoinTokens.Manager = "SWMTKN-1-0000-manager"
+	dockerCli := test.NewFakeCli(&fakeClient{
+		swarmInspectFunc: func() (swarm.Swarm, error) {
+			return sw, nil
+		},
+		swarmGetUnlockKeyFunc: func() (types.SwarmUnlockKeyResponse, error) {
+			return types.SwarmUnlockKeyResponse{UnlockKey: "unlock-key"}, nil
+		},
+		nodeListFunc: func() ([]swarm.Node, error) {
+			return healthyNodes(), nil
+		},
+	})
+	cmd := newDoctorCommand(dockerCli)
+	cmd.Flags().Set("format", "json")
+	cmd.Flags().Set("stuck-after", "1m")
+	assert.NoError(t, cmd.Execute())
+	assert.Contains(t, dockerCli.OutBuffer().String(), `{"Check":"join tokens","Message":"manager join token(s) not issued for the current root CA, rotate them with docker swarm join-token --rotate","Status":"warn"}`)
+}
+
+func TestCheckQuorum(t *testing.T) {
+	manager := func(id string, reachability swarm.Reachability, leader bool) swarm.Node {
+		return swarm.Node{ID: id, ManagerStatus: &swarm.ManagerStatus{Reachability: reachability, Leader: leader}}
+	}
+	reachable := swarm.ReachabilityReachable
+	unreachable := swarm.ReachabilityUnreachable
+
+	testCases := []struct {
+		nodes    []swarm.Node
+		expected string
+	}{
+		{
+			nodes:    []swarm.Node{manager("1", reachable, true)},
+			expected: "warn",
+		},
+		{
+			nodes:    []swarm.Node{manager("1", reachable, true), manager("2", reachable, false)},
+			expected: "warn",
+		},
+		{
+			nodes:    []swarm.Node{manager("1", reachable, true), manager("2", reachable, false), manager("3", reachable, false)},
+			expected: "pass",
+		},
+		{
+			nodes:    []swarm.Node{manager("1", reachable, true), manager("2", unreachable, false), manager("3", reachable, false)},
+			expected: "warn",
+		},
+		{
+			nodes:    []swarm.Node{manager("1", reachable, false), manager("2", reachable, false), manager("3", reachable, false)},
+			expected: "fail",
+		},
+		{
+			nodes:    []swarm.Node{manager("1", reachable, true), manager("2", unreachable, false), manager("3", unreachable, false)},
+			expected: "fail",
+		},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, checkQuorum(tc.nodes).Status)
+	}
+}
@@ -0,0 +1,186 @@
+package swarm
+
+import (
+	"bytes"
+	"io"
+	"sort"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/docker/docker/api/types/versions"
+	"github.com/docker/docker/client"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+type exportOptions struct {
+	output string
+}
+
+func newExportCommand(dockerCli command.Cli) *cobra.Command {
+	opts := exportOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "export [OPTIONS]",
+		Short: "Export the objects of the swarm to a tar archive (streamed to STDOUT by default)",
+		Args:  cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExport(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.output, "output", "o", "", "Write to a file, instead of STDOUT")
+	return cmd
+}
+
+func runExport(dockerCli command.Cli, opts exportOptions) error {
+	if opts.output == "" && dockerCli.Out().IsTerminal() {
+		return errors.New("cowardly refusing to save to a terminal. Use the -o flag or redirect")
+	}
+
+	archive, err := exportSwarm(context.Background(), dockerCli.Client())
+	if err != nil {
+		return err
+	}
+
+	buf := &bytes.Buffer{}
+	if err := writeArchive(buf, archive); err != nil {
+		return err
+	}
+	if opts.output == "" {
+		_, err := io.Copy(dockerCli.Out(), buf)
+		return err
+	}
+	return command.CopyToFile(opts.output, buf)
+}
+
+func exportSwarm(ctx context.Context, client client.APIClient) (*swarmArchive, error) {
+	swarmInspect, err := client.SwarmInspect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	archive := &swarmArchive{Swarm: swarmInspect.Spec}
+
+	nodes, err := client.NodeList(ctx, types.NodeListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes {
+		if len(node.Spec.Labels) == 0 {
+			continue
+		}
+		archive.Nodes = append(archive.Nodes, exportedNode{
+			Hostname: node.Description.Hostname,
+			Labels:   node.Spec.Labels,
+		})
+	}
+	sort.Slice(archive.Nodes, func(i, j int) bool {
+		return archive.Nodes[i].Hostname < archive.Nodes[j].Hostname
+	})
+
+	networks, err := client.NetworkList(ctx, types.NetworkListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	networkNames := map[string]string{}
+	for _, nw := range networks {
+		// The ingress network is created with the swarm, and local scoped
+		// networks only exist on the node they were created on.
+		if nw.Scope != "swarm" || nw.Ingress {
+			continue
+		}
+		networkNames[nw.ID] = nw.Name
+		archive.Networks = append(archive.Networks, exportNetwork(nw))
+	}
+	sort.Slice(archive.Networks, func(i, j int) bool {
+		return archive.Networks[i].Name < archive.Networks[j].Name
+	})
+
+	if !versions.LessThan(client.ClientVersion(), "1.30") {
+		configs, err := client.ConfigList(ctx, types.ConfigListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, config := range configs {
+			archive.Configs = append(archive.Configs, config.Spec)
+		}
+		sort.Slice(archive.Configs, func(i, j int) bool {
+			return archive.Configs[i].Name < archive.Configs[j].Name
+		})
+	}
+
+	secrets, err := client.SecretList(ctx, types.SecretListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, secret := range secrets {
+		spec := secret.Spec
+		spec.Data = nil
+		archive.Secrets = append(archive.Secrets, spec)
+	}
+	sort.Slice(archive.Secrets, func(i, j int) bool {
+		return archive.Secrets[i].Name < archive.Secrets[j].Name
+	})
+
+	services, err := client.ServiceList(ctx, types.ServiceListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, service := range services {
+		archive.Services = append(archive.Services, exportServiceSpec(service.Spec, networkNames))
+	}
+	sort.Slice(archive.Services, func(i, j int) bool {
+		return archive.Services[i].Name < archive.Services[j].Name
+	})
+
+	return archive, nil
+}
+
+func exportNetwork(nw types.NetworkResource) exportedNetwork {
+	ipam := nw.IPAM
+	exported := exportedNetwork{
+		Name: nw.Name,
+		NetworkCreate: types.NetworkCreate{
+			Driver:     nw.Driver,
+			Scope:      nw.Scope,
+			EnableIPv6: nw.EnableIPv6,
+			IPAM:       &ipam,
+			Internal:   nw.Internal,
+			Attachable: nw.Attachable,
+			ConfigOnly: nw.ConfigOnly,
+			Options:    nw.Options,
+			Labels:     nw.Labels,
+		},
+	}
+	if nw.ConfigFrom.Network != "" {
+		configFrom := nw.ConfigFrom
+		exported.ConfigFrom = &configFrom
+	}
+	return exported
+}
+
+// exportServiceSpec replaces the network IDs in the spec by the network
+// names, as the networks get a new ID when they are imported.
+func exportServiceSpec(spec swarm.ServiceSpec, networkNames map[string]string) swarm.ServiceSpec {
+	spec.TaskTemplate.Networks = exportNetworkAttachments(spec.TaskTemplate.Networks, networkNames)
+	spec.Networks = exportNetworkAttachments(spec.Networks, networkNames)
+	return spec
+}
+
+func exportNetworkAttachments(attachments []swarm.NetworkAttachmentConfig, networkNames map[string]string) []swarm.NetworkAttachmentConfig {
+	if attachments == nil {
+		return nil
+	}
+	exported := make([]swarm.NetworkAttachmentConfig, 0, len(attachments))
+	for _, attachment := range attachments {
+		if name, ok := networkNames[attachment.Target]; ok {
+			attachment.Target = name
+		}
+		exported = append(exported, attachment)
+	}
+	return exported
+}
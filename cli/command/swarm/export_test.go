@@ -0,0 +1,76 @@
+package swarm
+
+import (
+	"os"
+	"testing"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
+	// Import builders to get the builder function as package function
+	. "github.com/docker/cli/internal/test/builders"
+	"github.com/gotestyourself/gotestyourself/fs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwarmExport(t *testing.T) {
+	defer setDoctorTime()()
+	dir := fs.NewDir(t, "swarm-export")
+	defer dir.Remove()
+
+	dockerCli := test.NewFakeCli(&fakeClient{
+		version: "1.35",
+		swarmInspectFunc: func() (swarm.Swarm, error) {
+			return *Swarm(Autolock()), nil
+		},
+		nodeListFunc: func() ([]swarm.Node, error) {
+			return []swarm.Node{
+				*Node(Hostname("worker1"), NodeLabels(map[string]string{"zone": "east"})),
+				*Node(Hostname("manager1"), Manager()),
+			}, nil
+		},
+		networkListFunc: func() ([]types.NetworkResource, error) {
+			return []types.NetworkResource{
+				{ID: "ingress-id", Name: "ingress", Scope: "swarm", Driver: "overlay", Ingress: true},
+				{ID: "bridge-id", Name: "bridge", Scope: "local", Driver: "bridge"},
+				{ID: "backend-id", Name: "backend", Scope: "swarm", Driver: "overlay", Attachable: true},
+			}, nil
+		},
+		configListFunc: func() ([]swarm.Config, error) {
+			return []swarm.Config{*Config(ConfigName("nginx.conf"), ConfigData([]byte("server {}")))}, nil
+		},
+		secretListFunc: func() ([]swarm.Secret, error) {
+			secret := Secret(SecretName("db_password"))
+			secret.Spec.Data = []byte("not returned by the daemon")
+			return []swarm.Secret{*secret}, nil
+		},
+		serviceListFunc: func() ([]swarm.Service, error) {
+			service := Service(ServiceName("web"))
+			service.Spec.TaskTemplate.Networks = []swarm.NetworkAttachmentConfig{{Target: "backend-id", Aliases: []string{"www"}}}
+			return []swarm.Service{*service}, nil
+		},
+	})
+	cmd := newExportCommand(dockerCli)
+	cmd.Flags().Set("output", dir.Join("swarm.tar"))
+	require.NoError(t, cmd.Execute())
+
+	file, err := os.Open(dir.Join("swarm.tar"))
+	require.NoError(t, err)
+	defer file.Close()
+	archive, err := readArchive(file)
+	require.NoError(t, err)
+
+	assert.True(t, archive.Swarm.EncryptionConfig.AutoLockManagers)
+	assert.Equal(t, []exportedNode{{Hostname: "worker1", Labels: map[string]string{"zone": "east"}}}, archive.Nodes)
+	require.Len(t, archive.Networks, 1)
+	assert.Equal(t, "backend", archive.Networks[0].Name)
+	assert.True(t, archive.Networks[0].Attachable)
+	require.Len(t, archive.Configs, 1)
+	assert.Equal(t, []byte("server {}"), archive.Configs[0].Data)
+	require.Len(t, archive.Secrets, 1)
+	assert.Equal(t, "db_password", archive.Secrets[0].Name)
+	assert.Nil(t, archive.Secrets[0].Data)
+	require.Len(t, archive.Services, 1)
+	assert.Equal(t, []swarm.NetworkAttachmentConfig{{Target: "backend", Aliases: []string{"www"}}}, archive.Services[0].TaskTemplate.Networks)
+}
@@ -0,0 +1,379 @@
+package swarm
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/docker/docker/api/types/versions"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/system"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+type importOptions struct {
+	input      string
+	secretsDir string
+}
+
+func newImportCommand(dockerCli command.Cli) *cobra.Command {
+	opts := importOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "import [OPTIONS]",
+		Short: "Import the objects of a swarm from a tar archive or STDIN",
+		Args:  cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runImport(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.input, "input", "i", "", "Read from tar archive file, instead of STDIN")
+	flags.StringVar(&opts.secretsDir, "secrets-dir", "", "Read the value of each secret from a file named after the secret in this directory")
+	return cmd
+}
+
+// importState holds the IDs of the objects of the swarm, by name
+type importState struct {
+	networks map[string]string
+	configs  map[string]string
+	secrets  map[string]string
+	services map[string]string
+}
+
+func runImport(dockerCli command.Cli, opts importOptions) error {
+	var input io.Reader = dockerCli.In()
+	if opts.input != "" {
+		file, err := system.OpenSequential(opts.input)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+	if opts.input == "" && dockerCli.In().IsTerminal() {
+		return errors.Errorf("requested import from stdin, but stdin is empty")
+	}
+
+	archive, err := readArchive(input)
+	if err != nil {
+		return err
+	}
+
+	client := dockerCli.Client()
+	ctx := context.Background()
+
+	state, err := listImportState(ctx, client)
+	if err != nil {
+		return err
+	}
+	// Check everything that can be checked before the first object is
+	// created, so that a mistake doesn't leave a partially imported swarm.
+	secretValues, err := readSecretValues(archive.Secrets, state, opts.secretsDir)
+	if err != nil {
+		return err
+	}
+	if err := validateServiceReferences(archive, state); err != nil {
+		return err
+	}
+
+	// Objects are created in dependency order: services are created last,
+	// as they use the networks, configs and secrets.
+	if err := importSwarmSpec(ctx, dockerCli, archive.Swarm); err != nil {
+		return err
+	}
+	if err := importNodeLabels(ctx, dockerCli, archive.Nodes); err != nil {
+		return err
+	}
+	out := dockerCli.Out()
+	for _, nw := range archive.Networks {
+		if _, exists := state.networks[nw.Name]; exists {
+			fmt.Fprintf(out, "Skipped network %s: already exists\n", nw.Name)
+			continue
+		}
+		nw.CheckDuplicate = true
+		response, err := client.NetworkCreate(ctx, nw.Name, nw.NetworkCreate)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create network %s", nw.Name)
+		}
+		state.networks[nw.Name] = response.ID
+		fmt.Fprintf(out, "Created network %s\n", nw.Name)
+	}
+	for _, spec := range archive.Configs {
+		if _, exists := state.configs[spec.Name]; exists {
+			fmt.Fprintf(out, "Skipped config %s: already exists\n", spec.Name)
+			continue
+		}
+		response, err := client.ConfigCreate(ctx, spec)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create config %s", spec.Name)
+		}
+		state.configs[spec.Name] = response.ID
+		fmt.Fprintf(out, "Created config %s\n", spec.Name)
+	}
+	for _, spec := range archive.Secrets {
+		if _, exists := state.secrets[spec.Name]; exists {
+			fmt.Fprintf(out, "Skipped secret %s: already exists\n", spec.Name)
+			continue
+		}
+		spec.Data = secretValues[spec.Name]
+		response, err := client.SecretCreate(ctx, spec)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create secret %s", spec.Name)
+		}
+		state.secrets[spec.Name] = response.ID
+		fmt.Fprintf(out, "Created secret %s\n", spec.Name)
+	}
+	for _, spec := range archive.Services {
+		if _, exists := state.services[spec.Name]; exists {
+			fmt.Fprintf(out, "Skipped service %s: already exists\n", spec.Name)
+			continue
+		}
+		spec = importServiceSpec(spec, state)
+		response, err := client.ServiceCreate(ctx, spec, types.ServiceCreateOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "failed to create service %s", spec.Name)
+		}
+		for _, warning := range response.Warnings {
+			fmt.Fprintln(dockerCli.Err(), warning)
+		}
+		state.services[spec.Name] = response.ID
+		fmt.Fprintf(out, "Created service %s\n", spec.Name)
+	}
+	return nil
+}
+
+func listImportState(ctx context.Context, client client.APIClient) (*importState, error) {
+	state := &importState{
+		networks: map[string]string{},
+		configs:  map[string]string{},
+		secrets:  map[string]string{},
+		services: map[string]string{},
+	}
+
+	networks, err := client.NetworkList(ctx, types.NetworkListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, nw := range networks {
+		state.networks[nw.Name] = nw.ID
+	}
+	if !versions.LessThan(client.ClientVersion(), "1.30") {
+		configs, err := client.ConfigList(ctx, types.ConfigListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, config := range configs {
+			state.configs[config.Spec.Name] = config.ID
+		}
+	}
+	secrets, err := client.SecretList(ctx, types.SecretListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, secret := range secrets {
+		state.secrets[secret.Spec.Name] = secret.ID
+	}
+	services, err := client.ServiceList(ctx, types.ServiceListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, service := range services {
+		state.services[service.Spec.Name] = service.ID
+	}
+	return state, nil
+}
+
+// readSecretValues reads the value of the secrets that have to be created
+// from secretsDir. Secrets using a secret driver don't need a value.
+func readSecretValues(secrets []swarm.SecretSpec, state *importState, secretsDir string) (map[string][]byte, error) {
+	values := map[string][]byte{}
+	for _, spec := range secrets {
+		if _, exists := state.secrets[spec.Name]; exists || spec.Driver != nil {
+			continue
+		}
+		if secretsDir == "" {
+			return nil, errors.Errorf("secret %s has no value: use --secrets-dir to provide the secret values", spec.Name)
+		}
+		data, err := ioutil.ReadFile(filepath.Join(secretsDir, spec.Name))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read the value of secret %s", spec.Name)
+		}
+		values[spec.Name] = data
+	}
+	return values, nil
+}
+
+// validateServiceReferences checks that the networks, configs and secrets
+// used by the services are either in the archive, or exist in the swarm.
+func validateServiceReferences(archive *swarmArchive, state *importState) error {
+	networks := namesOf(state.networks)
+	for _, nw := range archive.Networks {
+		networks[nw.Name] = true
+	}
+	configs := namesOf(state.configs)
+	for _, spec := range archive.Configs {
+		configs[spec.Name] = true
+	}
+	secrets := namesOf(state.secrets)
+	for _, spec := range archive.Secrets {
+		secrets[spec.Name] = true
+	}
+
+	for _, spec := range archive.Services {
+		for _, attachments := range [][]swarm.NetworkAttachmentConfig{spec.TaskTemplate.Networks, spec.Networks} {
+			for _, attachment := range attachments {
+				if !networks[attachment.Target] {
+					return errors.Errorf("service %s uses network %s, which is not in the archive", spec.Name, attachment.Target)
+				}
+			}
+		}
+		containerSpec := spec.TaskTemplate.ContainerSpec
+		if containerSpec == nil {
+			continue
+		}
+		for _, ref := range containerSpec.Configs {
+			if !configs[ref.ConfigName] {
+				return errors.Errorf("service %s uses config %s, which is not in the archive", spec.Name, ref.ConfigName)
+			}
+		}
+		for _, ref := range containerSpec.Secrets {
+			if !secrets[ref.SecretName] {
+				return errors.Errorf("service %s uses secret %s, which is not in the archive", spec.Name, ref.SecretName)
+			}
+		}
+	}
+	return nil
+}
+
+func namesOf(ids map[string]string) map[string]bool {
+	names := map[string]bool{}
+	for name := range ids {
+		names[name] = true
+	}
+	return names
+}
+
+// importServiceSpec replaces the names of the networks, configs and secrets
+// used by the service by their ID in the swarm.
+func importServiceSpec(spec swarm.ServiceSpec, state *importState) swarm.ServiceSpec {
+	spec.TaskTemplate.Networks = importNetworkAttachments(spec.TaskTemplate.Networks, state)
+	spec.Networks = importNetworkAttachments(spec.Networks, state)
+
+	containerSpec := spec.TaskTemplate.ContainerSpec
+	if containerSpec == nil {
+		return spec
+	}
+	cspec := *containerSpec
+	cspec.Configs = make([]*swarm.ConfigReference, 0, len(containerSpec.Configs))
+	for _, ref := range containerSpec.Configs {
+		imported := *ref
+		imported.ConfigID = state.configs[ref.ConfigName]
+		cspec.Configs = append(cspec.Configs, &imported)
+	}
+	cspec.Secrets = make([]*swarm.SecretReference, 0, len(containerSpec.Secrets))
+	for _, ref := range containerSpec.Secrets {
+		imported := *ref
+		imported.SecretID = state.secrets[ref.SecretName]
+		cspec.Secrets = append(cspec.Secrets, &imported)
+	}
+	spec.TaskTemplate.ContainerSpec = &cspec
+	return spec
+}
+
+func importNetworkAttachments(attachments []swarm.NetworkAttachmentConfig, state *importState) []swarm.NetworkAttachmentConfig {
+	if attachments == nil {
+		return nil
+	}
+	imported := make([]swarm.NetworkAttachmentConfig, 0, len(attachments))
+	for _, attachment := range attachments {
+		attachment.Target = state.networks[attachment.Target]
+		imported = append(imported, attachment)
+	}
+	return imported
+}
+
+func importSwarmSpec(ctx context.Context, dockerCli command.Cli, spec swarm.Spec) error {
+	client := dockerCli.Client()
+
+	swarmInspect, err := client.SwarmInspect(ctx)
+	if err != nil {
+		return err
+	}
+	prevAutoLock := swarmInspect.Spec.EncryptionConfig.AutoLockManagers
+
+	// ForceRotate is a counter, changing it would rotate the root CA of
+	// the swarm.
+	spec.CAConfig.ForceRotate = swarmInspect.Spec.CAConfig.ForceRotate
+	if err := client.SwarmUpdate(ctx, swarmInspect.Version, spec, swarm.UpdateFlags{}); err != nil {
+		return errors.Wrap(err, "failed to update the swarm")
+	}
+	fmt.Fprintln(dockerCli.Out(), "Updated the swarm")
+
+	if spec.EncryptionConfig.AutoLockManagers && !prevAutoLock {
+		unlockKeyResp, err := client.SwarmGetUnlockKey(ctx)
+		if err != nil {
+			return errors.Wrap(err, "could not fetch unlock key")
+		}
+		printUnlockCommand(dockerCli.Out(), unlockKeyResp.UnlockKey)
+	}
+	return nil
+}
+
+// importNodeLabels adds the labels in the archive to the nodes with the same
+// hostname. Nodes that cannot be found are reported, but are not an error,
+// as the new swarm may not have the same nodes.
+func importNodeLabels(ctx context.Context, dockerCli command.Cli, exported []exportedNode) error {
+	if len(exported) == 0 {
+		return nil
+	}
+	client := dockerCli.Client()
+
+	nodes, err := client.NodeList(ctx, types.NodeListOptions{})
+	if err != nil {
+		return err
+	}
+	byHostname := map[string][]swarm.Node{}
+	for _, node := range nodes {
+		byHostname[node.Description.Hostname] = append(byHostname[node.Description.Hostname], node)
+	}
+
+	for _, exportedNode := range exported {
+		matches := byHostname[exportedNode.Hostname]
+		if len(matches) == 0 {
+			fmt.Fprintf(dockerCli.Err(), "Warning: node %s not found, its labels were not imported\n", exportedNode.Hostname)
+			continue
+		}
+		if len(matches) > 1 {
+			fmt.Fprintf(dockerCli.Err(), "Warning: hostname %s is ambiguous, its labels were not imported\n", exportedNode.Hostname)
+			continue
+		}
+
+		node := matches[0]
+		labels := map[string]string{}
+		for k, v := range node.Spec.Labels {
+			labels[k] = v
+		}
+		for k, v := range exportedNode.Labels {
+			labels[k] = v
+		}
+		if reflect.DeepEqual(labels, node.Spec.Labels) {
+			continue
+		}
+		node.Spec.Labels = labels
+		if err := client.NodeUpdate(ctx, node.ID, node.Version, node.Spec); err != nil {
+			return errors.Wrapf(err, "failed to update the labels of node %s", exportedNode.Hostname)
+		}
+		fmt.Fprintf(dockerCli.Out(), "Updated the labels of node %s\n", exportedNode.Hostname)
+	}
+	return nil
+}
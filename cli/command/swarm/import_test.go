@@ -0,0 +1,174 @@
+package swarm
+
+import (
+	"archive/tar"
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
+	// Import builders to get the builder function as package function
+	. "github.com/docker/cli/internal/test/builders"
+	"github.com/docker/cli/internal/test/testutil"
+	"github.com/gotestyourself/gotestyourself/fs"
+	"github.com/gotestyourself/gotestyourself/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testArchive() *swarmArchive {
+	service := Service(ServiceName("web")).Spec
+	service.TaskTemplate.Networks = []swarm.NetworkAttachmentConfig{{Target: "backend"}}
+	service.TaskTemplate.ContainerSpec = &swarm.ContainerSpec{
+		Image: "nginx",
+		Configs: []*swarm.ConfigReference{
+			{ConfigID: "old-config-id", ConfigName: "nginx.conf", File: &swarm.ConfigReferenceFileTarget{Name: "/etc/nginx.conf"}},
+		},
+		Secrets: []*swarm.SecretReference{
+			{SecretID: "old-secret-id", SecretName: "db_password", File: &swarm.SecretReferenceFileTarget{Name: "db_password"}},
+			{SecretID: "old-secret-id", SecretName: "existing", File: &swarm.SecretReferenceFileTarget{Name: "existing"}},
+		},
+	}
+	return &swarmArchive{
+		Swarm:    Swarm(Autolock()).Spec,
+		Nodes:    []exportedNode{{Hostname: "worker1", Labels: map[string]string{"zone": "east"}}, {Hostname: "worker2"}},
+		Networks: []exportedNetwork{{Name: "backend", NetworkCreate: types.NetworkCreate{Driver: "overlay"}}},
+		Configs:  []swarm.ConfigSpec{Config(ConfigName("nginx.conf")).Spec},
+		Secrets: []swarm.SecretSpec{
+			Secret(SecretName("db_password")).Spec,
+			Secret(SecretName("existing")).Spec,
+			Secret(SecretName("vault"), SecretDriver("vault")).Spec,
+		},
+		Services: []swarm.ServiceSpec{service},
+	}
+}
+
+func archiveInput(t *testing.T, archive *swarmArchive) *command.InStream {
+	buf := &bytes.Buffer{}
+	require.NoError(t, writeArchive(buf, archive))
+	return command.NewInStream(ioutil.NopCloser(buf))
+}
+
+func TestSwarmImport(t *testing.T) {
+	dir := fs.NewDir(t, "secrets", fs.WithFile("db_password", "s3cr3t"))
+	defer dir.Remove()
+
+	var (
+		nodeLabels map[string]string
+		secrets    []swarm.SecretSpec
+		service    swarm.ServiceSpec
+	)
+	dockerCli := test.NewFakeCli(&fakeClient{
+		version: "1.35",
+		nodeListFunc: func() ([]swarm.Node, error) {
+			return []swarm.Node{*Node(NodeID("worker1-id"), Hostname("worker1"), NodeLabels(map[string]string{"rack": "1"}))}, nil
+		},
+		nodeUpdateFunc: func(nodeID string, node swarm.NodeSpec) error {
+			assert.Equal(t, "worker1-id", nodeID)
+			nodeLabels = node.Labels
+			return nil
+		},
+		networkCreateFunc: func(name string, options types.NetworkCreate) (types.NetworkCreateResponse, error) {
+			assert.Equal(t, "backend", name)
+			return types.NetworkCreateResponse{ID: "backend-id"}, nil
+		},
+		configCreateFunc: func(spec swarm.ConfigSpec) (types.ConfigCreateResponse, error) {
+			return types.ConfigCreateResponse{ID: "config-id"}, nil
+		},
+		secretListFunc: func() ([]swarm.Secret, error) {
+			return []swarm.Secret{*Secret(SecretID("existing-id"), SecretName("existing"))}, nil
+		},
+		secretCreateFunc: func(spec swarm.SecretSpec) (types.SecretCreateResponse, error) {
+			secrets = append(secrets, spec)
+			return types.SecretCreateResponse{ID: spec.Name + "-id"}, nil
+		},
+		serviceCreateFunc: func(spec swarm.ServiceSpec) (types.ServiceCreateResponse, error) {
+			service = spec
+			return types.ServiceCreateResponse{ID: "service-id"}, nil
+		},
+		swarmGetUnlockKeyFunc: func() (types.SwarmUnlockKeyResponse, error) {
+			return types.SwarmUnlockKeyResponse{UnlockKey: "unlock-key"}, nil
+		},
+	})
+	dockerCli.SetIn(archiveInput(t, testArchive()))
+	cmd := newImportCommand(dockerCli)
+	cmd.Flags().Set("secrets-dir", dir.Path())
+	require.NoError(t, cmd.Execute())
+	golden.Assert(t, dockerCli.OutBuffer().String(), "import.golden")
+	assert.Equal(t, "Warning: node worker2 not found, its labels were not imported\n", dockerCli.ErrBuffer().String())
+
+	assert.Equal(t, map[string]string{"rack": "1", "zone": "east"}, nodeLabels)
+	require.Len(t, secrets, 2)
+	assert.Equal(t, []byte("s3cr3t"), secrets[0].Data)
+	assert.Nil(t, secrets[1].Data)
+	assert.Equal(t, []swarm.NetworkAttachmentConfig{{Target: "backend-id"}}, service.TaskTemplate.Networks)
+	assert.Equal(t, "config-id", service.TaskTemplate.ContainerSpec.Configs[0].ConfigID)
+	assert.Equal(t, "db_password-id", service.TaskTemplate.ContainerSpec.Secrets[0].SecretID)
+	assert.Equal(t, "existing-id", service.TaskTemplate.ContainerSpec.Secrets[1].SecretID)
+}
+
+func TestSwarmImportErrors(t *testing.T) {
+	unknownNetwork := testArchive()
+	unknownNetwork.Networks = nil
+	unknownNetwork.Secrets = nil
+	unknownNetwork.Services[0].TaskTemplate.ContainerSpec.Secrets = nil
+
+	unsupportedVersion := &bytes.Buffer{}
+	tw := tar.NewWriter(unsupportedVersion)
+	manifest := []byte(`{"Version": 2}`)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: archiveManifestFile, Mode: 0644, Size: int64(len(manifest))}))
+	_, err := tw.Write(manifest)
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+
+	testCases := []struct {
+		name          string
+		input         *command.InStream
+		secretsDir    string
+		expectedError string
+	}{
+		{
+			name:          "not-an-archive",
+			input:         command.NewInStream(ioutil.NopCloser(bytes.NewBufferString("not an archive"))),
+			expectedError: "invalid swarm archive",
+		},
+		{
+			name:          "unsupported-version",
+			input:         command.NewInStream(ioutil.NopCloser(unsupportedVersion)),
+			expectedError: "unsupported swarm archive version 2, expected version 1",
+		},
+		{
+			name:          "no-secrets-dir",
+			input:         archiveInput(t, testArchive()),
+			expectedError: "secret db_password has no value: use --secrets-dir to provide the secret values",
+		},
+		{
+			name:          "missing-secret-value",
+			input:         archiveInput(t, testArchive()),
+			secretsDir:    "/no/such/directory",
+			expectedError: "failed to read the value of secret db_password",
+		},
+		{
+			name:          "unknown-network",
+			input:         archiveInput(t, unknownNetwork),
+			expectedError: "service web uses network backend, which is not in the archive",
+		},
+	}
+	for _, tc := range testCases {
+		dockerCli := test.NewFakeCli(&fakeClient{
+			version: "1.35",
+			swarmUpdateFunc: func(swarm swarm.Spec, flags swarm.UpdateFlags) error {
+				t.Errorf("%s: the swarm was updated", tc.name)
+				return nil
+			},
+		})
+		dockerCli.SetIn(tc.input)
+		cmd := newImportCommand(dockerCli)
+		cmd.Flags().Set("secrets-dir", tc.secretsDir)
+		cmd.SetOutput(ioutil.Discard)
+		testutil.ErrorContains(t, cmd.Execute(), tc.expectedError)
+	}
+}
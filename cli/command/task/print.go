@@ -2,6 +2,7 @@ package task
 
 import (
 	"fmt"
+	"io"
 	"sort"
 
 	"github.com/docker/cli/cli/command"
@@ -36,20 +37,24 @@ func (t tasksBySlot) Less(i, j int) bool {
 // Besides this, command `docker node ps <node>`
 // and `docker stack ps` will call this, too.
 func Print(ctx context.Context, dockerCli command.Cli, tasks []swarm.Task, resolver *idresolver.IDResolver, trunc, quiet bool, format string) error {
+	return printTasks(ctx, dockerCli.Out(), tasks, resolver, trunc, quiet, format)
+}
+
+func printTasks(ctx context.Context, out io.Writer, tasks []swarm.Task, resolver *idresolver.IDResolver, trunc, quiet bool, format string) error {
 	sort.Stable(tasksBySlot(tasks))
 
 	names := map[string]string{}
 	nodes := map[string]string{}
 
 	tasksCtx := formatter.Context{
-		Output: dockerCli.Out(),
+		Output: out,
 		Format: formatter.NewTaskFormat(format, quiet),
 		Trunc:  trunc,
 	}
 
 	prevName := ""
 	for _, task := range tasks {
-		serviceName, err := resolver.Resolve(ctx, swarm.Service{}, task.ServiceID)
+		name, err := resolveName(ctx, resolver, task)
 		if err != nil {
 			return err
 		}
@@ -59,13 +64,6 @@ func Print(ctx context.Context, dockerCli command.Cli, tasks []swarm.Task, resol
 			return err
 		}
 
-		var name string
-		if task.Slot != 0 {
-			name = fmt.Sprintf("%v.%v", serviceName, task.Slot)
-		} else {
-			name = fmt.Sprintf("%v.%v", serviceName, task.NodeID)
-		}
-
 		// Indent the name if necessary
 		indentedName := name
 		if name == prevName {
@@ -83,6 +81,19 @@ func Print(ctx context.Context, dockerCli command.Cli, tasks []swarm.Task, resol
 	return formatter.TaskWrite(tasksCtx, tasks, names, nodes)
 }
 
+// resolveName returns the name of a task, which is the name of its service
+// followed by its slot, or by its node for tasks of global services.
+func resolveName(ctx context.Context, resolver *idresolver.IDResolver, task swarm.Task) (string, error) {
+	serviceName, err := resolver.Resolve(ctx, swarm.Service{}, task.ServiceID)
+	if err != nil {
+		return "", err
+	}
+	if task.Slot != 0 {
+		return fmt.Sprintf("%v.%v", serviceName, task.Slot), nil
+	}
+	return fmt.Sprintf("%v.%v", serviceName, task.NodeID), nil
+}
+
 // DefaultFormat returns the default format from the config file, or table
 // format if nothing is set in the config.
 func DefaultFormat(configFile *configfile.ConfigFile, quiet bool) string {
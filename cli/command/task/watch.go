@@ -0,0 +1,152 @@
+package task
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/idresolver"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/docker/docker/pkg/stringid"
+	"golang.org/x/net/context"
+)
+
+// ListFunc returns the tasks to display in watch mode.
+type ListFunc func(ctx context.Context) ([]swarm.Task, error)
+
+// watchInterval is the time between two listings of the tasks in watch mode.
+var watchInterval = time.Second
+
+// maxFailedTasks is the number of newly failed tasks shown below the table.
+const maxFailedTasks = 10
+
+type failedTask struct {
+	id   string
+	name string
+	node string
+	err  string
+}
+
+// Watch displays the tasks returned by list, and redraws them in place every
+// time they change, until ctx is cancelled. Tasks that fail while watching are
+// listed below the table, along with their error. Errors while listing the
+// tasks or resolving their names, for example while the managers elect a new
+// leader, are displayed below the last table and retried instead of ending
+// the watch.
+func Watch(ctx context.Context, dockerCli command.Cli, list ListFunc, resolver *idresolver.IDResolver, trunc, quiet bool, format string) error {
+	var (
+		out       = dockerCli.Out()
+		states    = map[string]swarm.TaskState{}
+		failed    []failedTask
+		table     []byte
+		lastFrame string
+		first     = true
+	)
+
+	for {
+		tasks, err := list(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err == nil {
+			// The state is only updated once the whole table is rendered,
+			// so that the tasks that failed are detected again on retry.
+			var newlyFailed []failedTask
+			buf := new(bytes.Buffer)
+			if !first {
+				newlyFailed, err = newFailedTasks(ctx, resolver, tasks, states)
+			}
+			if err == nil {
+				err = printTasks(ctx, buf, tasks, resolver, trunc, quiet, format)
+			}
+			if err == nil {
+				for _, task := range tasks {
+					states[task.ID] = task.Status.State
+				}
+				failed = append(failed, newlyFailed...)
+				if len(failed) > maxFailedTasks {
+					failed = failed[len(failed)-maxFailedTasks:]
+				}
+				first = false
+				table = buf.Bytes()
+			}
+		}
+
+		frame := renderWatchFrame(table, failed, err, out.IsTerminal())
+		if frame != lastFrame {
+			if out.IsTerminal() {
+				fmt.Fprint(out, "\033[2J")
+				fmt.Fprint(out, "\033[H")
+			}
+			fmt.Fprint(out, frame)
+			lastFrame = frame
+		}
+
+		select {
+		case <-time.After(watchInterval):
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func isFailed(state swarm.TaskState) bool {
+	return state == swarm.TaskStateFailed || state == swarm.TaskStateRejected
+}
+
+// newFailedTasks returns the tasks that failed since their state was
+// recorded in states.
+func newFailedTasks(ctx context.Context, resolver *idresolver.IDResolver, tasks []swarm.Task, states map[string]swarm.TaskState) ([]failedTask, error) {
+	var failed []failedTask
+	for _, task := range tasks {
+		previous, seen := states[task.ID]
+		if !isFailed(task.Status.State) || (seen && previous == task.Status.State) {
+			continue
+		}
+		f, err := newFailedTask(ctx, resolver, task)
+		if err != nil {
+			return nil, err
+		}
+		failed = append(failed, f)
+	}
+	return failed, nil
+}
+
+func newFailedTask(ctx context.Context, resolver *idresolver.IDResolver, task swarm.Task) (failedTask, error) {
+	name, err := resolveName(ctx, resolver, task)
+	if err != nil {
+		return failedTask{}, err
+	}
+	node, err := resolver.Resolve(ctx, swarm.Node{}, task.NodeID)
+	if err != nil {
+		return failedTask{}, err
+	}
+	return failedTask{
+		id:   stringid.TruncateID(task.ID),
+		name: name,
+		node: node,
+		err:  task.Status.Err,
+	}, nil
+}
+
+func renderWatchFrame(table []byte, failed []failedTask, err error, color bool) string {
+	frame := new(bytes.Buffer)
+	frame.Write(table)
+	if len(failed) > 0 {
+		fmt.Fprintln(frame)
+		fmt.Fprintln(frame, "Failed while watching:")
+		for _, f := range failed {
+			line := fmt.Sprintf("%s (%s) on %s: %s", f.name, f.id, f.node, f.err)
+			if color {
+				line = "\033[31m" + line + "\033[0m"
+			}
+			fmt.Fprintln(frame, line)
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(frame)
+		fmt.Fprintf(frame, "Error updating tasks, retrying: %v\n", err)
+	}
+	return frame.String()
+}
@@ -0,0 +1,52 @@
+package task
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/docker/cli/cli/command/idresolver"
+	"github.com/docker/cli/internal/test"
+	// Import builders to get the builder function as package function
+	. "github.com/docker/cli/internal/test/builders"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/gotestyourself/gotestyourself/golden"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestWatch(t *testing.T) {
+	defer func(interval time.Duration) { watchInterval = interval }(watchInterval)
+	watchInterval = time.Millisecond
+
+	running := *Task(TaskID("id-running"), TaskServiceID("service-id-foo"), TaskNodeID("node-id-bar"), TaskSlot(1),
+		WithStatus(TaskState(swarm.TaskStateRunning)))
+	alreadyFailed := *Task(TaskID("id-old-failure"), TaskServiceID("service-id-foo"), TaskNodeID("node-id-bar"), TaskSlot(2),
+		WithStatus(TaskState(swarm.TaskStateFailed), StatusErr("old failure")))
+	nowFailed := running
+	nowFailed.Status = *TaskStatus(TaskState(swarm.TaskStateFailed), StatusErr("task: non-zero exit (1)"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	listings := []func() ([]swarm.Task, error){
+		func() ([]swarm.Task, error) { return []swarm.Task{running, alreadyFailed}, nil },
+		// unchanged, must not redraw
+		func() ([]swarm.Task, error) { return []swarm.Task{running, alreadyFailed}, nil },
+		func() ([]swarm.Task, error) { return nil, errors.New("rpc error: manager is not the leader") },
+		func() ([]swarm.Task, error) { return []swarm.Task{nowFailed, alreadyFailed}, nil },
+		func() ([]swarm.Task, error) {
+			cancel()
+			return nil, context.Canceled
+		},
+	}
+	list := func(ctx context.Context) ([]swarm.Task, error) {
+		next := listings[0]
+		listings = listings[1:]
+		return next()
+	}
+
+	apiClient := &fakeClient{}
+	cli := test.NewFakeCli(apiClient)
+	err := Watch(ctx, cli, list, idresolver.New(apiClient, true), false, false, "{{ .ID }}\t{{ .Error }}")
+	assert.NoError(t, err)
+	golden.Assert(t, cli.OutBuffer().String(), "task-watch.golden")
+}
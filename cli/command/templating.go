@@ -0,0 +1,158 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/docker/docker/api/types/versions"
+	"github.com/docker/docker/client"
+	"github.com/docker/go-connections/sockets"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// templatingAPIVersion is the API version that added template drivers to
+// configs and secrets.
+const templatingAPIVersion = "1.37"
+
+// ConfigSpecWithTemplating is a swarm.ConfigSpec with the template driver of
+// API version 1.37, which the vendored API types do not have yet.
+type ConfigSpecWithTemplating struct {
+	swarm.ConfigSpec
+	Templating *swarm.Driver `json:",omitempty"`
+}
+
+// SecretSpecWithTemplating is a swarm.SecretSpec with the template driver of
+// API version 1.37, which the vendored API types do not have yet.
+type SecretSpecWithTemplating struct {
+	swarm.SecretSpec
+	Templating *swarm.Driver `json:",omitempty"`
+}
+
+// TemplatingClient creates configs and secrets that have a template driver.
+type TemplatingClient interface {
+	ConfigCreate(ctx context.Context, spec ConfigSpecWithTemplating) (types.ConfigCreateResponse, error)
+	SecretCreate(ctx context.Context, spec SecretSpecWithTemplating) (types.SecretCreateResponse, error)
+}
+
+// templatingClient sends its requests to the daemon of apiClient, with the
+// same API version.
+type templatingClient struct {
+	apiClient  client.APIClient
+	httpClient *http.Client
+	headers    map[string]string
+	scheme     string
+	proto      string
+	addr       string
+	basePath   string
+}
+
+// newTemplatingClient returns a TemplatingClient for the daemon of apiClient.
+// A nil httpClient uses the default transport of the API client.
+func newTemplatingClient(apiClient client.APIClient, httpClient *http.Client, headers map[string]string) (TemplatingClient, error) {
+	hostURL, err := client.ParseHostURL(apiClient.DaemonHost())
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := "http"
+	if httpClient == nil {
+		transport := new(http.Transport)
+		sockets.ConfigureTransport(transport, hostURL.Scheme, hostURL.Host)
+		httpClient = &http.Client{
+			Transport:     transport,
+			CheckRedirect: client.CheckRedirect,
+		}
+	} else if transport, ok := httpClient.Transport.(*http.Transport); ok && transport.TLSClientConfig != nil {
+		scheme = "https"
+	}
+
+	return &templatingClient{
+		apiClient:  apiClient,
+		httpClient: httpClient,
+		headers:    headers,
+		scheme:     scheme,
+		proto:      hostURL.Scheme,
+		addr:       hostURL.Host,
+		basePath:   hostURL.Path,
+	}, nil
+}
+
+// ConfigCreate creates a config with a template driver.
+func (c *templatingClient) ConfigCreate(ctx context.Context, spec ConfigSpecWithTemplating) (types.ConfigCreateResponse, error) {
+	var response types.ConfigCreateResponse
+	err := c.post(ctx, "/configs/create", spec, &response)
+	return response, err
+}
+
+// SecretCreate creates a secret with a template driver.
+func (c *templatingClient) SecretCreate(ctx context.Context, spec SecretSpecWithTemplating) (types.SecretCreateResponse, error) {
+	var response types.SecretCreateResponse
+	err := c.post(ctx, "/secrets/create", spec, &response)
+	return response, err
+}
+
+func (c *templatingClient) post(ctx context.Context, path string, body interface{}, response interface{}) error {
+	version := c.apiClient.ClientVersion()
+	if versions.LessThan(version, templatingAPIVersion) {
+		return errors.Errorf("template drivers require API version %s, but the Docker daemon API version is %s", templatingAPIVersion, version)
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", c.basePath+"/v"+version+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.proto == "unix" || c.proto == "npipe" {
+		// as done by the API client, for local communications the host only
+		// needs to be a valid host name
+		req.Host = "docker"
+	}
+	req.URL.Host = c.addr
+	req.URL.Scheme = c.scheme
+
+	resp, err := c.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return errors.Wrap(err, "error during connect")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return responseError(resp)
+	}
+	return json.NewDecoder(resp.Body).Decode(response)
+}
+
+// responseError returns the error of a failed API request, in the same form
+// as the API client.
+func responseError(resp *http.Response) error {
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if len(body) == 0 {
+		return fmt.Errorf("request returned %s for API route and version %s, check if the server supports the requested API version", http.StatusText(resp.StatusCode), resp.Request.URL)
+	}
+	message := string(body)
+	if resp.Header.Get("Content-Type") == "application/json" {
+		var errorResponse types.ErrorResponse
+		if err := json.Unmarshal(body, &errorResponse); err != nil {
+			return fmt.Errorf("Error reading JSON: %v", err)
+		}
+		message = errorResponse.Message
+	}
+	return fmt.Errorf("Error response from daemon: %s", strings.TrimSpace(message))
+}
@@ -0,0 +1,82 @@
+package command
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/docker/cli/internal/test/testutil"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/docker/docker/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func newTestTemplatingClient(t *testing.T, url, version string) TemplatingClient {
+	apiClient, err := client.NewClient("tcp://"+url[len("http://"):], version, nil, nil)
+	require.NoError(t, err)
+	templatingClient, err := newTemplatingClient(apiClient, nil, map[string]string{"User-Agent": UserAgent()})
+	require.NoError(t, err)
+	return templatingClient
+}
+
+func TestTemplatingClientConfigCreate(t *testing.T) {
+	var (
+		path, userAgent string
+		body            map[string]interface{}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		userAgent = r.Header.Get("User-Agent")
+		data, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(data, &body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ID": "config-id"}`))
+	}))
+	defer server.Close()
+
+	spec := ConfigSpecWithTemplating{
+		ConfigSpec: swarm.ConfigSpec{
+			Annotations: swarm.Annotations{Name: "nginx"},
+			Data:        []byte("listen {{env \"PORT\"}}"),
+		},
+		Templating: &swarm.Driver{Name: "golang"},
+	}
+	response, err := newTestTemplatingClient(t, server.URL, "1.37").ConfigCreate(context.Background(), spec)
+	require.NoError(t, err)
+	assert.Equal(t, types.ConfigCreateResponse{ID: "config-id"}, response)
+	assert.Equal(t, "/v1.37/configs/create", path)
+	assert.Equal(t, UserAgent(), userAgent)
+	assert.Equal(t, "nginx", body["Name"])
+	assert.Equal(t, map[string]interface{}{"Name": "golang"}, body["Templating"])
+}
+
+func TestTemplatingClientSecretCreateError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"message": "secret password already exists"}`))
+	}))
+	defer server.Close()
+
+	spec := SecretSpecWithTemplating{
+		SecretSpec: swarm.SecretSpec{Annotations: swarm.Annotations{Name: "password"}},
+		Templating: &swarm.Driver{Name: "golang"},
+	}
+	_, err := newTestTemplatingClient(t, server.URL, "1.37").SecretCreate(context.Background(), spec)
+	testutil.ErrorContains(t, err, "Error response from daemon: secret password already exists")
+}
+
+func TestTemplatingClientRequiresAPIVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	_, err := newTestTemplatingClient(t, server.URL, "1.35").ConfigCreate(context.Background(), ConfigSpecWithTemplating{})
+	testutil.ErrorContains(t, err, "template drivers require API version 1.37, but the Docker daemon API version is 1.35")
+}
@@ -110,6 +110,34 @@ func Secrets(namespace Namespace, secrets map[string]composetypes.SecretConfig)
 	return result, nil
 }
 
+// SecretSources returns the x-source of the secrets that have one, keyed by
+// the name of the secret. Secrets leaves the data of these secrets empty, as
+// their source must only be read when the secret is created.
+func SecretSources(namespace Namespace, secrets map[string]composetypes.SecretConfig) map[string]string {
+	sources := map[string]string{}
+	for name, secret := range secrets {
+		if secret.External.External || secret.Source == "" {
+			continue
+		}
+		sources[fileObjectName(namespace, name, composetypes.FileObjectConfig(secret))] = secret.Source
+	}
+	return sources
+}
+
+// SecretTemplateDrivers returns the template driver of the secrets that have
+// one, keyed by the name of the secret. The vendored API types do not have
+// the template driver of a secret yet, so Secrets leaves it out.
+func SecretTemplateDrivers(namespace Namespace, secrets map[string]composetypes.SecretConfig) map[string]string {
+	drivers := map[string]string{}
+	for name, secret := range secrets {
+		if secret.External.External || secret.TemplateDriver == "" {
+			continue
+		}
+		drivers[fileObjectName(namespace, name, composetypes.FileObjectConfig(secret))] = secret.TemplateDriver
+	}
+	return drivers
+}
+
 // Configs converts config objects from the Compose type to the engine API type
 func Configs(namespace Namespace, configs map[string]composetypes.ConfigObjConfig) ([]swarm.ConfigSpec, error) {
 	result := []swarm.ConfigSpec{}
@@ -127,28 +155,62 @@ func Configs(namespace Namespace, configs map[string]composetypes.ConfigObjConfi
 	return result, nil
 }
 
+// ConfigSources returns the x-source of the configs that have one, keyed by
+// the name of the config. Configs leaves the data of these configs empty, as
+// their source must only be read when the config is created.
+func ConfigSources(namespace Namespace, configs map[string]composetypes.ConfigObjConfig) map[string]string {
+	sources := map[string]string{}
+	for name, config := range configs {
+		if config.External.External || config.Source == "" {
+			continue
+		}
+		sources[fileObjectName(namespace, name, composetypes.FileObjectConfig(config))] = config.Source
+	}
+	return sources
+}
+
+// ConfigTemplateDrivers returns the template driver of the configs that have
+// one, keyed by the name of the config. The vendored API types do not have
+// the template driver of a config yet, so Configs leaves it out.
+func ConfigTemplateDrivers(namespace Namespace, configs map[string]composetypes.ConfigObjConfig) map[string]string {
+	drivers := map[string]string{}
+	for name, config := range configs {
+		if config.External.External || config.TemplateDriver == "" {
+			continue
+		}
+		drivers[fileObjectName(namespace, name, composetypes.FileObjectConfig(config))] = config.TemplateDriver
+	}
+	return drivers
+}
+
 type swarmFileObject struct {
 	Annotations swarm.Annotations
 	Data        []byte
 }
 
 func fileObjectConfig(namespace Namespace, name string, obj composetypes.FileObjectConfig) (swarmFileObject, error) {
-	data, err := ioutil.ReadFile(obj.File)
-	if err != nil {
-		return swarmFileObject{}, err
-	}
-
-	if obj.Name != "" {
-		name = obj.Name
-	} else {
-		name = namespace.Scope(name)
+	// the data of objects with an x-source is read by the caller
+	var data []byte
+	if obj.Source == "" {
+		var err error
+		if data, err = ioutil.ReadFile(obj.File); err != nil {
+			return swarmFileObject{}, err
+		}
 	}
 
 	return swarmFileObject{
 		Annotations: swarm.Annotations{
-			Name:   name,
+			Name:   fileObjectName(namespace, name, obj),
 			Labels: AddStackLabel(namespace, obj.Labels),
 		},
 		Data: data,
 	}, nil
 }
+
+// fileObjectName returns the name of a secret or config in the swarm.
+func fileObjectName(namespace Namespace, name string, obj composetypes.FileObjectConfig) string {
+	if obj.Name != "" {
+		return obj.Name
+	}
+	return namespace.Scope(name)
+}
@@ -162,3 +162,49 @@ func TestConfigs(t *testing.T) {
 	}, config.Labels)
 	assert.Equal(t, []byte(configText), config.Data)
 }
+
+func TestTemplateDrivers(t *testing.T) {
+	namespace := Namespace{name: "foo"}
+	configs := map[string]composetypes.ConfigObjConfig{
+		"one":      {Source: "env:CONVERT_TEST_CONFIG", TemplateDriver: "golang"},
+		"two":      {Source: "env:CONVERT_TEST_CONFIG"},
+		"external": {External: composetypes.External{External: true}, TemplateDriver: "golang"},
+	}
+	assert.Equal(t, map[string]string{"foo_one": "golang"}, ConfigTemplateDrivers(namespace, configs))
+
+	secrets := map[string]composetypes.SecretConfig{
+		"one": {Name: "db_url", Source: "env:CONVERT_TEST_SECRET", TemplateDriver: "golang"},
+	}
+	assert.Equal(t, map[string]string{"db_url": "golang"}, SecretTemplateDrivers(namespace, secrets))
+}
+
+func TestSecretsWithSource(t *testing.T) {
+	namespace := Namespace{name: "foo"}
+	secrets := map[string]composetypes.SecretConfig{
+		"one":      {Source: "env:CONVERT_TEST_SECRET"},
+		"two":      {Name: "db_password", Source: "generate:passphrase"},
+		"external": {External: composetypes.External{External: true}},
+	}
+	specs, err := Secrets(namespace, secrets)
+	require.NoError(t, err)
+	require.Len(t, specs, 2)
+	for _, spec := range specs {
+		assert.Nil(t, spec.Data, spec.Name)
+	}
+	assert.Equal(t, map[string]string{
+		"foo_one":     "env:CONVERT_TEST_SECRET",
+		"db_password": "generate:passphrase",
+	}, SecretSources(namespace, secrets))
+}
+
+func TestConfigsWithSource(t *testing.T) {
+	namespace := Namespace{name: "foo"}
+	configs := map[string]composetypes.ConfigObjConfig{
+		"one": {Source: "env:CONVERT_TEST_CONFIG"},
+	}
+	specs, err := Configs(namespace, configs)
+	require.NoError(t, err)
+	require.Len(t, specs, 1)
+	assert.Nil(t, specs[0].Data)
+	assert.Equal(t, map[string]string{"foo_one": "env:CONVERT_TEST_CONFIG"}, ConfigSources(namespace, configs))
+}
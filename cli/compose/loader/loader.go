@@ -12,6 +12,7 @@ import (
 	"github.com/docker/cli/cli/compose/template"
 	"github.com/docker/cli/cli/compose/types"
 	"github.com/docker/cli/opts"
+	"github.com/docker/cli/secret/source"
 	"github.com/docker/docker/api/types/versions"
 	"github.com/docker/go-connections/nat"
 	units "github.com/docker/go-units"
@@ -548,6 +549,13 @@ func loadFileObjectConfig(name string, objType string, obj types.FileObjectConfi
 			}
 		}
 		// if not "external: true"
+	} else if obj.Source != "" {
+		if obj.File != "" {
+			return obj, errors.Errorf("%[1]s %[2]s: %[1]s.file and %[1]s.x-source conflict; only use one of them", objType, name)
+		}
+		if _, err := source.Parse(obj.Source); err != nil {
+			return obj, errors.Wrapf(err, "%s %s", objType, name)
+		}
 	} else {
 		obj.File = absPath(details.WorkingDir, obj.File)
 	}
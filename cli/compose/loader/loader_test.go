@@ -1513,6 +1513,84 @@ configs:
 	assert.Equal(t, "invalid", actual.Services[0].Isolation)
 }
 
+func TestLoadV36TemplateDriver(t *testing.T) {
+	actual, err := loadYAML(`
+version: "3.6"
+services:
+  foo:
+    image: busybox
+configs:
+  nginx:
+    file: ./nginx.conf
+    template_driver: golang
+secrets:
+  password:
+    file: ./password.txt
+    template_driver: golang
+`)
+	require.NoError(t, err)
+	assert.Equal(t, "golang", actual.Configs["nginx"].TemplateDriver)
+	assert.Equal(t, "golang", actual.Secrets["password"].TemplateDriver)
+}
+
+func TestLoadV35TemplateDriverNotSupported(t *testing.T) {
+	_, err := loadYAML(`
+version: "3.5"
+services:
+  foo:
+    image: busybox
+configs:
+  nginx:
+    file: ./nginx.conf
+    template_driver: golang
+`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Additional property template_driver is not allowed")
+}
+
+func TestLoadV36SecretSource(t *testing.T) {
+	actual, err := loadYAML(`
+version: "3.6"
+services:
+  foo:
+    image: busybox
+secrets:
+  password:
+    x-source: generate:passphrase
+  token:
+    x-source: env:TOKEN
+    x-comment: read from the environment
+configs:
+  key:
+    x-source: generate:base64:16
+`)
+	require.NoError(t, err)
+	assert.Equal(t, types.SecretConfig{Source: "generate:passphrase"}, actual.Secrets["password"])
+	assert.Equal(t, types.SecretConfig{Source: "env:TOKEN"}, actual.Secrets["token"])
+	assert.Equal(t, types.ConfigObjConfig{Source: "generate:base64:16"}, actual.Configs["key"])
+}
+
+func TestLoadV36SecretSourceErrors(t *testing.T) {
+	_, err := loadYAML(`
+version: "3.6"
+secrets:
+  password:
+    file: ./password.txt
+    x-source: generate:passphrase
+`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "secret password: secret.file and secret.x-source conflict")
+
+	_, err = loadYAML(`
+version: "3.6"
+secrets:
+  password:
+    x-source: vault:secret/password
+`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `secret password: invalid secret source "vault:secret/password"`)
+}
+
 func TestLoadSecretInvalidExternalNameAndNameCombination(t *testing.T) {
 	_, err := loadYAML(`
 version: "3.5"
@@ -6,6 +6,7 @@
 // data/config_schema_v3.3.json
 // data/config_schema_v3.4.json
 // data/config_schema_v3.5.json
+// data/config_schema_v3.6.json
 // DO NOT EDIT!
 
 package schema
@@ -193,6 +194,26 @@ func dataConfig_schema_v35Json() (*asset, error) {
 	return a, nil
 }
 
+var _dataConfig_schema_v36Json = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xed\x5b\x4b\x93\x9b\x38\x10\xbe\xfb\x57\x4c\x91\xdc\x62\xcf\xa4\x6a\x53\xa9\xda\xdc\xf6\xb8\xa7\xdd\xf3\x4e\x39\x94\x0c\x32\x56\x06\x10\x91\x84\x13\x27\xe5\xff\xbe\x2d\x04\x0c\x12\x12\x12\x36\x93\x99\xec\x26\x97\x8c\xa1\xf5\xe8\xf7\xd7\x2d\xf1\x7d\x75\x73\x13\xbd\xe6\xc9\x01\x17\x28\xfa\x70\x13\x1d\x84\xa8\x3e\xdc\xdd\x7d\xe2\xb4\xdc\xa8\xa7\xb7\x94\x65\x77\x29\x43\x7b\xb1\x79\xfb\xee\x4e\x3d\x7b\x15\xad\xe5\x38\x92\xca\x21\x09\x2d\xf7\x24\x8b\xd5\x9b\xf8\xf8\xdb\xed\xfb\x5b\x39\x5c\x91\x88\x53\x85\x25\x11\xdd\x7d\xc2\x89\x50\xcf\x18\xfe\x5c\x13\x86\xe5\xe0\xfb\xe8\x88\x19\x27\x40\xbd\x5d\xaf\xe4\xbb\x8a\xd1\x0a\x33\x41\x30\x87\xb7\xdf\xe1\x09\x3c\xeb\x48\xba\x07\x83\x69\xb9\x60\xa4\xcc\xa2\xe6\xf1\xb9\x99\x01\x5e\x72\xcc\x8e\x24\x19\xcc\xd0\x6f\xf5\xd5\xdd\xe3\xfc\x77\x3d\xd9\xda\x9c\x75\xb0\xd9\xe6\x79\x85\x84\xc0\xac\xfc\x7b\xbc\xb7\xe6\xf5\xc7\x7b\xb4\xf9\xf6\xc7\xe6\x9f\xb7\x9b\xdf\x6f\xe3\xcd\xf6\xcd\x6b\xed\xb5\x94\x2f\xc3\x7b\xb5\x7c\x8a\xf7\xa4\x24\x02\xb8\xe9\xd7\x8f\x7a\xca\x73\xfb\xd7\xb9\x5f\x18\xa5\x69\x43\x8c\x72\x6d\xed\x3d\xca\x39\xd6\x79\x2e\xb1\xf8\x42\xd9\x83\x8f\xe7\x9e\xec\x99\x78\x6e\xd7\xb7\xf0\xac\xb3\x73\xa4\x79\x5d\x78\x35\xd8\x51\x3d\x13\x33\x6a\xf9\x65\xf4\xc7\x71\xc2\xb0\xf0\x9b\xac\xa2\x7a\x36\x8b\x95\xcb\x2f\xc3\xb0\x8a\x1a\x3e\x86\x3b\xaa\x67\x62\x58\x2d\x7f\x1d\xc3\xab\x8e\x69\xfb\x1e\xa3\x8f\x5f\x37\xf2\xff\x73\x33\xe7\xe4\x7c\x6a\x96\xc1\xfe\x1a\x26\xb4\x98\x67\x13\xa7\x2d\xe6\xb8\xe5\xd9\x0b\xd4\x21\xc9\x14\x57\x39\x3d\x35\x3b\xb7\xcb\x4c\x11\x14\xb8\x14\x51\x2f\x26\x18\xb7\xab\x49\x9e\x9a\x52\xa7\x25\xfe\x4b\x4e\x71\x3f\x78\x78\x03\x33\x1b\xe1\x7d\x30\x4f\xf3\x5e\xfb\xe5\x36\x8a\xfe\xbd\x83\x97\xfe\x3d\xa8\x59\xe0\xaf\xa2\x61\x6a\x7a\x69\x25\x02\x9a\x3c\x60\xb6\x27\x39\x0e\x1d\x81\x98\xb2\x74\x87\xc8\x72\xc2\x45\x4c\x59\x9c\x92\x44\x58\xc7\xe7\x68\x87\xf3\xab\x66\x48\x10\xa4\xe7\x78\xcf\x68\xe1\x9d\x65\x1f\x2b\x4e\xb8\x75\xa2\x2e\x82\x07\x72\x2e\x80\x75\x1c\x2c\x59\x7e\x28\x62\x4e\xbe\x69\x72\xbd\x8f\x08\x68\x27\xc3\x2c\x5a\xf7\x63\xb7\x67\x63\xec\x68\x32\xbf\x63\x9a\x3e\x2d\xff\x6d\x57\x96\x09\x41\x76\x55\x0c\xd3\x69\x4c\x20\xc6\xd0\x49\xee\x88\x08\x5c\x70\x3b\x7f\x37\x51\x5d\x92\xcf\x35\xfe\xb3\x25\x11\xac\xc6\xe6\xbc\x29\x6c\x6e\xf9\x89\x33\x46\xeb\x2a\xae\x10\x93\x5e\x38\x2d\x7b\x30\xfe\xa2\x40\xe5\x52\xae\x39\x87\x8f\x00\xc9\x8f\x92\x84\xe6\xef\xed\x1a\xc3\x57\xfd\x6a\xda\xb6\x1c\xdc\xdc\x04\x58\xa5\x25\x5c\x78\xc2\x8d\x3f\xe0\x48\x4b\xa7\x35\x4b\x42\xe3\xc7\x5c\x3f\x02\xfa\x9a\xa4\xe1\xc4\xd9\x1c\xe2\x82\xa6\xfa\xbe\xcb\xba\xd8\x81\x77\x9e\x47\xc4\x23\x27\xd5\x7e\x6f\x57\xb6\x37\x86\xf6\x05\x22\x25\x66\x71\x89\x0a\xec\xb5\x63\xa8\x28\xc0\xdc\x09\xca\x63\x5e\xe1\x44\x23\xef\x34\x35\xa1\x99\x28\x28\x9e\x43\xed\x92\x41\x90\x64\x27\x2b\xe5\x23\x17\xc3\x8d\x41\x42\xc4\x65\xca\x63\x55\xc1\xcc\x0f\xbd\x30\x41\x5f\xce\x2c\x1a\x26\xd2\x72\x2a\xa5\xa8\x69\x64\x52\x91\x7b\x8b\x8c\x81\x31\xc7\x88\x25\x87\x0b\xc7\xd3\x02\xf4\x1a\xa2\x54\x50\x28\x3b\x55\x94\xa8\x30\xf6\xe2\xe2\x13\x2e\x8f\x71\x6f\x37\xb3\xc5\x00\xa3\x09\xa3\x65\xd1\x05\xe9\xb0\xd4\x3e\x18\xff\xb5\xa2\x1c\x5f\x1f\x1c\xfb\x44\xdb\x32\xbe\xee\x7d\x7a\xab\x4b\x2f\xda\x53\x56\x20\xb9\xd9\x6e\xed\x95\x23\x05\x5b\x2c\x6f\x28\xc0\x21\x0f\x12\x12\x83\xcf\xe6\xa4\x7c\x58\xde\xc4\x61\x7a\x86\xe2\x03\xe5\xe2\x12\xf4\x14\x1d\x30\xca\xc5\x01\x90\x53\xf2\x30\x31\x7c\x48\xa5\x8d\x86\x65\x43\x8c\x9c\x14\x28\xf3\x13\x55\x89\x97\x84\xd3\x1c\x89\xb6\x53\x32\x45\x78\x31\x9c\x8c\x16\xd5\xd2\x60\x5a\x9a\x65\x92\xd4\x65\x9a\xa3\xf2\x24\x10\xd8\xa7\x8c\x1c\xc1\x8c\x03\xd1\x27\xad\x1e\xab\x2a\x5b\x0a\xf6\xa5\x7d\x6f\x19\xaa\x91\x7e\xbc\x55\x55\xe8\x84\xfb\x35\x7f\xe5\xf9\x18\xee\xda\xb2\xab\xf9\xc4\xe0\x30\x0c\x10\x6b\x5a\x29\x50\x22\x71\x2f\xc3\x9c\xfb\x2c\xaa\xad\x0a\xe2\x11\x38\x78\xa4\x1d\x11\xf3\xd0\x90\x7e\x51\xb1\x32\xbf\x48\x0c\x52\x9d\xb7\x93\xe0\x85\x9c\x2e\x58\x19\x6e\x65\x61\x10\xb3\x53\x7b\x4e\x10\xc7\xfc\xba\xaa\x6f\x10\x85\x8e\xef\x02\x6d\xc2\x36\xf6\xfd\xe4\x58\xc7\x50\xe7\x9c\xe1\x35\x9e\x67\xaa\x21\x96\x05\x77\xb3\x6d\x64\xeb\x47\xb7\x4f\x59\x82\x56\x3a\x42\xd7\x63\x45\x13\x21\x86\x0e\x56\x51\x26\x7e\x48\xd1\xf4\x18\xa7\x1e\x91\x81\x5a\x7c\x5c\x47\x99\xea\x0e\x1a\xf4\x34\xc5\xd7\x44\x94\x0a\x2b\xbd\xba\x8e\x84\x7d\x40\x55\xef\xc0\xa7\x0e\x38\x9d\x33\x86\x51\x41\x13\x9a\x87\x39\x86\xb5\xc7\x14\xee\x0c\x13\x85\xd8\x45\x20\xae\x82\x34\x0b\x28\x38\x33\x38\xde\x51\x9a\x63\x54\x6a\x89\x82\x61\x94\x42\x25\x94\x9f\x02\x28\x39\x48\xde\xdb\xbe\xe0\x38\xa9\x19\x11\xa7\x18\xb2\xf7\xe2\xf0\xd1\xde\x8f\x7a\xb4\xfa\xbe\x1d\xa5\x6f\xc8\xe8\xe4\xff\xea\x59\xfc\x7f\x7a\x16\xfc\xc4\x13\x71\x19\xb6\xe6\x22\x25\x25\x98\x31\x2e\xbd\xbe\xc1\x05\xad\xe2\x8c\xa1\x04\xc7\xa0\x33\x42\xad\xa2\xd0\x02\x6c\x5a\x33\x55\x1a\x8c\xa6\xe1\x24\x83\x98\xe1\x73\x33\x51\x54\xfb\x0b\xbb\x05\x42\xf8\x9d\xbd\xce\x49\x41\xdc\x4e\x63\xb1\xda\x00\xbc\xa6\xb0\x9a\x1d\xa2\x4d\xc0\xb3\xa0\x90\x3d\x51\x21\x4c\x17\x08\x01\x95\xc1\x01\xb1\x19\xa9\xa3\x71\xcc\xbd\x23\x3f\xad\x02\x31\x90\x7e\x26\xdf\xcc\xb7\x6e\x37\xb2\xb5\xd2\xcf\x82\x5e\xe6\x36\xb6\x4e\xf4\x63\x77\xaa\x9a\x7b\x8b\xb8\x86\xa6\xe4\x71\x40\x6a\xb7\x1c\x2e\xff\x1c\x11\x5a\xd3\x51\x43\xbe\xbd\x28\x8e\xb7\x2b\x05\xc6\xce\xa7\x8e\xfa\xc1\x88\x40\x3f\xb0\xe3\x10\x66\x70\x99\x9c\xc2\x17\xda\x91\xd1\x29\xc7\xdc\xba\x2b\xac\xea\x6a\xa8\x50\xe6\x6e\xc5\xd8\x6b\x93\x60\x5f\x6d\xef\x1d\xfc\x10\x56\x4a\x00\xa5\x95\x43\x35\xe1\x6c\x3c\x31\x80\x35\x3a\x1d\x13\xb0\xd5\x15\x61\x64\x3f\x42\xe6\xaf\x94\xb0\x29\x8d\x5d\x72\xd1\xc1\xe8\x21\x4e\x9d\xce\x0f\x49\xbd\x37\x1e\xa6\x6f\x0a\xf8\x4e\xf1\x09\x47\x3b\xe3\xbc\xc3\x96\x97\x65\x22\x61\x47\x3b\x3c\xf0\xe3\x0b\xc0\xc1\x8c\x18\x07\x17\x1d\xf2\x1a\x02\x04\x40\xfa\x2f\xb2\xbd\x2f\x48\x81\x69\x2d\x2e\x05\x57\x50\xbd\xcc\x87\x67\xe6\x7d\xa8\xc1\xa5\x8b\xee\xa0\x60\xca\x84\x06\x94\xa6\x05\xdd\x0f\x8e\xc1\x54\xd3\xc0\x6b\x26\x21\xd9\x14\x97\x69\x73\x40\x13\x94\x7a\x19\x6c\x8f\x24\x88\xfb\xe0\xcd\x15\x2d\xea\xba\x4a\x91\xc0\x71\x7b\x75\x67\x0e\xa0\x9c\x40\x92\x15\x62\x28\xcf\x31\x2c\x5a\x84\x20\x33\x50\x58\x8e\x4e\x17\xd9\x8d\x3a\x67\x41\x24\xaf\x19\x8e\x51\x12\xd0\xce\x6f\x35\x05\x82\xa1\xec\xf2\x25\x0b\xf4\x35\xee\x96\x6d\x48\x3c\x5e\xab\xbc\x94\xa5\xd8\xb5\x26\x86\x31\x16\x6c\xa4\xfc\x62\xb3\x27\x8c\x0b\x55\x42\xd3\xaa\xfd\xa5\x07\xf5\xb3\xb3\x2d\x11\xda\xc9\x1e\xb6\x12\x1a\x10\xc3\x97\x32\x07\x6b\xb5\xb2\xcc\x55\xa4\xaa\x0e\x6d\xac\x46\x05\x2e\xa8\xef\x24\xfa\xfa\xde\xa4\xa1\x72\x90\xa5\xcc\x08\xae\x93\x92\x97\x22\x00\x0b\x75\x86\x4b\x08\xc6\x49\xac\x59\x83\x23\xba\x8c\x69\x9f\xa8\xdd\x7b\xbd\x65\xab\x34\x43\x21\xac\x9e\x96\x32\x6f\x88\x9d\x6a\x1f\x21\x91\xe7\xca\x50\x27\xe3\x8e\xac\xe4\x8b\x4a\xf0\xa0\xd0\xfa\x05\x60\x3c\xfd\x32\x3f\xa3\x2e\x20\xed\x2a\x47\x09\x36\xb2\xf0\xb5\x82\x86\xbd\x23\x60\x75\xf6\xa1\xaa\x29\x96\x0a\xec\x18\x33\xa8\x88\xf0\xa4\x5b\x8e\x8b\xdb\x89\x02\x77\xb9\xce\x61\x25\xab\xbc\x67\xe8\x6d\x5f\xab\xfc\x2b\x70\xbf\x35\xdc\x4c\x41\xb7\xf1\x80\x51\x0d\xa0\x6b\xcf\xa2\x35\xb7\xb6\x26\x6e\x3e\x41\x31\x20\x5b\xd6\xb8\x5f\xb9\xbf\x40\xb5\x0a\xb7\x84\x69\x2b\x88\x1e\xda\xe2\xdb\x1b\xa8\x23\xa8\x36\xea\x80\x66\xed\x45\xc7\xdb\xae\xf2\x2f\x60\xf0\xd9\xfa\xbd\x85\x4f\xa7\x1d\xd9\x02\x58\x3c\xe4\x26\x49\xd0\x7d\x87\x96\x4a\x1e\x98\x2c\xde\x70\xf5\xdf\x69\xd8\xfa\xdb\x7d\xa4\x42\xc5\x52\x11\x36\xf8\x06\x48\x64\x2d\x18\x5e\x42\xec\xac\x77\xa5\xa3\x9f\xf6\xb2\x63\xe7\x7a\x7c\xcb\xcb\xa1\xd5\xfb\xbe\xf7\xb0\xee\x65\xb5\x0d\x56\xb1\xd3\x31\x96\xdb\x7f\xd3\x06\x31\x4f\x49\x6c\xfd\x12\x70\x11\x94\x1c\x82\x5a\x2b\x33\x2b\xdc\x2b\x32\xd1\xa8\x5f\x68\x0d\x55\x2d\xd5\xaf\x48\x35\x23\x52\xfd\xec\x76\xfd\xe3\x6c\xb0\xfd\x74\xcc\xfb\x79\x52\x43\xe5\xff\xda\xeb\x0a\xcb\x0b\xb8\xe7\x1d\xc9\x72\x24\x97\x5d\xa4\x30\x33\x7d\x7e\x33\x78\x12\xed\x06\x7c\xaf\x76\xd1\x47\x7f\x3e\x2b\x68\xa9\x7e\x59\xc1\x7f\xd4\x0a\x8c\xbb\x02\x03\x6b\x18\x77\xfd\xa7\x24\x1e\x7c\xa1\x71\x35\x6c\xf2\xf7\xdb\x30\xc9\x2c\x9f\x97\xbb\xea\x2d\xe7\xa6\x5c\x67\x4f\xc6\xa2\xad\xb0\xa7\x39\x5f\x30\xdb\xdd\xbe\x99\xc0\xb5\x53\x17\x8f\x9f\x08\x10\x2e\x70\x4b\xcb\xae\x53\xa3\xa1\xb2\xea\xef\x64\x99\x5f\xc7\xba\x43\x4f\x37\x7e\xf4\xad\xac\xe4\xb3\x3c\x8d\x4e\xa5\xbe\xeb\xe7\xf2\xea\x3b\xd7\xad\x26\x1f\x83\x44\xdd\xf7\x1f\xc0\x8b\x6d\x50\x99\x6e\xfb\x82\xd6\xbc\x15\xd0\x7d\xc9\xea\xb8\xa8\xa4\xd7\xb2\xf2\xcb\xe4\xd5\x79\xf5\x2f\x6f\x2b\xab\xad\x03\x42\x00\x00")
+
+func dataConfig_schema_v36JsonBytes() ([]byte, error) {
+	return bindataRead(
+		_dataConfig_schema_v36Json,
+		"data/config_schema_v3.6.json",
+	)
+}
+
+func dataConfig_schema_v36Json() (*asset, error) {
+	bytes, err := dataConfig_schema_v36JsonBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "data/config_schema_v3.6.json", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -251,6 +272,7 @@ var _bindata = map[string]func() (*asset, error){
 	"data/config_schema_v3.3.json": dataConfig_schema_v33Json,
 	"data/config_schema_v3.4.json": dataConfig_schema_v34Json,
 	"data/config_schema_v3.5.json": dataConfig_schema_v35Json,
+	"data/config_schema_v3.6.json": dataConfig_schema_v36Json,
 }
 
 // AssetDir returns the file names below a certain
@@ -300,6 +322,7 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		"config_schema_v3.3.json": &bintree{dataConfig_schema_v33Json, map[string]*bintree{}},
 		"config_schema_v3.4.json": &bintree{dataConfig_schema_v34Json, map[string]*bintree{}},
 		"config_schema_v3.5.json": &bintree{dataConfig_schema_v35Json, map[string]*bintree{}},
+		"config_schema_v3.6.json": &bintree{dataConfig_schema_v36Json, map[string]*bintree{}},
 	}},
 }}
 
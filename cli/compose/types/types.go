@@ -365,10 +365,14 @@ type CredentialSpecConfig struct {
 
 // FileObjectConfig is a config type for a file used by a service
 type FileObjectConfig struct {
-	Name     string
-	File     string
-	External External
-	Labels   Labels
+	Name           string
+	File           string
+	External       External
+	Labels         Labels
+	TemplateDriver string `mapstructure:"template_driver"`
+	// Source is the value of the x-source extension, which reads the value
+	// from a secret source (see secret/source) instead of File.
+	Source string `mapstructure:"x-source"`
 }
 
 // SecretConfig for a secret
@@ -27,6 +27,7 @@ type FakeCli struct {
 	in               *command.InStream
 	server           command.ServerInfo
 	notaryClientFunc notaryClientFuncType
+	templatingClient command.TemplatingClient
 }
 
 // NewFakeCli returns a fake for the command.Cli interface
@@ -110,3 +111,14 @@ func (c *FakeCli) NotaryClient(imgRefAndAuth trust.ImageRefAndAuth, actions []st
 	}
 	return nil, fmt.Errorf("no notary client available unless defined")
 }
+
+// SetTemplatingClient sets the client creating configs and secrets that have a
+// template driver
+func (c *FakeCli) SetTemplatingClient(templatingClient command.TemplatingClient) {
+	c.templatingClient = templatingClient
+}
+
+// TemplatingClient returns the client set with SetTemplatingClient
+func (c *FakeCli) TemplatingClient() command.TemplatingClient {
+	return c.templatingClient
+}
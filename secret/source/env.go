@@ -0,0 +1,27 @@
+package source
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+type envSource struct {
+	variable string
+}
+
+func newEnvSource(variable string) (Source, error) {
+	if variable == "" {
+		return nil, errors.New("env source requires the name of an environment variable")
+	}
+	return envSource{variable: variable}, nil
+}
+
+// Read returns the value of the environment variable.
+func (s envSource) Read() ([]byte, error) {
+	value, ok := os.LookupEnv(s.variable)
+	if !ok {
+		return nil, errors.Errorf("environment variable %s is not set", s.variable)
+	}
+	return []byte(value), nil
+}
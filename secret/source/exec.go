@@ -0,0 +1,43 @@
+package source
+
+import (
+	"bytes"
+	"os/exec"
+	"strings"
+
+	"github.com/mattn/go-shellwords"
+	"github.com/pkg/errors"
+)
+
+type execSource struct {
+	args []string
+}
+
+func newExecSource(command string) (Source, error) {
+	args, err := shellwords.Parse(command)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid command %q", command)
+	}
+	if len(args) == 0 {
+		return nil, errors.New("exec source requires a command")
+	}
+	return execSource{args: args}, nil
+}
+
+// Read runs the command, and returns its output without the trailing newline.
+func (s execSource) Read() ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(s.args[0], s.args[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, errors.Errorf("%s failed: %s", s.args[0], msg)
+		}
+		return nil, errors.Wrapf(err, "%s failed", s.args[0])
+	}
+	data := stdout.Bytes()
+	data = bytes.TrimSuffix(data, []byte("\n"))
+	data = bytes.TrimSuffix(data, []byte("\r"))
+	return data, nil
+}
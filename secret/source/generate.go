@@ -0,0 +1,106 @@
+package source
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	consonants = "bcdfghjklmnpqrstvwxz"
+	vowels     = "aeiou"
+)
+
+// generators generate random values of a given length.
+var generators = map[string]struct {
+	defaultLength int
+	generate      func(length int) ([]byte, error)
+}{
+	"bytes":      {defaultLength: 32, generate: randomBytes},
+	"base64":     {defaultLength: 32, generate: randomBase64},
+	"passphrase": {defaultLength: 6, generate: randomPassphrase},
+}
+
+type generateSource struct {
+	kind   string
+	length int
+}
+
+// newGenerateSource creates a source of random values. The argument has the
+// form kind[:length], where kind is one of:
+//
+//   - bytes: length random bytes (32 by default)
+//   - base64: length random bytes, encoded in base64 (32 by default)
+//   - passphrase: length random pronounceable words, separated by dashes
+//     (6 by default)
+func newGenerateSource(arg string) (Source, error) {
+	parts := strings.SplitN(arg, ":", 2)
+	generator, ok := generators[parts[0]]
+	if !ok {
+		return nil, errors.Errorf("invalid generator %q, expected one of bytes, base64 or passphrase", parts[0])
+	}
+	s := generateSource{kind: parts[0], length: generator.defaultLength}
+	if len(parts) == 2 {
+		length, err := strconv.Atoi(parts[1])
+		if err != nil || length < 1 {
+			return nil, errors.Errorf("invalid length %q for generator %s, expected a positive number", parts[1], parts[0])
+		}
+		s.length = length
+	}
+	return s, nil
+}
+
+// Read returns a new random value.
+func (s generateSource) Read() ([]byte, error) {
+	return generators[s.kind].generate(s.length)
+}
+
+func randomBytes(length int) ([]byte, error) {
+	data := make([]byte, length)
+	if _, err := rand.Read(data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func randomBase64(length int) ([]byte, error) {
+	data, err := randomBytes(length)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(base64.StdEncoding.EncodeToString(data)), nil
+}
+
+// randomPassphrase returns words made of three consonant-vowel pairs, which
+// gives about 20 bits of entropy per word.
+func randomPassphrase(words int) ([]byte, error) {
+	passphrase := make([]string, words)
+	for i := range passphrase {
+		word := make([]byte, 0, 6)
+		for j := 0; j < 3; j++ {
+			c, err := randomChar(consonants)
+			if err != nil {
+				return nil, err
+			}
+			v, err := randomChar(vowels)
+			if err != nil {
+				return nil, err
+			}
+			word = append(word, c, v)
+		}
+		passphrase[i] = string(word)
+	}
+	return []byte(strings.Join(passphrase, "-")), nil
+}
+
+func randomChar(chars string) (byte, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
+	if err != nil {
+		return 0, err
+	}
+	return chars[n.Int64()], nil
+}
@@ -0,0 +1,55 @@
+// Package source provides the values of secrets from sources other than
+// files, so that the value of a secret does not have to be written to disk.
+package source
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Source provides the value of a secret.
+type Source interface {
+	Read() ([]byte, error)
+}
+
+// Factory creates a Source from the argument of a source specification.
+type Factory func(arg string) (Source, error)
+
+var factories = map[string]Factory{}
+
+// Register makes a kind of source available under scheme. Registering the
+// same scheme twice replaces the previous factory.
+func Register(scheme string, factory Factory) {
+	factories[scheme] = factory
+}
+
+func init() {
+	Register("env", newEnvSource)
+	Register("exec", newExecSource)
+	Register("generate", newGenerateSource)
+}
+
+// Parse returns the Source of a specification of the form scheme:argument,
+// for example "env:DB_PASSWORD" or "exec:pass show db".
+func Parse(spec string) (Source, error) {
+	parts := strings.SplitN(spec, ":", 2)
+	if len(parts) != 2 {
+		return nil, errors.Errorf("invalid secret source %q: expected scheme:argument", spec)
+	}
+	factory, ok := factories[parts[0]]
+	if !ok {
+		return nil, errors.Errorf("invalid secret source %q: unknown scheme %q, expected one of %s", spec, parts[0], strings.Join(schemes(), ", "))
+	}
+	return factory(parts[1])
+}
+
+func schemes() []string {
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,103 @@
+package source
+
+import (
+	"encoding/base64"
+	"os"
+	"regexp"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseErrors(t *testing.T) {
+	testCases := []struct {
+		spec          string
+		expectedError string
+	}{
+		{spec: "DB_PASSWORD", expectedError: `invalid secret source "DB_PASSWORD": expected scheme:argument`},
+		{spec: "vault:secret/db", expectedError: `unknown scheme "vault", expected one of env, exec, generate`},
+		{spec: "env:", expectedError: "env source requires the name of an environment variable"},
+		{spec: "exec:", expectedError: "exec source requires a command"},
+		{spec: "generate:hex", expectedError: `invalid generator "hex"`},
+		{spec: "generate:base64:0", expectedError: `invalid length "0" for generator base64`},
+	}
+	for _, tc := range testCases {
+		_, err := Parse(tc.spec)
+		require.Error(t, err, tc.spec)
+		assert.Contains(t, err.Error(), tc.expectedError)
+	}
+}
+
+func TestEnvSource(t *testing.T) {
+	defer os.Unsetenv("SOURCE_TEST_PASSWORD")
+	os.Setenv("SOURCE_TEST_PASSWORD", "s3cr3t")
+
+	s, err := Parse("env:SOURCE_TEST_PASSWORD")
+	require.NoError(t, err)
+	data, err := s.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", string(data))
+
+	s, err = Parse("env:SOURCE_TEST_UNSET")
+	require.NoError(t, err)
+	_, err = s.Read()
+	assert.EqualError(t, err, "environment variable SOURCE_TEST_UNSET is not set")
+}
+
+func TestExecSource(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a POSIX shell")
+	}
+	s, err := Parse(`exec:sh -c "echo 's3cr3t value'"`)
+	require.NoError(t, err)
+	data, err := s.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t value", string(data))
+
+	s, err = Parse(`exec:sh -c "echo 'permission denied' >&2; exit 2"`)
+	require.NoError(t, err)
+	_, err = s.Read()
+	assert.EqualError(t, err, "sh failed: permission denied")
+}
+
+func TestGenerateSource(t *testing.T) {
+	s, err := Parse("generate:bytes:16")
+	require.NoError(t, err)
+	data, err := s.Read()
+	require.NoError(t, err)
+	assert.Len(t, data, 16)
+
+	s, err = Parse("generate:base64")
+	require.NoError(t, err)
+	data, err = s.Read()
+	require.NoError(t, err)
+	decoded, err := base64.StdEncoding.DecodeString(string(data))
+	require.NoError(t, err)
+	assert.Len(t, decoded, 32)
+
+	s, err = Parse("generate:passphrase:4")
+	require.NoError(t, err)
+	data, err = s.Read()
+	require.NoError(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^([a-z]{6}-){3}[a-z]{6}$`), string(data))
+}
+
+func TestRegister(t *testing.T) {
+	defer delete(factories, "static")
+	Register("static", func(arg string) (Source, error) {
+		return staticSource(arg), nil
+	})
+	s, err := Parse("static:value")
+	require.NoError(t, err)
+	data, err := s.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "value", string(data))
+}
+
+type staticSource string
+
+func (s staticSource) Read() ([]byte, error) {
+	return []byte(s), nil
+}
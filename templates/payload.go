@@ -0,0 +1,79 @@
+package templates
+
+import (
+	"strings"
+	"text/template"
+)
+
+// PayloadTemplateDriver is the name of the template driver of the daemon,
+// which expands config and secret payloads as Go templates.
+const PayloadTemplateDriver = "golang"
+
+// PayloadContext is the data a config or secret payload is expanded with by
+// the daemon. It describes the task the payload is mounted in.
+type PayloadContext struct {
+	Service PayloadService
+	Node    PayloadNode
+	Task    PayloadTask
+}
+
+// PayloadService describes the service of a task in a PayloadContext.
+type PayloadService struct {
+	ID     string
+	Name   string
+	Labels map[string]string
+}
+
+// PayloadNode describes the node of a task in a PayloadContext.
+type PayloadNode struct {
+	ID       string
+	Hostname string
+	Platform PayloadPlatform
+}
+
+// PayloadPlatform describes the platform of a node in a PayloadContext.
+type PayloadPlatform struct {
+	Architecture string
+	OS           string
+}
+
+// PayloadTask describes a task in a PayloadContext.
+type PayloadTask struct {
+	ID   string
+	Name string
+	Slot string
+}
+
+// PayloadFunctions look up the values returned by the `env`, `secret` and
+// `config` functions of a payload template. A nil function returns an empty
+// string.
+type PayloadFunctions struct {
+	Env    func(variable string) (string, error)
+	Secret func(target string) (string, error)
+	Config func(target string) (string, error)
+}
+
+// ParsePayload parses a config or secret payload with the template syntax of
+// the daemon. Only the functions that the daemon provides to payload
+// templates can be used.
+func ParsePayload(payload string, funcs PayloadFunctions) (*template.Template, error) {
+	funcMap := template.FuncMap{
+		"join": func(s ...string) string {
+			// first arg is sep, remaining args are strings to join
+			return strings.Join(s[1:], s[0])
+		},
+		"env":    payloadFunc(funcs.Env),
+		"secret": payloadFunc(funcs.Secret),
+		"config": payloadFunc(funcs.Config),
+	}
+	return template.New("expansion").Option("missingkey=error").Funcs(funcMap).Parse(payload)
+}
+
+func payloadFunc(f func(string) (string, error)) func(string) (string, error) {
+	if f != nil {
+		return f
+	}
+	return func(string) (string, error) {
+		return "", nil
+	}
+}
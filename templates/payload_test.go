@@ -0,0 +1,48 @@
+package templates
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePayload(t *testing.T) {
+	tm, err := ParsePayload(`{{.Service.Name}}.{{.Task.Slot}} on {{.Node.Hostname}} ({{.Node.Platform.OS}}): {{env "PORT"}} {{secret "password"}} {{join "," "a" "b"}}`, PayloadFunctions{
+		Env: func(variable string) (string, error) {
+			return "8080", nil
+		},
+		Secret: func(target string) (string, error) {
+			return "s3cr3t", nil
+		},
+	})
+	require.NoError(t, err)
+
+	ctx := PayloadContext{
+		Service: PayloadService{Name: "web"},
+		Node:    PayloadNode{Hostname: "node-1", Platform: PayloadPlatform{OS: "linux"}},
+		Task:    PayloadTask{Slot: "2"},
+	}
+	var b bytes.Buffer
+	require.NoError(t, tm.Execute(&b, ctx))
+	assert.Equal(t, "web.2 on node-1 (linux): 8080 s3cr3t a,b", b.String())
+}
+
+func TestParsePayloadErrors(t *testing.T) {
+	_, err := ParsePayload(`{{upper .Service.Name}}`, PayloadFunctions{})
+	assert.EqualError(t, err, `template: expansion:1: function "upper" not defined`)
+
+	_, err = ParsePayload(`{{.Service.Name`, PayloadFunctions{})
+	assert.Error(t, err)
+
+	tm, err := ParsePayload(`{{config "app.conf"}}`, PayloadFunctions{
+		Config: func(target string) (string, error) {
+			return "", errors.Errorf("config target %s not found", target)
+		},
+	})
+	require.NoError(t, err)
+	err = tm.Execute(&bytes.Buffer{}, PayloadContext{})
+	assert.Contains(t, err.Error(), "config target app.conf not found")
+}